@@ -53,7 +53,7 @@ func (c *Client) ListGlobalAccounts(ctx context.Context, pageNum, pageSize int)
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var result GlobalAccountsResponse
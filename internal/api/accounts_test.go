@@ -332,11 +332,13 @@ func TestListGlobalAccounts_Error(t *testing.T) {
 	defer server.Close()
 
 	c := &Client{
-		baseURL:        server.URL,
-		clientID:       "test-id",
-		apiKey:         "test-key",
-		httpClient:     http.DefaultClient,
-		circuitBreaker: &circuitBreaker{},
+		baseURL:               server.URL,
+		clientID:              "test-id",
+		apiKey:                "test-key",
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		rateLimitBaseDelay:    testRateLimitBaseDelay,
+		serverErrorRetryDelay: testServerErrorRetryDelay,
 		token: &TokenCache{
 			Token:     "test-token",
 			ExpiresAt: time.Now().Add(10 * time.Minute),
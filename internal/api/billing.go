@@ -3,7 +3,9 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
+	"net/http"
 	"net/url"
 	"strconv"
 )
@@ -245,7 +247,7 @@ func (c *Client) ListBillingCustomers(ctx context.Context, params BillingCustome
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var result BillingCustomersResponse
@@ -270,11 +272,11 @@ func (c *Client) GetBillingCustomer(ctx context.Context, customerID string) (*Bi
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var customer BillingCustomer
-	if err := json.NewDecoder(resp.Body).Decode(&customer); err != nil {
+	if err := decodeResource(resp.Body, &customer); err != nil {
 		return nil, err
 	}
 	return &customer, nil
@@ -293,11 +295,11 @@ func (c *Client) CreateBillingCustomer(ctx context.Context, req map[string]inter
 
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", Endpoints.BillingCustomersCreate.Path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", Endpoints.BillingCustomersCreate.Path, resp, ParseAPIError(body))
 	}
 
 	var customer BillingCustomer
-	if err := json.NewDecoder(resp.Body).Decode(&customer); err != nil {
+	if err := decodeMutation(resp.Body, &customer); err != nil {
 		return nil, err
 	}
 	return &customer, nil
@@ -318,13 +320,16 @@ func (c *Client) UpdateBillingCustomer(ctx context.Context, customerID string, r
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var customer BillingCustomer
-	if err := json.NewDecoder(resp.Body).Decode(&customer); err != nil {
+	if err := decodeMutation(resp.Body, &customer); err != nil {
 		return nil, err
 	}
+	if customer.ID == "" {
+		customer.ID = customerID
+	}
 	return &customer, nil
 }
 
@@ -347,7 +352,7 @@ func (c *Client) ListBillingProducts(ctx context.Context, params BillingProductL
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var result BillingProductsResponse
@@ -372,11 +377,11 @@ func (c *Client) GetBillingProduct(ctx context.Context, productID string) (*Bill
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var product BillingProduct
-	if err := json.NewDecoder(resp.Body).Decode(&product); err != nil {
+	if err := decodeResource(resp.Body, &product); err != nil {
 		return nil, err
 	}
 	return &product, nil
@@ -395,11 +400,11 @@ func (c *Client) CreateBillingProduct(ctx context.Context, req map[string]interf
 
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", Endpoints.BillingProductsCreate.Path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", Endpoints.BillingProductsCreate.Path, resp, ParseAPIError(body))
 	}
 
 	var product BillingProduct
-	if err := json.NewDecoder(resp.Body).Decode(&product); err != nil {
+	if err := decodeMutation(resp.Body, &product); err != nil {
 		return nil, err
 	}
 	return &product, nil
@@ -420,13 +425,16 @@ func (c *Client) UpdateBillingProduct(ctx context.Context, productID string, req
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var product BillingProduct
-	if err := json.NewDecoder(resp.Body).Decode(&product); err != nil {
+	if err := decodeMutation(resp.Body, &product); err != nil {
 		return nil, err
 	}
+	if product.ID == "" {
+		product.ID = productID
+	}
 	return &product, nil
 }
 
@@ -461,7 +469,7 @@ func (c *Client) ListBillingPrices(ctx context.Context, params BillingPriceListP
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var result BillingPricesResponse
@@ -486,11 +494,11 @@ func (c *Client) GetBillingPrice(ctx context.Context, priceID string) (*BillingP
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var price BillingPrice
-	if err := json.NewDecoder(resp.Body).Decode(&price); err != nil {
+	if err := decodeResource(resp.Body, &price); err != nil {
 		return nil, err
 	}
 	return &price, nil
@@ -509,11 +517,11 @@ func (c *Client) CreateBillingPrice(ctx context.Context, req map[string]interfac
 
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", Endpoints.BillingPricesCreate.Path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", Endpoints.BillingPricesCreate.Path, resp, ParseAPIError(body))
 	}
 
 	var price BillingPrice
-	if err := json.NewDecoder(resp.Body).Decode(&price); err != nil {
+	if err := decodeMutation(resp.Body, &price); err != nil {
 		return nil, err
 	}
 	return &price, nil
@@ -534,13 +542,16 @@ func (c *Client) UpdateBillingPrice(ctx context.Context, priceID string, req map
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var price BillingPrice
-	if err := json.NewDecoder(resp.Body).Decode(&price); err != nil {
+	if err := decodeMutation(resp.Body, &price); err != nil {
 		return nil, err
 	}
+	if price.ID == "" {
+		price.ID = priceID
+	}
 	return &price, nil
 }
 
@@ -577,7 +588,7 @@ func (c *Client) ListBillingInvoices(ctx context.Context, params BillingInvoiceL
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var result BillingInvoicesResponse
@@ -602,11 +613,11 @@ func (c *Client) GetBillingInvoice(ctx context.Context, invoiceID string) (*Bill
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var invoice BillingInvoice
-	if err := json.NewDecoder(resp.Body).Decode(&invoice); err != nil {
+	if err := decodeResource(resp.Body, &invoice); err != nil {
 		return nil, err
 	}
 	return &invoice, nil
@@ -625,11 +636,11 @@ func (c *Client) CreateBillingInvoice(ctx context.Context, req map[string]interf
 
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", Endpoints.BillingInvoicesCreate.Path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", Endpoints.BillingInvoicesCreate.Path, resp, ParseAPIError(body))
 	}
 
 	var invoice BillingInvoice
-	if err := json.NewDecoder(resp.Body).Decode(&invoice); err != nil {
+	if err := decodeMutation(resp.Body, &invoice); err != nil {
 		return nil, err
 	}
 	return &invoice, nil
@@ -648,11 +659,11 @@ func (c *Client) PreviewBillingInvoice(ctx context.Context, req map[string]inter
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", Endpoints.BillingInvoicesPreview.Path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", Endpoints.BillingInvoicesPreview.Path, resp, ParseAPIError(body))
 	}
 
 	var preview BillingInvoicePreview
-	if err := json.NewDecoder(resp.Body).Decode(&preview); err != nil {
+	if err := decodeMutation(resp.Body, &preview); err != nil {
 		return nil, err
 	}
 	return &preview, nil
@@ -679,7 +690,7 @@ func (c *Client) ListBillingInvoiceItems(ctx context.Context, invoiceID string,
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var result BillingInvoiceItemsResponse
@@ -707,11 +718,11 @@ func (c *Client) GetBillingInvoiceItem(ctx context.Context, invoiceID, itemID st
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var item BillingInvoiceItem
-	if err := json.NewDecoder(resp.Body).Decode(&item); err != nil {
+	if err := decodeResource(resp.Body, &item); err != nil {
 		return nil, err
 	}
 	return &item, nil
@@ -753,7 +764,7 @@ func (c *Client) ListBillingSubscriptions(ctx context.Context, params BillingSub
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var result BillingSubscriptionsResponse
@@ -778,11 +789,11 @@ func (c *Client) GetBillingSubscription(ctx context.Context, subscriptionID stri
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var sub BillingSubscription
-	if err := json.NewDecoder(resp.Body).Decode(&sub); err != nil {
+	if err := decodeResource(resp.Body, &sub); err != nil {
 		return nil, err
 	}
 	return &sub, nil
@@ -801,11 +812,11 @@ func (c *Client) CreateBillingSubscription(ctx context.Context, req map[string]i
 
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", Endpoints.BillingSubscriptionsCreate.Path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", Endpoints.BillingSubscriptionsCreate.Path, resp, ParseAPIError(body))
 	}
 
 	var sub BillingSubscription
-	if err := json.NewDecoder(resp.Body).Decode(&sub); err != nil {
+	if err := decodeMutation(resp.Body, &sub); err != nil {
 		return nil, err
 	}
 	return &sub, nil
@@ -826,13 +837,16 @@ func (c *Client) UpdateBillingSubscription(ctx context.Context, subscriptionID s
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var sub BillingSubscription
-	if err := json.NewDecoder(resp.Body).Decode(&sub); err != nil {
+	if err := decodeMutation(resp.Body, &sub); err != nil {
 		return nil, err
 	}
+	if sub.ID == "" {
+		sub.ID = subscriptionID
+	}
 	return &sub, nil
 }
 
@@ -851,13 +865,60 @@ func (c *Client) CancelBillingSubscription(ctx context.Context, subscriptionID s
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var sub BillingSubscription
-	if err := json.NewDecoder(resp.Body).Decode(&sub); err != nil {
+	if err := decodeMutation(resp.Body, &sub); err != nil {
+		return nil, err
+	}
+	if sub.ID == "" {
+		sub.ID = subscriptionID
+	}
+	return &sub, nil
+}
+
+// PauseBillingSubscription pauses an active billing subscription.
+func (c *Client) PauseBillingSubscription(ctx context.Context, subscriptionID string, req map[string]interface{}) (*BillingSubscription, error) {
+	return c.transitionBillingSubscription(ctx, subscriptionID, "pause", req)
+}
+
+// ResumeBillingSubscription resumes a paused billing subscription.
+func (c *Client) ResumeBillingSubscription(ctx context.Context, subscriptionID string, req map[string]interface{}) (*BillingSubscription, error) {
+	return c.transitionBillingSubscription(ctx, subscriptionID, "resume", req)
+}
+
+// transitionBillingSubscription posts a state transition (pause/resume) for a
+// subscription. Conflicting-state (409) responses are annotated with the
+// attempted action so callers see why the transition was rejected.
+func (c *Client) transitionBillingSubscription(ctx context.Context, subscriptionID, action string, req map[string]interface{}) (*BillingSubscription, error) {
+	if err := ValidateResourceID(subscriptionID, "subscription"); err != nil {
 		return nil, err
 	}
+
+	path := "/api/v1/subscriptions/" + url.PathEscape(subscriptionID) + "/" + action
+	resp, err := c.Post(ctx, path, req)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		apiErr := ParseAPIError(body)
+		if resp.StatusCode == http.StatusConflict {
+			return nil, WrapResponseError("POST", path, resp, fmt.Errorf("cannot %s subscription %s in its current state: %w", action, subscriptionID, apiErr))
+		}
+		return nil, WrapResponseError("POST", path, resp, apiErr)
+	}
+
+	var sub BillingSubscription
+	if err := decodeMutation(resp.Body, &sub); err != nil {
+		return nil, err
+	}
+	if sub.ID == "" {
+		sub.ID = subscriptionID
+	}
 	return &sub, nil
 }
 
@@ -882,7 +943,7 @@ func (c *Client) ListBillingSubscriptionItems(ctx context.Context, subscriptionI
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var result BillingSubscriptionItemsResponse
@@ -910,11 +971,11 @@ func (c *Client) GetBillingSubscriptionItem(ctx context.Context, subscriptionID,
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var item BillingSubscriptionItem
-	if err := json.NewDecoder(resp.Body).Decode(&item); err != nil {
+	if err := decodeResource(resp.Body, &item); err != nil {
 		return nil, err
 	}
 	return &item, nil
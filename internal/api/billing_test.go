@@ -2,8 +2,11 @@ package api
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -127,11 +130,13 @@ func TestListBillingCustomers_Error(t *testing.T) {
 	defer server.Close()
 
 	c := &Client{
-		baseURL:        server.URL,
-		clientID:       "test-id",
-		apiKey:         "test-key",
-		httpClient:     http.DefaultClient,
-		circuitBreaker: &circuitBreaker{},
+		baseURL:               server.URL,
+		clientID:              "test-id",
+		apiKey:                "test-key",
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		rateLimitBaseDelay:    testRateLimitBaseDelay,
+		serverErrorRetryDelay: testServerErrorRetryDelay,
 		token: &TokenCache{
 			Token:     "test-token",
 			ExpiresAt: time.Now().Add(10 * time.Minute),
@@ -1550,6 +1555,174 @@ func TestCancelBillingSubscription_InvalidID(t *testing.T) {
 	}
 }
 
+func TestPauseBillingSubscription_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if r.URL.Path != "/api/v1/subscriptions/sub_123/pause" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["resume_at"] != "2024-03-01T00:00:00Z" {
+			t.Errorf("resume_at = %v, want 2024-03-01T00:00:00Z", body["resume_at"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "sub_123", "status": "PAUSED"}`))
+	}))
+	defer server.Close()
+
+	c := &Client{
+		baseURL:        server.URL,
+		clientID:       "test-id",
+		apiKey:         "test-key",
+		httpClient:     http.DefaultClient,
+		circuitBreaker: &circuitBreaker{},
+		token: &TokenCache{
+			Token:     "test-token",
+			ExpiresAt: time.Now().Add(10 * time.Minute),
+		},
+	}
+
+	sub, err := c.PauseBillingSubscription(context.Background(), "sub_123", map[string]interface{}{
+		"resume_at": "2024-03-01T00:00:00Z",
+	})
+	if err != nil {
+		t.Fatalf("PauseBillingSubscription() error: %v", err)
+	}
+	if sub.Status != "PAUSED" {
+		t.Errorf("status = %q, want 'PAUSED'", sub.Status)
+	}
+}
+
+func TestResumeBillingSubscription_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if r.URL.Path != "/api/v1/subscriptions/sub_123/resume" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "sub_123", "status": "ACTIVE"}`))
+	}))
+	defer server.Close()
+
+	c := &Client{
+		baseURL:        server.URL,
+		clientID:       "test-id",
+		apiKey:         "test-key",
+		httpClient:     http.DefaultClient,
+		circuitBreaker: &circuitBreaker{},
+		token: &TokenCache{
+			Token:     "test-token",
+			ExpiresAt: time.Now().Add(10 * time.Minute),
+		},
+	}
+
+	sub, err := c.ResumeBillingSubscription(context.Background(), "sub_123", nil)
+	if err != nil {
+		t.Fatalf("ResumeBillingSubscription() error: %v", err)
+	}
+	if sub.Status != "ACTIVE" {
+		t.Errorf("status = %q, want 'ACTIVE'", sub.Status)
+	}
+}
+
+func TestResumeBillingSubscription_StateError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"code": "invalid_status_for_operation", "message": "Subscription is not paused"}`))
+	}))
+	defer server.Close()
+
+	c := &Client{
+		baseURL:        server.URL,
+		clientID:       "test-id",
+		apiKey:         "test-key",
+		httpClient:     http.DefaultClient,
+		circuitBreaker: &circuitBreaker{},
+		token: &TokenCache{
+			Token:     "test-token",
+			ExpiresAt: time.Now().Add(10 * time.Minute),
+		},
+	}
+
+	_, err := c.ResumeBillingSubscription(context.Background(), "sub_123", nil)
+	if err == nil {
+		t.Fatal("expected error for subscription that is not paused")
+	}
+	if !strings.Contains(err.Error(), "cannot resume subscription sub_123 in its current state") {
+		t.Errorf("error = %q, want state context", err.Error())
+	}
+	if !strings.Contains(err.Error(), "Subscription is not paused") {
+		t.Errorf("error = %q, want API message", err.Error())
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "invalid_status_for_operation" {
+		t.Errorf("expected wrapped APIError with code, got %v", err)
+	}
+}
+
+func TestResumeBillingSubscription_ValidationErrorPassesThrough(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code": "validation_error", "message": "resume_at must be in the future", "source": "resume_at"}`))
+	}))
+	defer server.Close()
+
+	c := &Client{
+		baseURL:        server.URL,
+		clientID:       "test-id",
+		apiKey:         "test-key",
+		httpClient:     http.DefaultClient,
+		circuitBreaker: &circuitBreaker{},
+		token: &TokenCache{
+			Token:     "test-token",
+			ExpiresAt: time.Now().Add(10 * time.Minute),
+		},
+	}
+
+	_, err := c.ResumeBillingSubscription(context.Background(), "sub_123", map[string]interface{}{"resume_at": "2000-01-01T00:00:00Z"})
+	if err == nil {
+		t.Fatal("expected a validation error")
+	}
+	if strings.Contains(err.Error(), "in its current state") {
+		t.Errorf("error = %q, a 400 should not be reported as a state conflict", err.Error())
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "validation_error" {
+		t.Errorf("expected wrapped APIError with code, got %v", err)
+	}
+}
+
+func TestPauseResumeBillingSubscription_InvalidID(t *testing.T) {
+	c := &Client{
+		baseURL:        "http://test.example.com",
+		clientID:       "test-id",
+		apiKey:         "test-key",
+		httpClient:     http.DefaultClient,
+		circuitBreaker: &circuitBreaker{},
+		token: &TokenCache{
+			Token:     "test-token",
+			ExpiresAt: time.Now().Add(10 * time.Minute),
+		},
+	}
+
+	if _, err := c.PauseBillingSubscription(context.Background(), "", nil); err == nil {
+		t.Error("expected error for empty subscription ID on pause, got nil")
+	}
+	if _, err := c.ResumeBillingSubscription(context.Background(), "../sub", nil); err == nil {
+		t.Error("expected error for invalid subscription ID on resume, got nil")
+	}
+}
+
 func TestListBillingSubscriptionItems_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/api/v1/subscriptions/sub_123/items" {
@@ -5,23 +5,33 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	mathrand "math/rand"
+	"net"
 	"net/http"
+	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 )
 
 const (
-	BaseURL    = "https://api.airwallex.com"
-	APIVersion = "2025-11-11"
+	BaseURL = "https://api.airwallex.com"
+	// DemoBaseURL is the sandbox (demo environment) API host.
+	DemoBaseURL = "https://api-demo.airwallex.com"
+	APIVersion  = "2025-11-11"
+	// APIVersionLatest passed to SetAPIVersion omits the x-api-version header,
+	// so requests use the account's default (latest) API version.
+	APIVersionLatest = "latest"
 
 	// DefaultHTTPTimeout is the default timeout for HTTP requests.
 	DefaultHTTPTimeout = 30 * time.Second
@@ -29,7 +39,8 @@ const (
 	// DefaultOperationTimeout is the default timeout for API operations.
 	DefaultOperationTimeout = 45 * time.Second
 
-	// TokenRefreshBuffer is how long before expiry to refresh the token.
+	// TokenRefreshBuffer is how long before expiry to refresh the token, unless
+	// overridden with WithTokenRefreshThreshold.
 	TokenRefreshBuffer = 60 * time.Second
 
 	// MaxRateLimitRetries is the maximum number of retries on 429 responses.
@@ -38,12 +49,31 @@ const (
 	// Max5xxRetries is the maximum retries for server errors on idempotent requests.
 	Max5xxRetries = 1
 
+	// MaxRetriesLimit is the largest value accepted by SetMaxRetries callers
+	// such as --max-retries.
+	MaxRetriesLimit = 10
+
+	// maxBackoffDoublings caps 429 backoff growth when retries are raised.
+	maxBackoffDoublings = 5
+
 	// RateLimitBaseDelay is the initial delay for rate limit exponential backoff.
 	RateLimitBaseDelay = 1 * time.Second
 
 	// ServerErrorRetryDelay is the delay before retrying on 5xx errors.
 	ServerErrorRetryDelay = 1 * time.Second
 
+	// MaxRequestTimeoutRetries is the maximum retries for idempotent requests
+	// whose attempt exceeded the per-request timeout.
+	MaxRequestTimeoutRetries = 2
+
+	// MaxConnectRetries is the maximum retries when the login request cannot
+	// reach the API host (DNS failure, connection refused).
+	MaxConnectRetries = 2
+
+	// ConnectRetryBaseDelay is the initial backoff before retrying a failed
+	// connection; it doubles on each attempt.
+	ConnectRetryBaseDelay = 500 * time.Millisecond
+
 	// IdempotencyKeyBytes is the number of random bytes for idempotency keys.
 	IdempotencyKeyBytes = 16
 
@@ -63,11 +93,6 @@ const (
 	CircuitBreakerResetTime = 30 * time.Second
 )
 
-var (
-	rateLimitBaseDelay    = RateLimitBaseDelay
-	serverErrorRetryDelay = ServerErrorRetryDelay
-)
-
 // withDefaultTimeout adds a timeout to the context if none exists.
 func withDefaultTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
 	// Check if context already has a deadline
@@ -108,19 +133,32 @@ func parseRetryAfter(header string, now time.Time) (delay time.Duration, ok bool
 	return 0, false
 }
 
+// retryAfterOr returns the delay from resp's Retry-After header (seconds or
+// HTTP date) when present and valid, otherwise fallback.
+func retryAfterOr(resp *http.Response, fallback time.Duration) time.Duration {
+	if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		return delay
+	}
+	return fallback
+}
+
 type circuitBreaker struct {
 	mu          sync.Mutex
 	failures    int
 	lastFailure time.Time
 	open        bool
+	// halfOpen is set once an open breaker's reset time passes: requests go
+	// through again, but the next failure re-opens it at once.
+	halfOpen bool
 }
 
 func (cb *circuitBreaker) recordSuccess() {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
-	wasOpen := cb.open
+	wasOpen := cb.open || cb.halfOpen
 	cb.failures = 0
 	cb.open = false
+	cb.halfOpen = false
 	if wasOpen {
 		slog.Info("circuit breaker reset")
 	}
@@ -131,8 +169,9 @@ func (cb *circuitBreaker) recordFailure() bool {
 	defer cb.mu.Unlock()
 	cb.failures++
 	cb.lastFailure = time.Now()
-	if cb.failures >= CircuitBreakerThreshold {
+	if cb.halfOpen || cb.failures >= CircuitBreakerThreshold {
 		cb.open = true
+		cb.halfOpen = false
 		slog.Warn("circuit breaker opened", "failures", cb.failures)
 		return true // circuit just opened
 	}
@@ -145,15 +184,65 @@ func (cb *circuitBreaker) isOpen() bool {
 	if !cb.open {
 		return false
 	}
-	// Check if reset time has passed
+	// Once the reset time has passed, let requests through on trial
 	if time.Since(cb.lastFailure) > CircuitBreakerResetTime {
 		cb.open = false
-		cb.failures = 0
+		cb.halfOpen = true
 		return false
 	}
 	return true
 }
 
+// CircuitState is a snapshot of a client's circuit breaker.
+type CircuitState struct {
+	Open bool `json:"open"`
+	// HalfOpen means the breaker opened but its reset time has passed:
+	// requests go through again, and a single failure re-opens it.
+	HalfOpen bool          `json:"half_open"`
+	Failures int           `json:"failures"` // consecutive 5xx failures
+	ResetIn  time.Duration `json:"-"`        // until an open breaker lets requests through again; 0 otherwise
+}
+
+// MarshalJSON writes ResetIn as whole seconds in reset_in_seconds.
+func (s CircuitState) MarshalJSON() ([]byte, error) {
+	type state CircuitState
+	return json.Marshal(struct {
+		state
+		ResetInSeconds int64 `json:"reset_in_seconds"`
+	}{state(s), int64(s.ResetIn.Round(time.Second) / time.Second)})
+}
+
+// String returns "closed", "open", or "half-open".
+func (s CircuitState) String() string {
+	switch {
+	case s.Open:
+		return "open"
+	case s.HalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+func (cb *circuitBreaker) state() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	st := CircuitState{Open: cb.open, HalfOpen: cb.halfOpen, Failures: cb.failures}
+	if cb.open {
+		st.ResetIn = CircuitBreakerResetTime - time.Since(cb.lastFailure)
+		if st.ResetIn <= 0 {
+			st = CircuitState{HalfOpen: true, Failures: cb.failures}
+		}
+	}
+	return st
+}
+
+// CircuitState reports the client's circuit breaker. The breaker lives only
+// as long as the client, so this reflects requests made by this process.
+func (c *Client) CircuitState() CircuitState {
+	return c.circuitBreaker.state()
+}
+
 type Client struct {
 	baseURL        string
 	clientID       string
@@ -161,8 +250,98 @@ type Client struct {
 	accountID      string // Optional: for x-login-as header (multi-account API keys)
 	token          *TokenCache
 	tokenMu        sync.RWMutex
+	refreshMu      sync.Mutex
+	refresh        *tokenRefresh // in-flight token refresh shared by concurrent callers
 	httpClient     *http.Client
 	circuitBreaker *circuitBreaker
+
+	// tokenStore, when set, persists the login token between invocations.
+	// tokenLoaded records that the store was read; tokenFromStore that the
+	// current token came from it and has not yet been accepted by the API.
+	tokenStore     TokenStore
+	tokenLoaded    bool
+	tokenFromStore bool
+
+	// retryIdempotent5xx allows a single 5xx retry for POST requests that carry
+	// an idempotency key (the server deduplicates on the key).
+	retryIdempotent5xx bool
+
+	// retryStatuses, when non-nil, replaces the default retryable statuses
+	// (429 and 5xx) with an explicit set (for --retry-status).
+	retryStatuses map[int]bool
+
+	// apiVersion overrides APIVersion in the x-api-version header;
+	// APIVersionLatest omits the header.
+	apiVersion string
+
+	// requestTimeout, when positive, bounds each HTTP attempt separately from
+	// the caller's overall deadline (for --request-timeout).
+	requestTimeout time.Duration
+
+	// limiter, when set, paces every HTTP attempt (for --rate-limit).
+	limiter *rateLimiter
+
+	// maxRetries, when maxRetriesSet, replaces MaxRateLimitRetries,
+	// Max5xxRetries, MaxRequestTimeoutRetries, and MaxConnectRetries (for
+	// --max-retries).
+	maxRetries    int
+	maxRetriesSet bool
+
+	// tokenRefreshThreshold is how long before expiry the token is refreshed;
+	// zero means TokenRefreshBuffer.
+	tokenRefreshThreshold time.Duration
+
+	// Retry delays; zero means RateLimitBaseDelay / ServerErrorRetryDelay /
+	// ConnectRetryBaseDelay.
+	rateLimitBaseDelay    time.Duration
+	serverErrorRetryDelay time.Duration
+	connectRetryDelay     time.Duration
+}
+
+// ClientOption configures optional Client behaviour at construction time.
+type ClientOption func(*Client)
+
+// WithRateLimitBaseDelay sets the base backoff before retrying a 429. It is
+// doubled on each attempt and jittered; the default is RateLimitBaseDelay.
+func WithRateLimitBaseDelay(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.rateLimitBaseDelay = d
+	}
+}
+
+// WithServerErrorRetryDelay sets the wait before retrying an idempotent
+// request after a server error; the default is ServerErrorRetryDelay.
+func WithServerErrorRetryDelay(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.serverErrorRetryDelay = d
+	}
+}
+
+// WithTokenRefreshThreshold sets how long before expiry the access token is
+// refreshed; the default is TokenRefreshBuffer. Long-running jobs can use a
+// larger margin so a token never expires mid-request.
+func WithTokenRefreshThreshold(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.tokenRefreshThreshold = d
+	}
+}
+
+// WithConnectRetryDelay sets the base backoff before retrying a login that
+// could not reach the API host; the default is ConnectRetryBaseDelay.
+func WithConnectRetryDelay(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.connectRetryDelay = d
+	}
+}
+
+// tokenRefresh is a single in-flight login; concurrent callers wait on done
+// and share err instead of logging in again.
+type tokenRefresh struct {
+	done chan struct{}
+	err  error
+	// canceled is set when the login failed because the caller that started
+	// it gave up; waiters with live contexts log in themselves instead.
+	canceled bool
 }
 
 type TokenCache struct {
@@ -170,31 +349,31 @@ type TokenCache struct {
 	ExpiresAt time.Time
 }
 
-func NewClient(clientID, apiKey string) (*Client, error) {
-	return newClient(BaseURL, clientID, apiKey, "", true)
+func NewClient(clientID, apiKey string, opts ...ClientOption) (*Client, error) {
+	return newClient(BaseURL, clientID, apiKey, "", true, opts...)
 }
 
 // NewClientWithAccount creates a client with an account ID for x-login-as header.
 // Use this when your API key has access to multiple accounts.
-func NewClientWithAccount(clientID, apiKey, accountID string) (*Client, error) {
-	return newClient(BaseURL, clientID, apiKey, accountID, true)
+func NewClientWithAccount(clientID, apiKey, accountID string, opts ...ClientOption) (*Client, error) {
+	return newClient(BaseURL, clientID, apiKey, accountID, true, opts...)
 }
 
 // NewClientWithBaseURL creates a client with a custom base URL (primarily for tests).
-func NewClientWithBaseURL(baseURL, clientID, apiKey string) (*Client, error) {
-	return newClient(baseURL, clientID, apiKey, "", false)
+func NewClientWithBaseURL(baseURL, clientID, apiKey string, opts ...ClientOption) (*Client, error) {
+	return newClient(baseURL, clientID, apiKey, "", false, opts...)
 }
 
 // NewClientWithBaseURLAndAccount creates a client with a custom base URL and account ID.
-func NewClientWithBaseURLAndAccount(baseURL, clientID, apiKey, accountID string) (*Client, error) {
-	return newClient(baseURL, clientID, apiKey, accountID, false)
+func NewClientWithBaseURLAndAccount(baseURL, clientID, apiKey, accountID string, opts ...ClientOption) (*Client, error) {
+	return newClient(baseURL, clientID, apiKey, accountID, false, opts...)
 }
 
-func newClient(baseURL, clientID, apiKey, accountID string, requireHTTPS bool) (*Client, error) {
+func newClient(baseURL, clientID, apiKey, accountID string, requireHTTPS bool, opts ...ClientOption) (*Client, error) {
 	if err := validateBaseURL(baseURL, requireHTTPS); err != nil {
 		return nil, err
 	}
-	return &Client{
+	c := &Client{
 		baseURL:   baseURL,
 		clientID:  clientID,
 		apiKey:    apiKey,
@@ -202,6 +381,7 @@ func newClient(baseURL, clientID, apiKey, accountID string, requireHTTPS bool) (
 		httpClient: &http.Client{
 			Timeout: DefaultHTTPTimeout,
 			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
 				MaxIdleConns:    MaxIdleConns,
 				MaxConnsPerHost: MaxConnsPerHost,
 				IdleConnTimeout: IdleConnTimeout,
@@ -212,7 +392,11 @@ func newClient(baseURL, clientID, apiKey, accountID string, requireHTTPS bool) (
 			},
 		},
 		circuitBreaker: &circuitBreaker{},
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
 }
 
 func validateBaseURL(baseURL string, requireHTTPS bool) error {
@@ -232,20 +416,282 @@ func validateBaseURL(baseURL string, requireHTTPS bool) error {
 }
 
 func (c *Client) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if dryRun := hooksFromContext(ctx).DryRun; dryRun != nil && req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return nil, interceptDryRun(req, dryRun)
+	}
+
 	if err := c.ensureValidToken(ctx); err != nil {
+		if IsUnreachableError(err) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("auth failed: %w", err)
 	}
 
 	c.tokenMu.RLock()
-	token := c.token.Token
+	token, fromStore := c.token.Token, c.tokenFromStore
 	c.tokenMu.RUnlock()
 
 	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("x-api-version", APIVersion)
-	req.Header.Set("Content-Type", "application/json")
+	switch c.apiVersion {
+	case "":
+		req.Header.Set("x-api-version", APIVersion)
+	case APIVersionLatest:
+		// No header: the account's default version applies.
+	default:
+		req.Header.Set("x-api-version", c.apiVersion)
+	}
+	// Keep a caller-supplied content type (e.g. form-encoded bodies).
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.doWithRetry(ctx, req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	if !fromStore || (req.Body != nil && req.GetBody == nil) {
+		return resp, nil
+	}
+	c.expireStoredToken(token)
+
+	// A persisted token may have been revoked (e.g. after key rotation), so
+	// log in again and retry once.
+	slog.Debug("stored api token rejected, logging in again")
+	closeBody(resp)
+	if req.GetBody != nil {
+		req.Body, err = req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to replay request body: %w", err)
+		}
+	}
+	if err := c.ensureValidToken(ctx); err != nil {
+		if IsUnreachableError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("auth failed: %w", err)
+	}
+	c.tokenMu.RLock()
+	req.Header.Set("Authorization", "Bearer "+c.token.Token)
+	c.tokenMu.RUnlock()
 	return c.doWithRetry(ctx, req)
 }
 
+// SetTokenStore persists login tokens in s and reuses a stored token while
+// it is valid. A nil store keeps tokens in memory only.
+func (c *Client) SetTokenStore(s TokenStore) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	c.tokenStore = s
+	c.tokenLoaded = false
+}
+
+// SetRetryIdempotent5xx opts POST requests with an x-idempotency-key header
+// into the single 5xx retry normally reserved for idempotent methods.
+func (c *Client) SetRetryIdempotent5xx(enabled bool) {
+	c.retryIdempotent5xx = enabled
+}
+
+// SetRetryStatuses overrides which HTTP statuses are retried. 429 keeps its
+// backoff and applies to all methods; any other listed status gets the single
+// retry reserved for idempotent requests. An empty list restores the default
+// (429 and all 5xx).
+func (c *Client) SetRetryStatuses(statuses []int) {
+	if len(statuses) == 0 {
+		c.retryStatuses = nil
+		return
+	}
+	c.retryStatuses = make(map[int]bool, len(statuses))
+	for _, status := range statuses {
+		c.retryStatuses[status] = true
+	}
+}
+
+// isRetryableStatus reports whether status is retryable under the current
+// configuration.
+func (c *Client) isRetryableStatus(status int) bool {
+	if c.retryStatuses == nil {
+		return status == 429 || status >= 500
+	}
+	return c.retryStatuses[status]
+}
+
+// SetRequestTimeout bounds each individual HTTP attempt, including reading
+// its response body. An idempotent
+// request whose attempt times out is retried up to MaxRequestTimeoutRetries
+// times; the caller's context still bounds the request as a whole. Zero
+// disables the per-attempt timeout.
+func (c *Client) SetRequestTimeout(d time.Duration) {
+	c.requestTimeout = d
+}
+
+// SetRateLimit caps outbound requests at perSecond, counting every attempt
+// including retries. Requests wait for capacity (or the context to end)
+// rather than failing. Zero or a negative value removes the limit.
+func (c *Client) SetRateLimit(perSecond float64) {
+	if perSecond <= 0 {
+		c.limiter = nil
+		return
+	}
+	if c.limiter != nil && c.limiter.rate == perSecond {
+		return
+	}
+	c.limiter = newRateLimiter(perSecond)
+}
+
+// SetMaxRetries caps retries on 429 and retryable 5xx responses, timed-out
+// attempts, and logins that cannot reach the API host at n; zero disables
+// retries. A negative n restores the defaults (MaxRateLimitRetries,
+// Max5xxRetries, MaxRequestTimeoutRetries, and MaxConnectRetries).
+func (c *Client) SetMaxRetries(n int) {
+	c.maxRetries = n
+	c.maxRetriesSet = n >= 0
+}
+
+// rateLimitRetryLimit returns the number of retries allowed on 429.
+func (c *Client) rateLimitRetryLimit() int {
+	if c.maxRetriesSet {
+		return c.maxRetries
+	}
+	return MaxRateLimitRetries
+}
+
+// serverErrorRetryLimit returns the number of retries allowed on 5xx.
+func (c *Client) serverErrorRetryLimit() int {
+	if c.maxRetriesSet {
+		return c.maxRetries
+	}
+	return Max5xxRetries
+}
+
+// timeoutRetryLimit returns the number of retries allowed on timed-out
+// attempts.
+func (c *Client) timeoutRetryLimit() int {
+	if c.maxRetriesSet {
+		return c.maxRetries
+	}
+	return MaxRequestTimeoutRetries
+}
+
+// connectRetryLimit returns the number of retries allowed when the login
+// request cannot reach the API host.
+func (c *Client) connectRetryLimit() int {
+	if c.maxRetriesSet {
+		return c.maxRetries
+	}
+	return MaxConnectRetries
+}
+
+// SetAPIVersion pins the dated API version sent as x-api-version.
+// APIVersionLatest omits the header; an empty string restores APIVersion,
+// the version the CLI's request shapes are written against.
+func (c *Client) SetAPIVersion(version string) {
+	c.apiVersion = version
+}
+
+// SetProxy sends requests through proxyURL instead of the proxy selected by
+// HTTP_PROXY, HTTPS_PROXY, and NO_PROXY. A nil URL restores the environment
+// proxy.
+func (c *Client) SetProxy(proxyURL *url.URL) {
+	t, ok := c.httpClient.Transport.(*http.Transport)
+	if !ok {
+		return
+	}
+	if proxyURL == nil {
+		t.Proxy = http.ProxyFromEnvironment
+		return
+	}
+	t.Proxy = http.ProxyURL(proxyURL)
+}
+
+// AddRootCAs trusts the PEM certificates in pemData in addition to the
+// system roots, e.g. for a corporate TLS-inspecting proxy. Verification stays
+// on; calls are additive.
+func (c *Client) AddRootCAs(pemData []byte) error {
+	t, ok := c.httpClient.Transport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("custom CA certificates need an *http.Transport")
+	}
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	pool := t.TLSClientConfig.RootCAs
+	if pool == nil {
+		var err error
+		if pool, err = x509.SystemCertPool(); err != nil {
+			pool = x509.NewCertPool()
+		}
+	}
+	if !pool.AppendCertsFromPEM(pemData) {
+		return fmt.Errorf("no PEM certificates found")
+	}
+	t.TLSClientConfig.RootCAs = pool
+	return nil
+}
+
+// ErrDryRun is returned for mutating requests intercepted by Hooks.DryRun.
+var ErrDryRun = errors.New("dry run: request not sent")
+
+// DryRunRequest describes a mutating request that dry-run mode held back.
+type DryRunRequest struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+func interceptDryRun(req *http.Request, dryRun func(DryRunRequest) error) error {
+	r := DryRunRequest{Method: req.Method, URL: req.URL.String()}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return err
+		}
+		data, err := io.ReadAll(body)
+		_ = body.Close()
+		if err != nil {
+			return err
+		}
+		if json.Valid(data) {
+			r.Body = data
+		}
+	}
+	if err := dryRun(r); err != nil {
+		return err
+	}
+	return ErrDryRun
+}
+
+// Hooks are per-invocation request outputs, carried in the request context
+// so a client shared by concurrent commands serves each with its own. Nil
+// fields are disabled.
+type Hooks struct {
+	// ShowURL receives the method and resolved URL of each request before
+	// it is sent (for --show-url).
+	ShowURL io.Writer
+	// ShowIdempotencyKey receives the x-idempotency-key of each financial
+	// create before it is sent (for --show-idempotency-key).
+	ShowIdempotencyKey io.Writer
+	// DryRun receives every non-GET request instead of it being sent; Do
+	// then returns ErrDryRun. Reads and the login request still go through
+	// (for --dry-run).
+	DryRun func(DryRunRequest) error
+	// Stats records request latency and retry counts, and reports the
+	// client's circuit breaker (for --stats).
+	Stats *RequestStats
+}
+
+type hooksContextKey struct{}
+
+// WithHooks attaches h to ctx for the requests made with it.
+func WithHooks(ctx context.Context, h Hooks) context.Context {
+	return context.WithValue(ctx, hooksContextKey{}, h)
+}
+
+// hooksFromContext returns the hooks attached to ctx; none are set without
+// WithHooks.
+func hooksFromContext(ctx context.Context) Hooks {
+	h, _ := ctx.Value(hooksContextKey{}).(Hooks)
+	return h
+}
+
 // BaseURL returns the configured base URL for the API.
 func (c *Client) BaseURL() string {
 	return c.baseURL
@@ -254,8 +700,17 @@ func (c *Client) BaseURL() string {
 // doWithRetry executes the request with retry logic:
 //   - 429: exponential backoff with jitter, max 3 retries (safe for all methods)
 //     Respects Retry-After header if present
-//   - 5xx: single retry after 1s, ONLY for idempotent methods (GET, HEAD, OPTIONS)
+//   - 5xx: single retry after 1s, ONLY for idempotent methods (GET, HEAD, OPTIONS),
+//     or, at most once, POST with an idempotency key when SetRetryIdempotent5xx
+//     is enabled.
+//     Respects Retry-After header if present
+//   - SetMaxRetries overrides both the 429 and 5xx retry counts
+//   - SetRateLimit paces every attempt, waiting before it is sent
 //   - 4xx: no retry
+//   - SetRetryStatuses replaces the retryable set (e.g. adding 408 or 425,
+//     which then get the single idempotent retry)
+//   - Per-attempt timeout (SetRequestTimeout): immediate retry, max 2, for
+//     idempotent requests only
 //   - Circuit breaker: stops requests after 5 consecutive 5xx errors
 func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Response, error) {
 	// Check circuit breaker before making request
@@ -263,15 +718,30 @@ func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Resp
 		return nil, fmt.Errorf("circuit breaker open: API experiencing issues, retry later")
 	}
 
+	hooks := hooksFromContext(ctx)
+	if hooks.ShowURL != nil {
+		_, _ = fmt.Fprintf(hooks.ShowURL, "%s %s\n", req.Method, req.URL.String())
+	}
+
 	var resp *http.Response
 	var err error
 
+	attempts := 0
+	if stats := hooks.Stats; stats != nil {
+		stats.setCircuit(c.circuitBreaker)
+		defer func() { stats.recordRequest(attempts) }()
+	}
+
 	// Separate retry counters for different error types
 	retries429 := 0
 	retries5xx := 0
+	retriesTimeout := 0
 
 	// Determine if the method is idempotent
 	isIdempotent := req.Method == "GET" || req.Method == "HEAD" || req.Method == "OPTIONS"
+	// keyedPOST opts a POST into a single 5xx retry; it never widens the
+	// timeout or retryable-4xx paths, which stay limited to idempotent methods.
+	keyedPOST := c.retryIdempotent5xx && req.Method == "POST" && req.Header.Get("x-idempotency-key") != ""
 
 	for {
 		// Log request details in debug mode
@@ -281,11 +751,38 @@ func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Resp
 			"has_body", req.Body != nil,
 		)
 
+		if c.limiter != nil {
+			if err := c.limiter.wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
 		start := time.Now()
-		resp, err = c.httpClient.Do(req)
+		resp, err = c.doAttempt(ctx, req)
+		attempts++
+		if hooks.Stats != nil {
+			hooks.Stats.recordLatency(time.Since(start))
+		}
 		if err != nil {
 			slog.Debug("api request failed", "error", err)
-			return nil, err
+			var timeoutErr *attemptTimeoutError
+			if !errors.As(err, &timeoutErr) {
+				return nil, err
+			}
+			// A timed-out POST may still have been processed, so only
+			// idempotent requests are retried.
+			if !isIdempotent || retriesTimeout >= c.timeoutRetryLimit() {
+				return nil, err
+			}
+			slog.Info("request attempt timed out, retrying", "timeout", c.requestTimeout, "attempt", retriesTimeout+1, "max_retries", c.timeoutRetryLimit())
+			if req.GetBody != nil {
+				req.Body, err = req.GetBody()
+				if err != nil {
+					return nil, fmt.Errorf("failed to replay request body: %w", err)
+				}
+			}
+			retriesTimeout++
+			continue
 		}
 
 		// Log response details in debug mode
@@ -294,34 +791,33 @@ func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Resp
 			"content_length", resp.ContentLength,
 		)
 
-		// 4xx errors (except 429): no retry
-		if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != 429 {
+		retryable := c.isRetryableStatus(resp.StatusCode)
+
+		// 4xx errors (except 429): no retry unless configured as retryable
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != 429 && !(retryable && isIdempotent) {
 			return resp, nil
 		}
 
 		// 429 rate limit: exponential backoff with jitter
 		// Safe to retry for all methods because the request wasn't processed
 		if resp.StatusCode == 429 {
-			if retries429 >= MaxRateLimitRetries {
+			if !retryable || retries429 >= c.rateLimitRetryLimit() {
 				return resp, nil
 			}
 
 			// Calculate backoff: base, 2x, 4x with jitter
-			baseDelay := rateLimitBaseDelay
+			baseDelay := c.rateLimitBaseDelay
 			if baseDelay <= 0 {
 				baseDelay = RateLimitBaseDelay
 			}
-			baseDelay *= time.Duration(1 << retries429)
+			baseDelay *= time.Duration(1 << min(retries429, maxBackoffDoublings))
 			//nolint:gosec // G404: jitter doesn't need crypto-strength randomness
 			jitter := time.Duration(mathrand.Int63n(int64(baseDelay / 2)))
 			delay := baseDelay + jitter
 
-			// Check for Retry-After header (seconds or HTTP date)
-			if retryAfterDelay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
-				delay = retryAfterDelay
-			}
+			delay = retryAfterOr(resp, delay)
 
-			slog.Info("rate limited, retrying", "delay", delay, "attempt", retries429+1, "max_retries", MaxRateLimitRetries)
+			slog.Info("rate limited, retrying", "delay", delay, "attempt", retries429+1, "max_retries", c.rateLimitRetryLimit())
 
 			closeBody(resp)
 
@@ -348,27 +844,38 @@ func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Resp
 		// 5xx errors: retry once after 1s, ONLY for idempotent operations
 		// Non-idempotent operations (POST, PUT, DELETE, PATCH) could have been partially
 		// processed, so retrying could cause duplicates (e.g., duplicate transfers)
-		if resp.StatusCode >= 500 {
+		// Configured retryable 4xx statuses (e.g. 408, 425) share this path.
+		if resp.StatusCode >= 400 {
 			// Record failure for circuit breaker
-			circuitOpened := c.circuitBreaker.recordFailure()
-			if circuitOpened {
-				slog.Warn("circuit breaker opened", "consecutive_failures", CircuitBreakerThreshold)
+			if resp.StatusCode >= 500 {
+				circuitOpened := c.circuitBreaker.recordFailure()
+				if circuitOpened {
+					slog.Warn("circuit breaker opened", "consecutive_failures", CircuitBreakerThreshold)
+				}
 			}
 
 			// Don't retry non-idempotent operations on 5xx
-			if !isIdempotent {
+			retryKeyedPOST := keyedPOST && resp.StatusCode >= 500
+			if !(isIdempotent || retryKeyedPOST) || !retryable {
 				return resp, nil
 			}
 
-			// Only retry once
-			if retries5xx >= Max5xxRetries {
+			// Only retry once by default; a keyed POST never retries more
+			// than once, even when SetMaxRetries allows more.
+			limit := c.serverErrorRetryLimit()
+			if !isIdempotent {
+				limit = min(limit, 1)
+			}
+			if retries5xx >= limit {
 				return resp, nil
 			}
 
-			delay := serverErrorRetryDelay
+			delay := c.serverErrorRetryDelay
 			if delay <= 0 {
 				delay = ServerErrorRetryDelay
 			}
+			// A 503 during maintenance may say when to come back.
+			delay = retryAfterOr(resp, delay)
 			slog.Info("retrying after server error", "status", resp.StatusCode, "attempt", retries5xx+1, "delay", delay)
 
 			closeBody(resp)
@@ -403,15 +910,143 @@ func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Resp
 	}
 }
 
+// attemptTimeoutError reports a single attempt that exceeded the
+// per-request timeout while the overall context was still live.
+type attemptTimeoutError struct {
+	timeout time.Duration
+	err     error
+}
+
+func (e *attemptTimeoutError) Error() string {
+	return fmt.Sprintf("request attempt timed out after %s (--request-timeout): %v", e.timeout, e.err)
+}
+
+func (e *attemptTimeoutError) Unwrap() error { return e.err }
+
+// doAttempt sends one HTTP attempt, bounded by requestTimeout when set. The
+// attempt's context is released when the response body is closed.
+func (c *Client) doAttempt(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if c.requestTimeout <= 0 {
+		return c.httpClient.Do(req)
+	}
+	attemptCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+	resp, err := c.httpClient.Do(req.WithContext(attemptCtx))
+	if err != nil {
+		cancel()
+		if ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+			return nil, &attemptTimeoutError{timeout: c.requestTimeout, err: err}
+		}
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose releases a per-attempt context once its body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// refreshThreshold returns how long before expiry the token is refreshed.
+func (c *Client) refreshThreshold() time.Duration {
+	if c.tokenRefreshThreshold > 0 {
+		return c.tokenRefreshThreshold
+	}
+	return TokenRefreshBuffer
+}
+
 func (c *Client) ensureValidToken(ctx context.Context) error {
 	c.tokenMu.RLock()
-	valid := c.token != nil && time.Now().Add(TokenRefreshBuffer).Before(c.token.ExpiresAt)
+	valid := c.token != nil && time.Now().Add(c.refreshThreshold()).Before(c.token.ExpiresAt)
 	c.tokenMu.RUnlock()
 
-	if valid {
+	if valid || c.loadStoredToken() {
 		return nil
 	}
-	return c.fetchToken(ctx)
+	return c.refreshToken(ctx)
+}
+
+// loadStoredToken adopts a still-valid token from the token store, reading
+// it at most once. Unreadable or expired tokens are ignored so the caller
+// logs in as usual.
+func (c *Client) loadStoredToken() bool {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	if c.tokenStore == nil || c.tokenLoaded {
+		return false
+	}
+	c.tokenLoaded = true
+
+	token, err := c.tokenStore.Load()
+	if err != nil {
+		slog.Debug("ignoring stored api token", "error", err)
+		return false
+	}
+	if token == nil || !time.Now().Add(c.refreshThreshold()).Before(token.ExpiresAt) {
+		return false
+	}
+	c.token = token
+	c.tokenFromStore = true
+	return true
+}
+
+// expireStoredToken marks the rejected stored token as expired so the next
+// ensureValidToken logs in again, and clears it from the token store. The
+// token stays set for concurrent callers; if another caller already replaced
+// it, nothing changes.
+func (c *Client) expireStoredToken(rejected string) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	if !c.tokenFromStore || c.token.Token != rejected {
+		return
+	}
+	c.token = &TokenCache{Token: rejected}
+	c.tokenFromStore = false
+	if err := c.tokenStore.Clear(); err != nil {
+		slog.Debug("failed to clear stored api token", "error", err)
+	}
+}
+
+// refreshToken fetches a new token, collapsing concurrent refreshes into a
+// single login whose result every caller shares. If that login fails only
+// because its caller's context ended, the remaining callers try again.
+func (c *Client) refreshToken(ctx context.Context) error {
+	for {
+		c.refreshMu.Lock()
+		call := c.refresh
+		if call == nil {
+			break
+		}
+		c.refreshMu.Unlock()
+		select {
+		case <-call.done:
+			if call.canceled && ctx.Err() == nil {
+				continue
+			}
+			return call.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	call := &tokenRefresh{done: make(chan struct{})}
+	c.refresh = call
+	c.refreshMu.Unlock()
+
+	call.err = c.fetchToken(ctx)
+	call.canceled = call.err != nil && ctx.Err() != nil
+
+	c.refreshMu.Lock()
+	c.refresh = nil
+	c.refreshMu.Unlock()
+	close(call.done)
+	return call.err
 }
 
 func (c *Client) fetchToken(ctx context.Context) error {
@@ -419,7 +1054,7 @@ func (c *Client) fetchToken(ctx context.Context) error {
 	defer c.tokenMu.Unlock()
 
 	// Double-check pattern: another goroutine might have fetched while we waited
-	if c.token != nil && time.Now().Add(TokenRefreshBuffer).Before(c.token.ExpiresAt) {
+	if c.token != nil && time.Now().Add(c.refreshThreshold()).Before(c.token.ExpiresAt) {
 		return nil
 	}
 
@@ -436,7 +1071,7 @@ func (c *Client) fetchToken(ctx context.Context) error {
 		req.Header.Set("x-login-as", c.accountID)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doLogin(ctx, req)
 	if err != nil {
 		return err
 	}
@@ -445,7 +1080,7 @@ func (c *Client) fetchToken(ctx context.Context) error {
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		apiErr := ParseAPIError(body)
-		return WrapError(req.Method, url, resp.StatusCode, fmt.Errorf("authentication failed: %s", apiErr.Error()))
+		return WrapResponseError(req.Method, url, resp, fmt.Errorf("authentication failed: %s", apiErr.Error()))
 	}
 
 	var result struct {
@@ -474,9 +1109,61 @@ func (c *Client) fetchToken(ctx context.Context) error {
 		Token:     result.Token,
 		ExpiresAt: expiresAt,
 	}
+	c.tokenFromStore = false
+	if c.tokenStore != nil {
+		if err := c.tokenStore.Save(c.token); err != nil {
+			slog.Debug("failed to persist api token", "error", err)
+		}
+	}
 	return nil
 }
 
+// doLogin sends the login request, which is the first request of every
+// command. Transient connection failures (DNS lookup errors, refused or
+// timed-out dials) are retried with backoff, since nothing reached the
+// server; once retries run out the error is an *UnreachableError. HTTP
+// responses, including auth failures, are returned as-is.
+func (c *Client) doLogin(ctx context.Context, req *http.Request) (*http.Response, error) {
+	delay := c.connectRetryDelay
+	if delay <= 0 {
+		delay = ConnectRetryBaseDelay
+	}
+	for attempt := 0; ; attempt++ {
+		resp, err := c.httpClient.Do(req)
+		if err == nil {
+			return resp, nil
+		}
+		if !isConnectError(err) || ctx.Err() != nil {
+			return nil, err
+		}
+		if attempt >= c.connectRetryLimit() {
+			return nil, &UnreachableError{Host: req.URL.Host, Attempts: attempt + 1, Err: err}
+		}
+
+		slog.Info("cannot reach api host, retrying", "host", req.URL.Host, "error", err, "delay", delay, "attempt", attempt+1, "max_retries", c.connectRetryLimit())
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		delay *= 2
+	}
+}
+
+// isConnectError reports whether err means the request never reached the
+// server: a DNS failure or a dial error such as connection refused.
+func isConnectError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
+
 // generateIdempotencyKey creates a unique key for idempotent operations.
 func generateIdempotencyKey() (string, error) {
 	b := make([]byte, IdempotencyKeyBytes)
@@ -486,6 +1173,25 @@ func generateIdempotencyKey() (string, error) {
 	return hex.EncodeToString(b), nil
 }
 
+// IdempotencyKey carries the x-idempotency-key of a financial create through
+// the request context. A non-empty Key is sent as-is; otherwise the client
+// generates one and stores it in Key so the caller can report it.
+type IdempotencyKey struct {
+	Key string
+}
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey attaches k to ctx for the financial create made with it.
+func WithIdempotencyKey(ctx context.Context, k *IdempotencyKey) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, k)
+}
+
+func idempotencyKeyFromContext(ctx context.Context) *IdempotencyKey {
+	k, _ := ctx.Value(idempotencyKeyContextKey{}).(*IdempotencyKey)
+	return k
+}
+
 var (
 	idempotencyPatternsOnce sync.Once
 	idempotencyPatterns     []string
@@ -566,6 +1272,18 @@ func (c *Client) Get(ctx context.Context, path string) (*http.Response, error) {
 }
 
 func (c *Client) Post(ctx context.Context, path string, body interface{}) (*http.Response, error) {
+	return c.postWithHeader(ctx, path, body, nil)
+}
+
+// PostIfMatch is Post with an If-Match precondition; an empty etag sends none.
+func (c *Client) PostIfMatch(ctx context.Context, path string, body interface{}, etag string) (*http.Response, error) {
+	if etag == "" {
+		return c.Post(ctx, path, body)
+	}
+	return c.postWithHeader(ctx, path, body, http.Header{"If-Match": {etag}})
+}
+
+func (c *Client) postWithHeader(ctx context.Context, path string, body interface{}, header http.Header) (*http.Response, error) {
 	var bodyReader io.Reader
 	var getBody func() (io.ReadCloser, error)
 	if body != nil {
@@ -586,11 +1304,30 @@ func (c *Client) Post(ctx context.Context, path string, body interface{}) (*http
 
 	// Add idempotency key for financial operations
 	if isFinancialOperation(path) {
-		idempotencyKey, err := generateIdempotencyKey()
-		if err != nil {
-			return nil, err
+		holder := idempotencyKeyFromContext(ctx)
+		var idempotencyKey string
+		if holder != nil && holder.Key != "" {
+			if err := ValidateIdempotencyKey(holder.Key); err != nil {
+				return nil, err
+			}
+			idempotencyKey = holder.Key
+		} else {
+			idempotencyKey, err = generateIdempotencyKey()
+			if err != nil {
+				return nil, err
+			}
+			if holder != nil {
+				holder.Key = idempotencyKey
+			}
 		}
 		req.Header.Set("x-idempotency-key", idempotencyKey)
+		if w := hooksFromContext(ctx).ShowIdempotencyKey; w != nil {
+			_, _ = fmt.Fprintf(w, "idempotency-key: %s (POST %s)\n", idempotencyKey, path)
+		}
+	}
+
+	for k, v := range header {
+		req.Header[k] = v
 	}
 
 	req.GetBody = getBody
@@ -627,11 +1364,80 @@ func (c *Client) doJSON(ctx context.Context, method, path string, body interface
 	if !statusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
 		apiErr := ParseAPIError(bodyBytes)
-		return WrapError(method, path, resp.StatusCode, NormalizeAPIError(resp.StatusCode, apiErr))
+		return WrapResponseError(method, path, resp, NormalizeAPIError(resp.StatusCode, apiErr))
 	}
 
 	if out == nil {
 		return nil
 	}
-	return json.NewDecoder(resp.Body).Decode(out)
+	if method == http.MethodGet {
+		return decodeResource(resp.Body, out)
+	}
+	return decodeMutation(resp.Body, out)
+}
+
+// decodeMutation decodes the response to a create, update, or other action
+// into out. Some actions succeed with an empty body (e.g. content-length: 0);
+// that is not an error, and out is left for the caller to fill from the
+// request where it can.
+func decodeMutation(r io.Reader, out interface{}) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	return json.Unmarshal(body, out)
+}
+
+// resourceEnvelopeKeys are the top-level keys single-resource responses are
+// wrapped in: the generic "data" and the resource names used by typed gets.
+var resourceEnvelopeKeys = map[string]bool{
+	"data":              true,
+	"account":           true,
+	"global_account":    true,
+	"transfer":          true,
+	"beneficiary":       true,
+	"customer":          true,
+	"product":           true,
+	"price":             true,
+	"invoice":           true,
+	"invoice_item":      true,
+	"subscription":      true,
+	"subscription_item": true,
+	"deposit":           true,
+	"quote":             true,
+	"conversion":        true,
+	"card":              true,
+	"cardholder":        true,
+	"transaction":       true,
+	"authorization":     true,
+	"dispute":           true,
+	"linked_account":    true,
+	"payer":             true,
+	"payment_link":      true,
+	"report":            true,
+	"webhook":           true,
+}
+
+// decodeResource decodes a single-resource response into out. Some endpoints
+// wrap the resource in an envelope such as {"data": {...}} or
+// {"beneficiary": {...}}; a lone envelope key (see resourceEnvelopeKeys)
+// holding an object is unwrapped so every typed get returns (and prints) the
+// bare resource. Any other single-key object is decoded as-is.
+func decodeResource(r io.Reader, out interface{}) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(body, &envelope); err == nil && len(envelope) == 1 {
+		for key, inner := range envelope {
+			if trimmed := bytes.TrimSpace(inner); resourceEnvelopeKeys[key] && len(trimmed) > 0 && trimmed[0] == '{' {
+				body = trimmed
+			}
+		}
+	}
+	return json.Unmarshal(body, out)
 }
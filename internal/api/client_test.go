@@ -1,12 +1,30 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
 	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/exec"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 )
@@ -16,11 +34,6 @@ const (
 	testServerErrorRetryDelay = 10 * time.Millisecond
 )
 
-func init() {
-	rateLimitBaseDelay = testRateLimitBaseDelay
-	serverErrorRetryDelay = testServerErrorRetryDelay
-}
-
 func TestClient_ensureValidToken_fetchesWhenEmpty(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/api/v1/authentication/login" {
@@ -119,6 +132,259 @@ func TestClient_ensureValidToken_refreshesExpiredToken(t *testing.T) {
 	}
 }
 
+func TestClient_ensureValidToken_customRefreshThreshold(t *testing.T) {
+	var logins atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logins.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"token": "new-token", "expires_at": "2099-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	newTestClient := func(opts ...ClientOption) *Client {
+		c, err := NewClientWithBaseURL(server.URL, "test-id", "test-key", opts...)
+		if err != nil {
+			t.Fatalf("NewClientWithBaseURL() error: %v", err)
+		}
+		c.token = &TokenCache{Token: "old-token", ExpiresAt: time.Now().Add(4 * time.Minute)}
+		return c
+	}
+
+	// The default 60s threshold keeps a token with 4 minutes left.
+	c := newTestClient()
+	if err := c.ensureValidToken(context.Background()); err != nil {
+		t.Fatalf("ensureValidToken() error: %v", err)
+	}
+	if c.token.Token != "old-token" || logins.Load() != 0 {
+		t.Errorf("default threshold: token=%q logins=%d, want old-token and no login", c.token.Token, logins.Load())
+	}
+
+	c = newTestClient(WithTokenRefreshThreshold(5 * time.Minute))
+	if err := c.ensureValidToken(context.Background()); err != nil {
+		t.Fatalf("ensureValidToken() error: %v", err)
+	}
+	if c.token.Token != "new-token" || logins.Load() != 1 {
+		t.Errorf("5m threshold: token=%q logins=%d, want new-token after one login", c.token.Token, logins.Load())
+	}
+}
+
+func TestClient_ensureValidToken_concurrentFirstLoginLogsInOnce(t *testing.T) {
+	var logins atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logins.Add(1)
+		// Hold the login until every goroutine has had a chance to call in.
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"token": "shared-token", "expires_at": "2099-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	c := &Client{
+		baseURL:        server.URL,
+		clientID:       "test-id",
+		apiKey:         "test-key",
+		httpClient:     http.DefaultClient,
+		circuitBreaker: &circuitBreaker{},
+	}
+
+	const workers = 20
+	var wg sync.WaitGroup
+	var started sync.WaitGroup
+	errs := make(chan error, workers)
+	started.Add(workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			started.Done()
+			errs <- c.ensureValidToken(context.Background())
+		}()
+	}
+	started.Wait()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("ensureValidToken() error: %v", err)
+		}
+	}
+	if got := logins.Load(); got != 1 {
+		t.Errorf("login calls = %d, want exactly 1 for %d concurrent callers", got, workers)
+	}
+	if c.token == nil || c.token.Token != "shared-token" {
+		t.Errorf("token = %+v, want shared-token", c.token)
+	}
+}
+
+func TestClient_refreshToken_waiterRetriesWhenLeaderCanceled(t *testing.T) {
+	var logins atomic.Int32
+	firstLogin := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if logins.Add(1) == 1 {
+			// Hold the first login until its caller gives up.
+			close(firstLogin)
+			<-r.Context().Done()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"token": "waiter-token", "expires_at": "2099-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	c := &Client{
+		baseURL:        server.URL,
+		clientID:       "test-id",
+		apiKey:         "test-key",
+		httpClient:     http.DefaultClient,
+		circuitBreaker: &circuitBreaker{},
+	}
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() { leaderErr <- c.ensureValidToken(leaderCtx) }()
+	<-firstLogin
+
+	waiterErr := make(chan error, 1)
+	go func() { waiterErr <- c.refreshToken(context.Background()) }()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("leader error = %v, want context.Canceled", err)
+	}
+	if err := <-waiterErr; err != nil {
+		t.Fatalf("waiter error = %v, want it to log in itself", err)
+	}
+	if c.token == nil || c.token.Token != "waiter-token" {
+		t.Errorf("token = %+v, want waiter-token", c.token)
+	}
+	if got := logins.Load(); got != 2 {
+		t.Errorf("login calls = %d, want 2", got)
+	}
+}
+
+func TestClient_ensureValidToken_concurrentRefreshLogsInOnce(t *testing.T) {
+	var logins, requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == Endpoints.Login.Path {
+			logins.Add(1)
+			// Hold the login open so every goroutine sees the expired token.
+			time.Sleep(50 * time.Millisecond)
+			_, _ = w.Write([]byte(`{"token": "new-token", "expires_at": "2099-01-01T00:00:00Z"}`))
+			return
+		}
+		requests.Add(1)
+		if got := r.Header.Get("Authorization"); got != "Bearer new-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := &Client{
+		baseURL:        server.URL,
+		clientID:       "test-id",
+		apiKey:         "test-key",
+		httpClient:     http.DefaultClient,
+		circuitBreaker: &circuitBreaker{},
+		token: &TokenCache{
+			Token:     "old-token",
+			ExpiresAt: time.Now().Add(-time.Minute),
+		},
+	}
+
+	const workers = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := c.Get(context.Background(), "/api/v1/test")
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer closeBody(resp)
+			if resp.StatusCode != http.StatusOK {
+				errs <- fmt.Errorf("status = %d", resp.StatusCode)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("request failed: %v", err)
+	}
+	if got := logins.Load(); got != 1 {
+		t.Errorf("login calls = %d, want 1", got)
+	}
+	if got := requests.Load(); got != workers {
+		t.Errorf("API requests = %d, want %d", got, workers)
+	}
+}
+
+func TestClientOptions_RetryDelays(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == Endpoints.Login.Path {
+			_, _ = w.Write([]byte(`{"token": "tok", "expires_at": "2099-01-01T00:00:00Z"}`))
+			return
+		}
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClientWithBaseURL(server.URL, "test-id", "test-key",
+		WithRateLimitBaseDelay(time.Millisecond),
+		WithServerErrorRetryDelay(time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("NewClientWithBaseURL() error: %v", err)
+	}
+	if c.rateLimitBaseDelay != time.Millisecond || c.serverErrorRetryDelay != time.Millisecond {
+		t.Fatalf("options not applied: rateLimit=%s serverError=%s", c.rateLimitBaseDelay, c.serverErrorRetryDelay)
+	}
+
+	start := time.Now()
+	resp, err := c.Get(context.Background(), "/api/v1/test")
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	defer closeBody(resp)
+	elapsed := time.Since(start)
+
+	if resp.StatusCode != http.StatusOK || calls.Load() != 3 {
+		t.Errorf("status=%d calls=%d, want 200 after 3 calls", resp.StatusCode, calls.Load())
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Errorf("retries took %s, want the configured millisecond delays", elapsed)
+	}
+
+	defaults, err := NewClientWithBaseURL(server.URL, "test-id", "test-key")
+	if err != nil {
+		t.Fatalf("NewClientWithBaseURL() error: %v", err)
+	}
+	if defaults.rateLimitBaseDelay != 0 || defaults.serverErrorRetryDelay != 0 {
+		t.Errorf("default client should fall back to package delays, got %s/%s", defaults.rateLimitBaseDelay, defaults.serverErrorRetryDelay)
+	}
+}
+
 func TestClient_doWithRetry_noRetryOn4xx(t *testing.T) {
 	callCount := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -171,11 +437,13 @@ func TestClient_doWithRetry_retriesOn429WithExponentialBackoff(t *testing.T) {
 	defer server.Close()
 
 	c := &Client{
-		baseURL:        server.URL,
-		clientID:       "test-id",
-		apiKey:         "test-key",
-		httpClient:     http.DefaultClient,
-		circuitBreaker: &circuitBreaker{},
+		baseURL:               server.URL,
+		clientID:              "test-id",
+		apiKey:                "test-key",
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		rateLimitBaseDelay:    testRateLimitBaseDelay,
+		serverErrorRetryDelay: testServerErrorRetryDelay,
 
 		token: &TokenCache{
 			Token:     "test-token",
@@ -217,11 +485,13 @@ func TestClient_doWithRetry_maxRetriesOn429(t *testing.T) {
 	defer server.Close()
 
 	c := &Client{
-		baseURL:        server.URL,
-		clientID:       "test-id",
-		apiKey:         "test-key",
-		httpClient:     http.DefaultClient,
-		circuitBreaker: &circuitBreaker{},
+		baseURL:               server.URL,
+		clientID:              "test-id",
+		apiKey:                "test-key",
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		rateLimitBaseDelay:    testRateLimitBaseDelay,
+		serverErrorRetryDelay: testServerErrorRetryDelay,
 
 		token: &TokenCache{
 			Token:     "test-token",
@@ -245,6 +515,88 @@ func TestClient_doWithRetry_maxRetriesOn429(t *testing.T) {
 	}
 }
 
+func TestClient_doWithRetry_maxRetriesOverride(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxRetries int
+		status     int
+		wantCalls  int
+	}{
+		{"zero disables 429 retries", 0, http.StatusTooManyRequests, 1},
+		{"zero disables 5xx retries", 0, http.StatusServiceUnavailable, 1},
+		{"raises 429 retries", 5, http.StatusTooManyRequests, 6},
+		{"raises 5xx retries", 2, http.StatusServiceUnavailable, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callCount := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				callCount++
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			c := &Client{
+				baseURL:               server.URL,
+				httpClient:            http.DefaultClient,
+				circuitBreaker:        &circuitBreaker{},
+				rateLimitBaseDelay:    testRateLimitBaseDelay,
+				serverErrorRetryDelay: testServerErrorRetryDelay,
+			}
+			c.SetMaxRetries(tt.maxRetries)
+
+			req, _ := http.NewRequest("GET", server.URL+"/test", nil)
+			resp, err := c.doWithRetry(context.Background(), req)
+			if err != nil {
+				t.Fatalf("doWithRetry() error: %v", err)
+			}
+			closeBody(resp)
+
+			if callCount != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", callCount, tt.wantCalls)
+			}
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+		})
+	}
+}
+
+func TestClient_doWithRetry_maxRetriesKeepsCircuitBreaker(t *testing.T) {
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	c := &Client{
+		baseURL:               server.URL,
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		serverErrorRetryDelay: testServerErrorRetryDelay,
+	}
+	c.SetMaxRetries(CircuitBreakerThreshold)
+
+	req, _ := http.NewRequest("GET", server.URL+"/test", nil)
+	resp, err := c.doWithRetry(context.Background(), req)
+	if err != nil {
+		t.Fatalf("doWithRetry() error: %v", err)
+	}
+	closeBody(resp)
+	if callCount != CircuitBreakerThreshold+1 {
+		t.Errorf("calls = %d, want %d", callCount, CircuitBreakerThreshold+1)
+	}
+
+	req, _ = http.NewRequest("GET", server.URL+"/test", nil)
+	if _, err := c.doWithRetry(context.Background(), req); err == nil || !strings.Contains(err.Error(), "circuit breaker open") {
+		t.Errorf("expected circuit breaker to open after %d failures, got %v", CircuitBreakerThreshold, err)
+	}
+	if callCount != CircuitBreakerThreshold+1 {
+		t.Errorf("open circuit should not send requests, calls = %d", callCount)
+	}
+}
+
 func TestClient_doWithRetry_respectsRetryAfterHeader(t *testing.T) {
 	callCount := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -261,11 +613,13 @@ func TestClient_doWithRetry_respectsRetryAfterHeader(t *testing.T) {
 	defer server.Close()
 
 	c := &Client{
-		baseURL:        server.URL,
-		clientID:       "test-id",
-		apiKey:         "test-key",
-		httpClient:     http.DefaultClient,
-		circuitBreaker: &circuitBreaker{},
+		baseURL:               server.URL,
+		clientID:              "test-id",
+		apiKey:                "test-key",
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		rateLimitBaseDelay:    testRateLimitBaseDelay,
+		serverErrorRetryDelay: testServerErrorRetryDelay,
 
 		token: &TokenCache{
 			Token:     "test-token",
@@ -318,11 +672,13 @@ func TestClient_doWithRetry_respectsRetryAfterHeaderDate(t *testing.T) {
 	defer server.Close()
 
 	c := &Client{
-		baseURL:        server.URL,
-		clientID:       "test-id",
-		apiKey:         "test-key",
-		httpClient:     http.DefaultClient,
-		circuitBreaker: &circuitBreaker{},
+		baseURL:               server.URL,
+		clientID:              "test-id",
+		apiKey:                "test-key",
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		rateLimitBaseDelay:    testRateLimitBaseDelay,
+		serverErrorRetryDelay: testServerErrorRetryDelay,
 
 		token: &TokenCache{
 			Token:     "test-token",
@@ -424,11 +780,13 @@ func TestClient_doWithRetry_retriesOnce5xx(t *testing.T) {
 	defer server.Close()
 
 	c := &Client{
-		baseURL:        server.URL,
-		clientID:       "test-id",
-		apiKey:         "test-key",
-		httpClient:     http.DefaultClient,
-		circuitBreaker: &circuitBreaker{},
+		baseURL:               server.URL,
+		clientID:              "test-id",
+		apiKey:                "test-key",
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		rateLimitBaseDelay:    testRateLimitBaseDelay,
+		serverErrorRetryDelay: testServerErrorRetryDelay,
 
 		token: &TokenCache{
 			Token:     "test-token",
@@ -507,6 +865,61 @@ func TestClient_doWithRetry_contextCancellationDuring5xxRetry(t *testing.T) {
 	}
 }
 
+func TestClient_doWithRetry_5xxRespectsRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter func() string
+		minDelay   time.Duration
+	}{
+		{"seconds", func() string { return "1" }, 900 * time.Millisecond},
+		{"http date", func() string { return time.Now().Add(2 * time.Second).UTC().Format(http.TimeFormat) }, 900 * time.Millisecond},
+		{"invalid falls back", func() string { return "soon" }, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callCount := 0
+			var retryAt time.Time
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				callCount++
+				if callCount == 1 {
+					w.Header().Set("Retry-After", tt.retryAfter())
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				retryAt = time.Now()
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			c := &Client{
+				baseURL:               server.URL,
+				httpClient:            http.DefaultClient,
+				circuitBreaker:        &circuitBreaker{},
+				serverErrorRetryDelay: testServerErrorRetryDelay,
+			}
+
+			start := time.Now()
+			req, _ := http.NewRequest("GET", server.URL+"/test", nil)
+			resp, err := c.doWithRetry(context.Background(), req)
+			if err != nil {
+				t.Fatalf("doWithRetry() error: %v", err)
+			}
+			closeBody(resp)
+
+			if callCount != 2 || resp.StatusCode != http.StatusOK {
+				t.Fatalf("calls = %d, status = %d; want a successful retry", callCount, resp.StatusCode)
+			}
+			waited := retryAt.Sub(start)
+			if waited < tt.minDelay {
+				t.Errorf("retried after %s, want at least %s from Retry-After", waited, tt.minDelay)
+			}
+			if tt.minDelay == 0 && waited > 500*time.Millisecond {
+				t.Errorf("retried after %s, want the short default delay", waited)
+			}
+		})
+	}
+}
+
 func TestClient_doWithRetry_noSecondRetryOn5xx(t *testing.T) {
 	callCount := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -517,11 +930,13 @@ func TestClient_doWithRetry_noSecondRetryOn5xx(t *testing.T) {
 	defer server.Close()
 
 	c := &Client{
-		baseURL:        server.URL,
-		clientID:       "test-id",
-		apiKey:         "test-key",
-		httpClient:     http.DefaultClient,
-		circuitBreaker: &circuitBreaker{},
+		baseURL:               server.URL,
+		clientID:              "test-id",
+		apiKey:                "test-key",
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		rateLimitBaseDelay:    testRateLimitBaseDelay,
+		serverErrorRetryDelay: testServerErrorRetryDelay,
 
 		token: &TokenCache{
 			Token:     "test-token",
@@ -605,11 +1020,13 @@ func TestClient_Post_retriesOn429WithBodyReplay(t *testing.T) {
 	defer server.Close()
 
 	c := &Client{
-		baseURL:        server.URL,
-		clientID:       "test-id",
-		apiKey:         "test-key",
-		httpClient:     http.DefaultClient,
-		circuitBreaker: &circuitBreaker{},
+		baseURL:               server.URL,
+		clientID:              "test-id",
+		apiKey:                "test-key",
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		rateLimitBaseDelay:    testRateLimitBaseDelay,
+		serverErrorRetryDelay: testServerErrorRetryDelay,
 
 		token: &TokenCache{
 			Token:     "test-token",
@@ -665,11 +1082,13 @@ func TestClient_doWithRetry_mixedErrors5xxThen429(t *testing.T) {
 	defer server.Close()
 
 	c := &Client{
-		baseURL:        server.URL,
-		clientID:       "test-id",
-		apiKey:         "test-key",
-		httpClient:     http.DefaultClient,
-		circuitBreaker: &circuitBreaker{},
+		baseURL:               server.URL,
+		clientID:              "test-id",
+		apiKey:                "test-key",
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		rateLimitBaseDelay:    testRateLimitBaseDelay,
+		serverErrorRetryDelay: testServerErrorRetryDelay,
 
 		token: &TokenCache{
 			Token:     "test-token",
@@ -712,11 +1131,13 @@ func TestClient_doWithRetry_mixedErrors429Then5xx(t *testing.T) {
 	defer server.Close()
 
 	c := &Client{
-		baseURL:        server.URL,
-		clientID:       "test-id",
-		apiKey:         "test-key",
-		httpClient:     http.DefaultClient,
-		circuitBreaker: &circuitBreaker{},
+		baseURL:               server.URL,
+		clientID:              "test-id",
+		apiKey:                "test-key",
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		rateLimitBaseDelay:    testRateLimitBaseDelay,
+		serverErrorRetryDelay: testServerErrorRetryDelay,
 
 		token: &TokenCache{
 			Token:     "test-token",
@@ -756,11 +1177,13 @@ func TestClient_doWithRetry_GET_retriesOn5xx(t *testing.T) {
 	defer server.Close()
 
 	c := &Client{
-		baseURL:        server.URL,
-		clientID:       "test-id",
-		apiKey:         "test-key",
-		httpClient:     http.DefaultClient,
-		circuitBreaker: &circuitBreaker{},
+		baseURL:               server.URL,
+		clientID:              "test-id",
+		apiKey:                "test-key",
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		rateLimitBaseDelay:    testRateLimitBaseDelay,
+		serverErrorRetryDelay: testServerErrorRetryDelay,
 
 		token: &TokenCache{
 			Token:     "test-token",
@@ -821,6 +1244,84 @@ func TestClient_doWithRetry_POST_noRetryOn5xx(t *testing.T) {
 	}
 }
 
+// TestClient_doWithRetry_POST_retryIdempotent5xx verifies that POST requests with an
+// idempotency key are retried once on 5xx only when the opt-in is enabled
+func TestClient_doWithRetry_POST_retryIdempotent5xx(t *testing.T) {
+	tests := []struct {
+		name       string
+		optIn      bool
+		key        string
+		maxRetries int
+		wantCalls  int
+	}{
+		{name: "opt-in with key retries once", optIn: true, key: "stable-key", maxRetries: -1, wantCalls: 2},
+		{name: "opt-in with key ignores higher max retries", optIn: true, key: "stable-key", maxRetries: 3, wantCalls: 2},
+		{name: "opt-in with key honors max retries 0", optIn: true, key: "stable-key", maxRetries: 0, wantCalls: 1},
+		{name: "no opt-in with key does not retry", optIn: false, key: "stable-key", maxRetries: -1, wantCalls: 1},
+		{name: "opt-in without key does not retry", optIn: true, key: "", maxRetries: -1, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callCount := 0
+			var keys []string
+			var bodies []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				callCount++
+				keys = append(keys, r.Header.Get("x-idempotency-key"))
+				body, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+				w.WriteHeader(http.StatusBadGateway)
+				_, _ = w.Write([]byte(`{"error": "bad gateway"}`))
+			}))
+			defer server.Close()
+
+			c := &Client{
+				baseURL:               server.URL,
+				clientID:              "test-id",
+				apiKey:                "test-key",
+				httpClient:            http.DefaultClient,
+				circuitBreaker:        &circuitBreaker{},
+				rateLimitBaseDelay:    testRateLimitBaseDelay,
+				serverErrorRetryDelay: testServerErrorRetryDelay,
+
+				token: &TokenCache{
+					Token:     "test-token",
+					ExpiresAt: time.Now().Add(10 * time.Minute),
+				},
+			}
+			c.SetRetryIdempotent5xx(tt.optIn)
+			c.SetMaxRetries(tt.maxRetries)
+
+			payload := `{"amount":"10"}`
+			req, _ := http.NewRequest("POST", server.URL+"/test", strings.NewReader(payload))
+			if tt.key != "" {
+				req.Header.Set("x-idempotency-key", tt.key)
+			}
+			resp, err := c.doWithRetry(context.Background(), req)
+			if err != nil {
+				t.Fatalf("doWithRetry() error: %v", err)
+			}
+			defer closeBody(resp)
+
+			if callCount != tt.wantCalls {
+				t.Errorf("expected %d calls, got %d", tt.wantCalls, callCount)
+			}
+			for i := range keys {
+				if keys[i] != tt.key {
+					t.Errorf("call %d idempotency key = %q, want %q", i+1, keys[i], tt.key)
+				}
+				if bodies[i] != payload {
+					t.Errorf("call %d body = %q, want %q", i+1, bodies[i], payload)
+				}
+			}
+			if resp.StatusCode != http.StatusBadGateway {
+				t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusBadGateway)
+			}
+		})
+	}
+}
+
 // TestClient_doWithRetry_POST_retriesOn429 verifies that POST requests ARE still retried on 429 rate limit
 func TestClient_doWithRetry_POST_retriesOn429(t *testing.T) {
 	callCount := 0
@@ -837,11 +1338,13 @@ func TestClient_doWithRetry_POST_retriesOn429(t *testing.T) {
 	defer server.Close()
 
 	c := &Client{
-		baseURL:        server.URL,
-		clientID:       "test-id",
-		apiKey:         "test-key",
-		httpClient:     http.DefaultClient,
-		circuitBreaker: &circuitBreaker{},
+		baseURL:               server.URL,
+		clientID:              "test-id",
+		apiKey:                "test-key",
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		rateLimitBaseDelay:    testRateLimitBaseDelay,
+		serverErrorRetryDelay: testServerErrorRetryDelay,
 
 		token: &TokenCache{
 			Token:     "test-token",
@@ -992,11 +1495,13 @@ func TestClient_doWithRetry_HEAD_retriesOn5xx(t *testing.T) {
 	defer server.Close()
 
 	c := &Client{
-		baseURL:        server.URL,
-		clientID:       "test-id",
-		apiKey:         "test-key",
-		httpClient:     http.DefaultClient,
-		circuitBreaker: &circuitBreaker{},
+		baseURL:               server.URL,
+		clientID:              "test-id",
+		apiKey:                "test-key",
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		rateLimitBaseDelay:    testRateLimitBaseDelay,
+		serverErrorRetryDelay: testServerErrorRetryDelay,
 
 		token: &TokenCache{
 			Token:     "test-token",
@@ -1033,11 +1538,13 @@ func TestClient_doWithRetry_OPTIONS_retriesOn5xx(t *testing.T) {
 	defer server.Close()
 
 	c := &Client{
-		baseURL:        server.URL,
-		clientID:       "test-id",
-		apiKey:         "test-key",
-		httpClient:     http.DefaultClient,
-		circuitBreaker: &circuitBreaker{},
+		baseURL:               server.URL,
+		clientID:              "test-id",
+		apiKey:                "test-key",
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		rateLimitBaseDelay:    testRateLimitBaseDelay,
+		serverErrorRetryDelay: testServerErrorRetryDelay,
 
 		token: &TokenCache{
 			Token:     "test-token",
@@ -1100,7 +1607,111 @@ func TestNewClientWithAccount_enforcesTLS12(t *testing.T) {
 	}
 }
 
-func TestNewClient_verifiesCertificates(t *testing.T) {
+func TestClient_SetAPIVersion(t *testing.T) {
+	var got []string
+	var present []bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("x-api-version"))
+		_, ok := r.Header["X-Api-Version"]
+		present = append(present, ok)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		version     string
+		set         bool
+		want        string
+		wantPresent bool
+	}{
+		{name: "default pinned version", want: APIVersion, wantPresent: true},
+		{name: "configured date", version: "2024-09-27", set: true, want: "2024-09-27", wantPresent: true},
+		{name: "latest omits header", version: APIVersionLatest, set: true, wantPresent: false},
+		{name: "empty restores default", version: "", set: true, want: APIVersion, wantPresent: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClientWithBaseURL(server.URL, "test-id", "test-key")
+			if err != nil {
+				t.Fatalf("NewClientWithBaseURL failed: %v", err)
+			}
+			c.token = &TokenCache{Token: "tok", ExpiresAt: time.Now().Add(time.Hour)}
+			if tt.set {
+				c.SetAPIVersion(tt.version)
+			}
+			got, present = nil, nil
+			resp, err := c.Get(context.Background(), "/api/v1/balances/current")
+			if err != nil {
+				t.Fatalf("Get failed: %v", err)
+			}
+			_ = resp.Body.Close()
+			if len(got) != 1 || got[0] != tt.want || present[0] != tt.wantPresent {
+				t.Errorf("x-api-version = %q (present %v), want %q (present %v)", got, present, tt.want, tt.wantPresent)
+			}
+		})
+	}
+}
+
+// net/http reads the proxy environment once per process, so the environment
+// case runs in a fresh test process.
+func TestNewClient_proxyFromEnvironment(t *testing.T) {
+	if os.Getenv("AWX_TEST_PROXY_CHILD") == "" {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestNewClient_proxyFromEnvironment$")
+		env := []string{"AWX_TEST_PROXY_CHILD=1", "HTTP_PROXY=http://proxy.example.com:3128", "HTTPS_PROXY=http://proxy.example.com:3128", "NO_PROXY=internal.example.com"}
+		for _, kv := range os.Environ() {
+			if name, _, _ := strings.Cut(kv, "="); !strings.EqualFold(name, "HTTP_PROXY") && !strings.EqualFold(name, "HTTPS_PROXY") && !strings.EqualFold(name, "NO_PROXY") && name != "REQUEST_METHOD" {
+				env = append(env, kv)
+			}
+		}
+		cmd.Env = env
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("proxy test process failed: %v\n%s", err, out)
+		}
+		return
+	}
+
+	client, err := NewClient("test-id", "test-key")
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	transport := client.httpClient.Transport.(*http.Transport)
+	if transport.TLSClientConfig.MinVersion != tls.VersionTLS12 || transport.MaxConnsPerHost != MaxConnsPerHost {
+		t.Error("proxy support must keep the TLS and connection-pool settings")
+	}
+
+	proxyFor := func(rawURL string) string {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, rawURL, nil)
+		u, err := transport.Proxy(req)
+		if err != nil {
+			t.Fatalf("Proxy(%s) error: %v", rawURL, err)
+		}
+		if u == nil {
+			return ""
+		}
+		return u.String()
+	}
+	if got := proxyFor(BaseURL + "/api/v1/balances/current"); got != "http://proxy.example.com:3128" {
+		t.Errorf("proxy for API request = %q, want http://proxy.example.com:3128", got)
+	}
+	if got := proxyFor("https://internal.example.com/"); got != "" {
+		t.Errorf("proxy for NO_PROXY host = %q, want none", got)
+	}
+
+	explicit, _ := url.Parse("http://override.example.com:8080")
+	client.SetProxy(explicit)
+	if got := proxyFor(BaseURL + "/api/v1/balances/current"); got != "http://override.example.com:8080" {
+		t.Errorf("proxy after SetProxy = %q, want the explicit proxy", got)
+	}
+	client.SetProxy(nil)
+	if got := proxyFor(BaseURL + "/api/v1/balances/current"); got != "http://proxy.example.com:3128" {
+		t.Errorf("proxy after SetProxy(nil) = %q, want the environment proxy", got)
+	}
+}
+
+func TestNewClient_verifiesCertificates(t *testing.T) {
 	client, err := NewClient("test-id", "test-key")
 	if err != nil {
 		t.Fatalf("NewClient failed: %v", err)
@@ -1248,6 +1859,118 @@ func TestClient_Post_addsIdempotencyKeyForFinancialOperations(t *testing.T) {
 	}
 }
 
+// TestClient_Post_usesSuppliedIdempotencyKey verifies a caller-supplied key reaches the header unchanged
+func TestClient_Post_usesSuppliedIdempotencyKey(t *testing.T) {
+	var capturedHeaders http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedHeaders = r.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"success": true}`))
+	}))
+	defer server.Close()
+
+	c := &Client{
+		baseURL:        server.URL,
+		clientID:       "test-id",
+		apiKey:         "test-key",
+		httpClient:     http.DefaultClient,
+		circuitBreaker: &circuitBreaker{},
+		token: &TokenCache{
+			Token:     "test-token",
+			ExpiresAt: time.Now().Add(10 * time.Minute),
+		},
+	}
+
+	const key = "payroll-2024-05:run#1"
+	idem := &IdempotencyKey{Key: key}
+	resp, err := c.Post(WithIdempotencyKey(context.Background(), idem), "/api/v1/transfers/create", map[string]string{"amount": "100"})
+	if err != nil {
+		t.Fatalf("Post() error: %v", err)
+	}
+	defer closeBody(resp)
+
+	if got := capturedHeaders.Get("x-idempotency-key"); got != key {
+		t.Errorf("x-idempotency-key = %q, want %q", got, key)
+	}
+	if idem.Key != key {
+		t.Errorf("holder key = %q, want %q", idem.Key, key)
+	}
+
+	// An invalid key is rejected before anything is sent
+	capturedHeaders = nil
+	_, err = c.Post(WithIdempotencyKey(context.Background(), &IdempotencyKey{Key: "bad key"}), "/api/v1/transfers/create", nil)
+	if err == nil {
+		t.Fatal("expected error for invalid idempotency key")
+	}
+	if capturedHeaders != nil {
+		t.Error("request should not be sent with an invalid idempotency key")
+	}
+}
+
+func TestClient_WithHooks(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := &Client{
+		baseURL:        server.URL,
+		clientID:       "test-id",
+		apiKey:         "test-key",
+		httpClient:     http.DefaultClient,
+		circuitBreaker: &circuitBreaker{},
+		token: &TokenCache{
+			Token:     "test-token",
+			ExpiresAt: time.Now().Add(10 * time.Minute),
+		},
+	}
+
+	var urls bytes.Buffer
+	stats := &RequestStats{}
+	ctx := WithHooks(context.Background(), Hooks{ShowURL: &urls, Stats: stats})
+	resp, err := c.Post(ctx, "/api/v1/beneficiaries/ben_1/delete", nil)
+	if err != nil {
+		t.Fatalf("Post() error: %v", err)
+	}
+	closeBody(resp)
+
+	if calls != 1 {
+		t.Errorf("server calls = %d, want 1", calls)
+	}
+	if !strings.Contains(urls.String(), "POST "+server.URL+"/api/v1/beneficiaries/ben_1/delete") {
+		t.Errorf("show-url = %q", urls.String())
+	}
+	if got := stats.Summary().Requests; got != 1 {
+		t.Errorf("stats requests = %d, want 1", got)
+	}
+
+	// A dry run hook holds the request back
+	var held []DryRunRequest
+	ctx = WithHooks(context.Background(), Hooks{DryRun: func(r DryRunRequest) error {
+		held = append(held, r)
+		return nil
+	}})
+	if _, err := c.Post(ctx, "/api/v1/beneficiaries/ben_1/delete", nil); !errors.Is(err, ErrDryRun) {
+		t.Errorf("Post() error = %v, want ErrDryRun", err)
+	}
+	if calls != 1 || len(held) != 1 || held[0].Method != http.MethodPost {
+		t.Errorf("server calls = %d, held = %+v; want the POST held back", calls, held)
+	}
+
+	// Without hooks in the context requests are sent as-is
+	urls.Reset()
+	resp, err = c.Post(context.Background(), "/api/v1/beneficiaries/ben_1/delete", nil)
+	if err != nil {
+		t.Fatalf("Post() error: %v", err)
+	}
+	closeBody(resp)
+	if calls != 2 || urls.Len() != 0 || stats.Summary().Requests != 1 {
+		t.Errorf("calls = %d, show-url = %q, stats = %d; want the request sent without hooks", calls, urls.String(), stats.Summary().Requests)
+	}
+}
+
 // TestClient_Post_noIdempotencyKeyForNonFinancialOperations verifies no header for non-financial paths
 func TestClient_Post_noIdempotencyKeyForNonFinancialOperations(t *testing.T) {
 	var capturedHeaders http.Header
@@ -1294,11 +2017,13 @@ func TestCircuitBreaker_opensAfterThreshold(t *testing.T) {
 	defer server.Close()
 
 	c := &Client{
-		baseURL:        server.URL,
-		clientID:       "test-id",
-		apiKey:         "test-key",
-		httpClient:     http.DefaultClient,
-		circuitBreaker: &circuitBreaker{},
+		baseURL:               server.URL,
+		clientID:              "test-id",
+		apiKey:                "test-key",
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		rateLimitBaseDelay:    testRateLimitBaseDelay,
+		serverErrorRetryDelay: testServerErrorRetryDelay,
 		token: &TokenCache{
 			Token:     "test-token",
 			ExpiresAt: time.Now().Add(10 * time.Minute),
@@ -1361,17 +2086,33 @@ func TestCircuitBreaker_resetsAfterTimeout(t *testing.T) {
 	cb.lastFailure = time.Now().Add(-CircuitBreakerResetTime - 1*time.Second)
 	cb.mu.Unlock()
 
-	// Circuit should now be closed
+	// Circuit should now let a trial request through
 	if cb.isOpen() {
-		t.Error("expected circuit to be closed after reset time")
+		t.Error("expected circuit to let requests through after reset time")
+	}
+	if st := cb.state(); !st.HalfOpen || st.Open {
+		t.Errorf("after reset time state = %+v, want half-open", st)
 	}
 
-	// Verify failures were reset
-	cb.mu.Lock()
-	if cb.failures != 0 {
-		t.Errorf("expected failures to be reset to 0, got %d", cb.failures)
+	// A single failure while half-open re-opens the circuit
+	if !cb.recordFailure() {
+		t.Error("expected a half-open failure to re-open the circuit")
+	}
+	if !cb.isOpen() {
+		t.Error("expected circuit to be open again after a half-open failure")
 	}
+
+	// A success while half-open closes it
+	cb.mu.Lock()
+	cb.lastFailure = time.Now().Add(-CircuitBreakerResetTime - 1*time.Second)
 	cb.mu.Unlock()
+	if cb.isOpen() {
+		t.Fatal("expected circuit to let requests through after reset time")
+	}
+	cb.recordSuccess()
+	if st := cb.state(); st.Open || st.HalfOpen || st.Failures != 0 {
+		t.Errorf("after half-open success state = %+v, want closed", st)
+	}
 }
 
 // TestCircuitBreaker_resetsOnSuccess tests that successful requests reset the circuit breaker
@@ -1390,11 +2131,13 @@ func TestCircuitBreaker_resetsOnSuccess(t *testing.T) {
 	defer server.Close()
 
 	c := &Client{
-		baseURL:        server.URL,
-		clientID:       "test-id",
-		apiKey:         "test-key",
-		httpClient:     http.DefaultClient,
-		circuitBreaker: &circuitBreaker{},
+		baseURL:               server.URL,
+		clientID:              "test-id",
+		apiKey:                "test-key",
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		rateLimitBaseDelay:    testRateLimitBaseDelay,
+		serverErrorRetryDelay: testServerErrorRetryDelay,
 		token: &TokenCache{
 			Token:     "test-token",
 			ExpiresAt: time.Now().Add(10 * time.Minute),
@@ -1455,11 +2198,13 @@ func TestCircuitBreaker_tracksOnlyConsecutiveFailures(t *testing.T) {
 	defer server.Close()
 
 	c := &Client{
-		baseURL:        server.URL,
-		clientID:       "test-id",
-		apiKey:         "test-key",
-		httpClient:     http.DefaultClient,
-		circuitBreaker: &circuitBreaker{},
+		baseURL:               server.URL,
+		clientID:              "test-id",
+		apiKey:                "test-key",
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		rateLimitBaseDelay:    testRateLimitBaseDelay,
+		serverErrorRetryDelay: testServerErrorRetryDelay,
 		token: &TokenCache{
 			Token:     "test-token",
 			ExpiresAt: time.Now().Add(10 * time.Minute),
@@ -1637,3 +2382,391 @@ func TestClient_fetchToken_wrapsErrorWithHTTPContext(t *testing.T) {
 		t.Errorf("error message %q should contain status code", err.Error())
 	}
 }
+
+// refusingTransport fails the first refusals dials with connection refused,
+// then delegates to http.DefaultTransport.
+type refusingTransport struct {
+	refusals int32
+	dials    atomic.Int32
+}
+
+func (rt *refusingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt.dials.Add(1) <= rt.refusals {
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestClient_fetchToken_retriesConnectionErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"token": "test-token", "expires_at": "2099-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	newTestClient := func(rt *refusingTransport) *Client {
+		return &Client{
+			baseURL:           server.URL,
+			clientID:          "test-id",
+			apiKey:            "test-key",
+			httpClient:        &http.Client{Transport: rt},
+			circuitBreaker:    &circuitBreaker{},
+			connectRetryDelay: time.Millisecond,
+		}
+	}
+
+	t.Run("refused then success", func(t *testing.T) {
+		rt := &refusingTransport{refusals: 1}
+		c := newTestClient(rt)
+		if err := c.ensureValidToken(context.Background()); err != nil {
+			t.Fatalf("ensureValidToken() error: %v", err)
+		}
+		if c.token == nil || c.token.Token != "test-token" {
+			t.Errorf("token = %+v, want test-token", c.token)
+		}
+		if got := rt.dials.Load(); got != 2 {
+			t.Errorf("dials = %d, want 2", got)
+		}
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		rt := &refusingTransport{refusals: 100}
+		c := newTestClient(rt)
+		_, err := c.Get(context.Background(), "/api/v1/test")
+		if !IsUnreachableError(err) {
+			t.Fatalf("expected UnreachableError, got %T: %v", err, err)
+		}
+		if strings.Contains(err.Error(), "auth failed") {
+			t.Errorf("unreachable host reported as auth failure: %v", err)
+		}
+		if !strings.Contains(err.Error(), "after 3 attempts") {
+			t.Errorf("error %q should report the attempt count", err)
+		}
+		if got := rt.dials.Load(); got != MaxConnectRetries+1 {
+			t.Errorf("dials = %d, want %d", got, MaxConnectRetries+1)
+		}
+	})
+
+	t.Run("max retries 0 disables the retry", func(t *testing.T) {
+		rt := &refusingTransport{refusals: 100}
+		c := newTestClient(rt)
+		c.SetMaxRetries(0)
+		if err := c.ensureValidToken(context.Background()); !IsUnreachableError(err) {
+			t.Fatalf("expected UnreachableError, got %T: %v", err, err)
+		}
+		if got := rt.dials.Load(); got != 1 {
+			t.Errorf("dials = %d, want 1", got)
+		}
+	})
+}
+
+func TestDecodeResource_UnwrapsSingleKeyEnvelope(t *testing.T) {
+	type resource struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	}
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "bare", body: `{"id":"r_1","status":"ACTIVE"}`},
+		{name: "data envelope", body: `{"data":{"id":"r_1","status":"ACTIVE"}}`},
+		{name: "named envelope", body: `{"beneficiary": {"id":"r_1","status":"ACTIVE"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got resource
+			if err := decodeResource(strings.NewReader(tt.body), &got); err != nil {
+				t.Fatalf("decodeResource() error: %v", err)
+			}
+			if got.ID != "r_1" || got.Status != "ACTIVE" {
+				t.Errorf("got %+v, want id=r_1 status=ACTIVE", got)
+			}
+		})
+	}
+
+	// A single scalar field is a resource, not an envelope.
+	var scalar resource
+	if err := decodeResource(strings.NewReader(`{"id":"r_2"}`), &scalar); err != nil {
+		t.Fatalf("decodeResource() error: %v", err)
+	}
+	if scalar.ID != "r_2" {
+		t.Errorf("scalar single-key body should decode as-is, got %+v", scalar)
+	}
+
+	// Neither is a resource whose only field holds an object.
+	type withAddress struct {
+		Address struct {
+			City string `json:"city"`
+		} `json:"address"`
+	}
+	var nested withAddress
+	if err := decodeResource(strings.NewReader(`{"address":{"city":"Sydney"}}`), &nested); err != nil {
+		t.Fatalf("decodeResource() error: %v", err)
+	}
+	if nested.Address.City != "Sydney" {
+		t.Errorf("single object field should not be unwrapped, got %+v", nested)
+	}
+}
+
+func TestClient_doWithRetry_RetryStatuses(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		statuses  []int
+		status    int
+		wantCalls int
+	}{
+		{name: "408 not retried by default", method: "GET", statuses: nil, status: http.StatusRequestTimeout, wantCalls: 1},
+		{name: "408 retried when configured", method: "GET", statuses: []int{408, 425, 429, 503}, status: http.StatusRequestTimeout, wantCalls: 2},
+		{name: "configured 408 not retried for POST", method: "POST", statuses: []int{408}, status: http.StatusRequestTimeout, wantCalls: 1},
+		{name: "502 not retried when omitted from list", method: "GET", statuses: []int{408, 503}, status: http.StatusBadGateway, wantCalls: 1},
+		{name: "503 retried when listed", method: "GET", statuses: []int{408, 503}, status: http.StatusServiceUnavailable, wantCalls: 2},
+		{name: "429 not retried when omitted from list", method: "GET", statuses: []int{503}, status: http.StatusTooManyRequests, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callCount := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				callCount++
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			c := &Client{
+				baseURL:               server.URL,
+				clientID:              "test-id",
+				apiKey:                "test-key",
+				httpClient:            http.DefaultClient,
+				circuitBreaker:        &circuitBreaker{},
+				rateLimitBaseDelay:    testRateLimitBaseDelay,
+				serverErrorRetryDelay: testServerErrorRetryDelay,
+				token: &TokenCache{
+					Token:     "test-token",
+					ExpiresAt: time.Now().Add(10 * time.Minute),
+				},
+			}
+			c.SetRetryStatuses(tt.statuses)
+
+			req, _ := http.NewRequest(tt.method, server.URL+"/test", nil)
+			resp, err := c.doWithRetry(context.Background(), req)
+			if err != nil {
+				t.Fatalf("doWithRetry() error: %v", err)
+			}
+			defer closeBody(resp)
+
+			if callCount != tt.wantCalls {
+				t.Errorf("expected %d calls, got %d", tt.wantCalls, callCount)
+			}
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+		})
+	}
+}
+
+func TestClient_doWithRetry_RequestTimeout(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			// Hang the first attempt until the client gives up on it.
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	newTestClient := func() *Client {
+		c := &Client{
+			baseURL:        server.URL,
+			clientID:       "test-id",
+			apiKey:         "test-key",
+			httpClient:     http.DefaultClient,
+			circuitBreaker: &circuitBreaker{},
+			token: &TokenCache{
+				Token:     "test-token",
+				ExpiresAt: time.Now().Add(10 * time.Minute),
+			},
+		}
+		c.SetRequestTimeout(50 * time.Millisecond)
+		return c
+	}
+
+	t.Run("GET retries after a slow attempt", func(t *testing.T) {
+		calls.Store(0)
+		req, _ := http.NewRequest("GET", server.URL+"/test", nil)
+		resp, err := newTestClient().doWithRetry(context.Background(), req)
+		if err != nil {
+			t.Fatalf("doWithRetry() error: %v", err)
+		}
+		defer closeBody(resp)
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("reading body: %v", err)
+		}
+		if calls.Load() != 2 || string(body) != `{"ok": true}` {
+			t.Errorf("calls=%d body=%q, want 2 calls and the retried response", calls.Load(), body)
+		}
+	})
+
+	t.Run("POST is not retried", func(t *testing.T) {
+		calls.Store(0)
+		req, _ := http.NewRequest("POST", server.URL+"/test", nil)
+		_, err := newTestClient().doWithRetry(context.Background(), req)
+		if err == nil || !strings.Contains(err.Error(), "--request-timeout") {
+			t.Fatalf("expected per-attempt timeout error, got %v", err)
+		}
+		if calls.Load() != 1 {
+			t.Errorf("calls = %d, want 1", calls.Load())
+		}
+	})
+
+	t.Run("keyed POST with retry-idempotent-5xx is not retried", func(t *testing.T) {
+		calls.Store(0)
+		c := newTestClient()
+		c.SetRetryIdempotent5xx(true)
+		req, _ := http.NewRequest("POST", server.URL+"/test", nil)
+		req.Header.Set("x-idempotency-key", "stable-key")
+		_, err := c.doWithRetry(context.Background(), req)
+		if err == nil || !strings.Contains(err.Error(), "--request-timeout") {
+			t.Fatalf("expected per-attempt timeout error, got %v", err)
+		}
+		if calls.Load() != 1 {
+			t.Errorf("calls = %d, want 1", calls.Load())
+		}
+	})
+
+	t.Run("max retries 0 disables the retry", func(t *testing.T) {
+		calls.Store(0)
+		c := newTestClient()
+		c.SetMaxRetries(0)
+		req, _ := http.NewRequest("GET", server.URL+"/test", nil)
+		_, err := c.doWithRetry(context.Background(), req)
+		if err == nil || !strings.Contains(err.Error(), "--request-timeout") {
+			t.Fatalf("expected per-attempt timeout error, got %v", err)
+		}
+		if calls.Load() != 1 {
+			t.Errorf("calls = %d, want 1", calls.Load())
+		}
+	})
+}
+
+func TestClient_CircuitState(t *testing.T) {
+	c := &Client{circuitBreaker: &circuitBreaker{}}
+	if st := c.CircuitState(); st.Open || st.Failures != 0 || st.ResetIn != 0 {
+		t.Errorf("new client state = %+v, want closed", st)
+	}
+
+	c.circuitBreaker.recordFailure()
+	if st := c.CircuitState(); st.Open || st.Failures != 1 {
+		t.Errorf("after one failure state = %+v, want closed with 1 failure", st)
+	}
+
+	for i := 1; i < CircuitBreakerThreshold; i++ {
+		c.circuitBreaker.recordFailure()
+	}
+	st := c.CircuitState()
+	if !st.Open || st.Failures != CircuitBreakerThreshold {
+		t.Errorf("after threshold state = %+v, want open", st)
+	}
+	if st.ResetIn <= 0 || st.ResetIn > CircuitBreakerResetTime {
+		t.Errorf("ResetIn = %v, want within (0, %v]", st.ResetIn, CircuitBreakerResetTime)
+	}
+
+	data, err := json.Marshal(st)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	want := fmt.Sprintf(`"reset_in_seconds":%d`, int64(st.ResetIn.Round(time.Second)/time.Second))
+	if !strings.Contains(string(data), want) || strings.Contains(string(data), `"ResetIn"`) {
+		t.Errorf("JSON = %s, want %s", data, want)
+	}
+
+	c.circuitBreaker.lastFailure = time.Now().Add(-CircuitBreakerResetTime - time.Second)
+	if st := c.CircuitState(); st.Open {
+		t.Errorf("after reset time state = %+v, want closed", st)
+	}
+}
+
+func TestClient_AddRootCAs(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	other := selfSignedCert(t, "corp-inspection-ca")
+
+	c, err := NewClientWithBaseURL(server.URL, "id", "key")
+	if err != nil {
+		t.Fatalf("NewClientWithBaseURL: %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	if resp, err := c.httpClient.Do(req); err == nil {
+		_ = resp.Body.Close()
+		t.Fatal("self-signed server should be rejected before its CA is added")
+	}
+
+	if err := c.AddRootCAs(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})); err != nil {
+		t.Fatalf("AddRootCAs: %v", err)
+	}
+	if err := c.AddRootCAs(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: other.Raw})); err != nil {
+		t.Fatalf("AddRootCAs (second): %v", err)
+	}
+
+	cfg := c.httpClient.Transport.(*http.Transport).TLSClientConfig
+	if cfg.InsecureSkipVerify {
+		t.Error("AddRootCAs must not disable verification")
+	}
+	for _, cert := range []*x509.Certificate{server.Certificate(), other} {
+		if _, err := cert.Verify(x509.VerifyOptions{Roots: cfg.RootCAs}); err != nil {
+			t.Errorf("certificate %s not trusted by the pool: %v", cert.Subject, err)
+		}
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("request with added CA failed: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if err := c.AddRootCAs([]byte("not a certificate")); err == nil {
+		t.Error("expected an error for input without PEM certificates")
+	}
+}
+
+// selfSignedCert returns a new self-signed CA certificate named cn.
+func selfSignedCert(t *testing.T, cn string) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+	return cert
+}
@@ -61,7 +61,7 @@ func (c *Client) ListDeposits(ctx context.Context, status, fromDate, toDate stri
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var result DepositsResponse
@@ -86,11 +86,11 @@ func (c *Client) GetDeposit(ctx context.Context, depositID string) (*Deposit, er
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var d Deposit
-	if err := json.NewDecoder(resp.Body).Decode(&d); err != nil {
+	if err := decodeResource(resp.Body, &d); err != nil {
 		return nil, err
 	}
 	return &d, nil
@@ -724,11 +724,13 @@ func TestGetDeposit_InternalServerError(t *testing.T) {
 	defer server.Close()
 
 	c := &Client{
-		baseURL:        server.URL,
-		clientID:       "test-id",
-		apiKey:         "test-key",
-		httpClient:     http.DefaultClient,
-		circuitBreaker: &circuitBreaker{},
+		baseURL:               server.URL,
+		clientID:              "test-id",
+		apiKey:                "test-key",
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		rateLimitBaseDelay:    testRateLimitBaseDelay,
+		serverErrorRetryDelay: testServerErrorRetryDelay,
 
 		token: &TokenCache{
 			Token:     "test-token",
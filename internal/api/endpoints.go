@@ -155,6 +155,8 @@ var Endpoints = struct {
 	BillingSubscriptionsCreate   Endpoint
 	BillingSubscriptionsUpdate   Endpoint
 	BillingSubscriptionsCancel   Endpoint
+	BillingSubscriptionsPause    Endpoint
+	BillingSubscriptionsResume   Endpoint
 	BillingSubscriptionItemsList Endpoint
 	BillingSubscriptionItemGet   Endpoint
 }{
@@ -751,6 +753,18 @@ var Endpoints = struct {
 		RequiresIdem:   false,
 		ExpectedStatus: http.StatusOK,
 	},
+	BillingSubscriptionsPause: Endpoint{
+		Path:           "/api/v1/subscriptions/{id}/pause",
+		Method:         http.MethodPost,
+		RequiresIdem:   false,
+		ExpectedStatus: http.StatusOK,
+	},
+	BillingSubscriptionsResume: Endpoint{
+		Path:           "/api/v1/subscriptions/{id}/resume",
+		Method:         http.MethodPost,
+		RequiresIdem:   false,
+		ExpectedStatus: http.StatusOK,
+	},
 	BillingSubscriptionItemsList: Endpoint{
 		Path:           "/api/v1/subscriptions/{id}/items",
 		Method:         http.MethodGet,
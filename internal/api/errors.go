@@ -193,6 +193,34 @@ func (e *CircuitBreakerError) Error() string {
 	return "circuit breaker is open, too many recent failures"
 }
 
+// UnreachableError indicates the API host could not be reached (DNS lookup
+// or connection failure) after retrying.
+type UnreachableError struct {
+	Host     string
+	Attempts int
+	Err      error
+}
+
+func (e *UnreachableError) Error() string {
+	return fmt.Sprintf("cannot reach %s after %d attempts: %v (check your network connection and DNS settings)", e.Host, e.Attempts, e.Err)
+}
+
+func (e *UnreachableError) Unwrap() error {
+	return e.Err
+}
+
+// PreconditionFailedError indicates an If-Match update was rejected (HTTP 412)
+// because the resource changed after its ETag was read.
+type PreconditionFailedError struct {
+	Resource string
+	ID       string
+	ETag     string
+}
+
+func (e *PreconditionFailedError) Error() string {
+	return fmt.Sprintf("%s %s was modified since it was read (If-Match %s no longer matches); fetch it again and retry", e.Resource, e.ID, e.ETag)
+}
+
 // IsRateLimitError checks if the error is a rate limit error.
 func IsRateLimitError(err error) bool {
 	var e *RateLimitError
@@ -211,6 +239,18 @@ func IsCircuitBreakerError(err error) bool {
 	return errors.As(err, &e)
 }
 
+// IsUnreachableError checks if the error is an unreachable-host error.
+func IsUnreachableError(err error) bool {
+	var e *UnreachableError
+	return errors.As(err, &e)
+}
+
+// IsPreconditionFailedError checks if the error is an If-Match conflict.
+func IsPreconditionFailedError(err error) bool {
+	var e *PreconditionFailedError
+	return errors.As(err, &e)
+}
+
 // IsNotFoundError checks if the error indicates a resource was not found.
 func IsNotFoundError(err error) bool {
 	if err == nil {
@@ -230,11 +270,16 @@ type ContextualError struct {
 	Method     string
 	URL        string
 	StatusCode int
+	RequestID  string // x-request-id from the response, for Airwallex support
 	Err        error
 }
 
 func (e *ContextualError) Error() string {
-	return fmt.Sprintf("%s %s failed (status %d): %v", e.Method, e.URL, e.StatusCode, e.Err)
+	msg := fmt.Sprintf("%s %s failed (status %d): %v", e.Method, e.URL, e.StatusCode, e.Err)
+	if e.RequestID != "" {
+		msg += " request_id=" + e.RequestID
+	}
+	return msg
 }
 
 func (e *ContextualError) Unwrap() error {
@@ -251,6 +296,30 @@ func WrapError(method, url string, statusCode int, err error) error {
 	}
 }
 
+// WrapResponseError is WrapError for a received response: it also records
+// the response's request ID.
+func WrapResponseError(method, url string, resp *http.Response, err error) error {
+	return &ContextualError{
+		Method:     method,
+		URL:        url,
+		StatusCode: resp.StatusCode,
+		RequestID:  RequestID(resp),
+		Err:        err,
+	}
+}
+
+// RequestID returns the Airwallex request ID from x-request-id (or
+// x-airwallex-request-id), or "" if the response has none.
+func RequestID(resp *http.Response) string {
+	if resp == nil {
+		return ""
+	}
+	if id := resp.Header.Get("x-request-id"); id != "" {
+		return id
+	}
+	return resp.Header.Get("x-airwallex-request-id")
+}
+
 // NormalizeAPIError maps API errors to typed errors when possible.
 func NormalizeAPIError(statusCode int, apiErr *APIError) error {
 	if apiErr == nil {
@@ -1,9 +1,13 @@
 package api
 
 import (
+	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestAPIError_Error(t *testing.T) {
@@ -269,3 +273,40 @@ func TestContextualError(t *testing.T) {
 		t.Error("expected to unwrap to APIError")
 	}
 }
+
+func TestContextualError_RequestID(t *testing.T) {
+	for _, header := range []string{"x-request-id", "x-airwallex-request-id"} {
+		t.Run(header, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set(header, "req_7f3a9c")
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"code":"invalid_argument","message":"bad transfer"}`))
+			}))
+			defer server.Close()
+
+			c := &Client{
+				baseURL:        server.URL,
+				httpClient:     http.DefaultClient,
+				circuitBreaker: &circuitBreaker{},
+				token:          &TokenCache{Token: "test-token", ExpiresAt: time.Now().Add(time.Hour)},
+			}
+			_, err := c.GetTransfer(context.Background(), "tfr_123")
+
+			var ctxErr *ContextualError
+			if !errors.As(err, &ctxErr) {
+				t.Fatalf("expected ContextualError, got %T: %v", err, err)
+			}
+			if ctxErr.RequestID != "req_7f3a9c" {
+				t.Errorf("RequestID = %q, want req_7f3a9c", ctxErr.RequestID)
+			}
+			if !strings.HasSuffix(err.Error(), " request_id=req_7f3a9c") {
+				t.Errorf("error should end with the request ID, got %q", err.Error())
+			}
+		})
+	}
+
+	if err := WrapError("GET", "/x", 500, errors.New("boom")); strings.Contains(err.Error(), "request_id") {
+		t.Errorf("error without a request ID should not mention one: %q", err.Error())
+	}
+}
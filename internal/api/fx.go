@@ -14,6 +14,9 @@ type Rate struct {
 	BuyCurrency  string      `json:"buy_currency"`
 	Rate         json.Number `json:"rate"`
 	RateType     string      `json:"rate_type"`
+	// SellAmount and BuyAmount are set when the rate was requested for an amount.
+	SellAmount json.Number `json:"sell_amount,omitempty"`
+	BuyAmount  json.Number `json:"buy_amount,omitempty"`
 }
 
 type RatesResponse struct {
@@ -45,6 +48,8 @@ type Conversion struct {
 	Rate         json.Number `json:"rate"`
 	Status       string      `json:"status"`
 	CreatedAt    string      `json:"created_at"`
+	// ConversionDate is the value date the bought funds settle on (YYYY-MM-DD).
+	ConversionDate string `json:"conversion_date,omitempty"`
 }
 
 type ConversionsResponse struct {
@@ -54,6 +59,12 @@ type ConversionsResponse struct {
 
 // GetRates retrieves current exchange rates
 func (c *Client) GetRates(ctx context.Context, sellCurrency, buyCurrency string) (*RatesResponse, error) {
+	return c.GetFXRate(ctx, sellCurrency, buyCurrency, "")
+}
+
+// GetFXRate retrieves the current market rate for selling sellAmount (empty
+// for no amount), so the response also carries the amount bought.
+func (c *Client) GetFXRate(ctx context.Context, sellCurrency, buyCurrency string, sellAmount json.Number) (*RatesResponse, error) {
 	params := url.Values{}
 	if sellCurrency != "" {
 		params.Set("sell_currency", sellCurrency)
@@ -61,6 +72,9 @@ func (c *Client) GetRates(ctx context.Context, sellCurrency, buyCurrency string)
 	if buyCurrency != "" {
 		params.Set("buy_currency", buyCurrency)
 	}
+	if sellAmount != "" {
+		params.Set("sell_amount", sellAmount.String())
+	}
 
 	path := "/api/v1/fx/rates/current"
 	if len(params) > 0 {
@@ -75,7 +89,7 @@ func (c *Client) GetRates(ctx context.Context, sellCurrency, buyCurrency string)
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	// Try parsing as array response first (RatesResponse)
@@ -110,11 +124,11 @@ func (c *Client) CreateQuote(ctx context.Context, req map[string]interface{}) (*
 	// Accept both 200 and 201 for backward compatibility
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", Endpoints.FXQuotesCreate.Path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", Endpoints.FXQuotesCreate.Path, resp, ParseAPIError(body))
 	}
 
 	var q Quote
-	if err := json.NewDecoder(resp.Body).Decode(&q); err != nil {
+	if err := decodeMutation(resp.Body, &q); err != nil {
 		return nil, err
 	}
 	return &q, nil
@@ -135,11 +149,11 @@ func (c *Client) GetQuote(ctx context.Context, quoteID string) (*Quote, error) {
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var q Quote
-	if err := json.NewDecoder(resp.Body).Decode(&q); err != nil {
+	if err := decodeResource(resp.Body, &q); err != nil {
 		return nil, err
 	}
 	return &q, nil
@@ -179,7 +193,7 @@ func (c *Client) ListConversions(ctx context.Context, status string, fromDate, t
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var result ConversionsResponse
@@ -204,11 +218,11 @@ func (c *Client) GetConversion(ctx context.Context, conversionID string) (*Conve
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var conv Conversion
-	if err := json.NewDecoder(resp.Body).Decode(&conv); err != nil {
+	if err := decodeResource(resp.Body, &conv); err != nil {
 		return nil, err
 	}
 	return &conv, nil
@@ -228,11 +242,11 @@ func (c *Client) CreateConversion(ctx context.Context, req map[string]interface{
 	// Accept both 200 and 201 for backward compatibility
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", Endpoints.FXConversionsCreate.Path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", Endpoints.FXConversionsCreate.Path, resp, ParseAPIError(body))
 	}
 
 	var conv Conversion
-	if err := json.NewDecoder(resp.Body).Decode(&conv); err != nil {
+	if err := decodeMutation(resp.Body, &conv); err != nil {
 		return nil, err
 	}
 	return &conv, nil
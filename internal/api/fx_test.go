@@ -2,6 +2,8 @@ package api
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -1016,3 +1018,147 @@ func TestCreateConversion_200Response(t *testing.T) {
 		t.Errorf("id = %q, want 'conv_200'", conv.ID)
 	}
 }
+
+func TestCreateConversion_SendsIdempotencyKeyAndConversionDate(t *testing.T) {
+	var gotKey string
+	var gotBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey = r.Header.Get("x-idempotency-key")
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{
+			"id": "conv_dated",
+			"sell_currency": "USD",
+			"buy_currency": "EUR",
+			"sell_amount": 1000.00,
+			"buy_amount": 921.50,
+			"rate": 0.9215,
+			"status": "SCHEDULED",
+			"conversion_date": "2025-06-30",
+			"created_at": "2025-06-26T12:00:00Z"
+		}`))
+	}))
+	defer server.Close()
+
+	c := &Client{
+		baseURL:        server.URL,
+		clientID:       "test-id",
+		apiKey:         "test-key",
+		httpClient:     http.DefaultClient,
+		circuitBreaker: &circuitBreaker{},
+
+		token: &TokenCache{
+			Token:     "test-token",
+			ExpiresAt: time.Now().Add(10 * time.Minute),
+		},
+	}
+
+	conv, err := c.CreateConversion(context.Background(), map[string]interface{}{
+		"sell_currency":   "USD",
+		"buy_currency":    "EUR",
+		"sell_amount":     1000.00,
+		"conversion_date": "2025-06-30",
+	})
+	if err != nil {
+		t.Fatalf("CreateConversion() error: %v", err)
+	}
+	if gotKey == "" {
+		t.Error("expected an x-idempotency-key header on the conversion create")
+	}
+	if gotBody["conversion_date"] != "2025-06-30" {
+		t.Errorf("conversion_date sent = %v, want 2025-06-30", gotBody["conversion_date"])
+	}
+	if conv.ID != "conv_dated" || conv.Rate.String() != "0.9215" || conv.BuyAmount.String() != "921.50" {
+		t.Errorf("conversion = %+v, want id conv_dated, rate 0.9215, buy_amount 921.50", conv)
+	}
+	if conv.ConversionDate != "2025-06-30" {
+		t.Errorf("conversion_date = %q, want 2025-06-30", conv.ConversionDate)
+	}
+}
+
+func TestCreateConversion_ValidationError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{
+			"code": "validation_error",
+			"message": "conversion_date must not be a non-settlement day",
+			"source": "conversion_date"
+		}`))
+	}))
+	defer server.Close()
+
+	c := &Client{
+		baseURL:        server.URL,
+		clientID:       "test-id",
+		apiKey:         "test-key",
+		httpClient:     http.DefaultClient,
+		circuitBreaker: &circuitBreaker{},
+
+		token: &TokenCache{
+			Token:     "test-token",
+			ExpiresAt: time.Now().Add(10 * time.Minute),
+		},
+	}
+
+	_, err := c.CreateConversion(context.Background(), map[string]interface{}{
+		"sell_currency":   "USD",
+		"buy_currency":    "EUR",
+		"sell_amount":     1000.00,
+		"conversion_date": "2025-06-28",
+	})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an APIError, got %v", err)
+	}
+	if apiErr.Code != "validation_error" || apiErr.Source != "conversion_date" {
+		t.Errorf("error = %+v, want validation_error on conversion_date", apiErr)
+	}
+}
+
+func TestGetFXRate_SendsSellAmount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("sell_amount"); got != "1000" {
+			t.Errorf("sell_amount = %q, want 1000", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"sell_currency": "USD",
+			"buy_currency": "JPY",
+			"rate": 149.235,
+			"rate_type": "CURRENT",
+			"sell_amount": 1000,
+			"buy_amount": 149235
+		}`))
+	}))
+	defer server.Close()
+
+	c := &Client{
+		baseURL:        server.URL,
+		clientID:       "test-id",
+		apiKey:         "test-key",
+		httpClient:     http.DefaultClient,
+		circuitBreaker: &circuitBreaker{},
+
+		token: &TokenCache{
+			Token:     "test-token",
+			ExpiresAt: time.Now().Add(10 * time.Minute),
+		},
+	}
+
+	result, err := c.GetFXRate(context.Background(), "USD", "JPY", "1000")
+	if err != nil {
+		t.Fatalf("GetFXRate() error: %v", err)
+	}
+	if len(result.Rates) != 1 {
+		t.Fatalf("rates count = %d, want 1", len(result.Rates))
+	}
+	r := result.Rates[0]
+	if r.Rate != jn("149.235") {
+		t.Errorf("rate = %s, want 149.235", r.Rate)
+	}
+	if r.SellAmount != jn("1000") || r.BuyAmount != jn("149235") {
+		t.Errorf("amounts = %s -> %s, want 1000 -> 149235", r.SellAmount, r.BuyAmount)
+	}
+}
@@ -146,6 +146,9 @@ type Transaction struct {
 	} `json:"merchant"`
 	Status          string `json:"status"`
 	TransactionDate string `json:"transaction_date"`
+	// PostedAt is when the transaction posted. The API names it posted_date
+	// even though it carries a full timestamp, and JSON output keeps that name.
+	PostedAt string `json:"posted_date,omitempty"`
 }
 
 type TransactionsResponse struct {
@@ -190,7 +193,7 @@ func (c *Client) ListCards(ctx context.Context, status, cardholderID string, pag
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var result CardsResponse
@@ -214,11 +217,11 @@ func (c *Client) GetCard(ctx context.Context, cardID string) (*Card, error) {
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var card Card
-	if err := json.NewDecoder(resp.Body).Decode(&card); err != nil {
+	if err := decodeResource(resp.Body, &card); err != nil {
 		return nil, err
 	}
 	return &card, nil
@@ -238,7 +241,7 @@ func (c *Client) GetCardDetails(ctx context.Context, cardID string) (*CardDetail
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var details CardDetails
@@ -262,7 +265,7 @@ func (c *Client) GetCardLimits(ctx context.Context, cardID string) (*CardLimits,
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var limits CardLimits
@@ -286,13 +289,16 @@ func (c *Client) UpdateCard(ctx context.Context, cardID string, update map[strin
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var card Card
-	if err := json.NewDecoder(resp.Body).Decode(&card); err != nil {
+	if err := decodeMutation(resp.Body, &card); err != nil {
 		return nil, err
 	}
+	if card.CardID == "" {
+		card.CardID = cardID
+	}
 	return &card, nil
 }
 
@@ -313,13 +319,16 @@ func (c *Client) ActivateCard(ctx context.Context, cardID string) (*Card, error)
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var card Card
-	if err := json.NewDecoder(resp.Body).Decode(&card); err != nil {
+	if err := decodeMutation(resp.Body, &card); err != nil {
 		return nil, err
 	}
+	if card.CardID == "" {
+		card.CardID = cardID
+	}
 	return &card, nil
 }
 
@@ -338,11 +347,11 @@ func (c *Client) CreateCard(ctx context.Context, req map[string]interface{}) (*C
 	// Accept 200, 201, and 202 (Accepted) as success
 	if resp.StatusCode != 200 && resp.StatusCode != 201 && resp.StatusCode != 202 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var card Card
-	if err := json.NewDecoder(resp.Body).Decode(&card); err != nil {
+	if err := decodeMutation(resp.Body, &card); err != nil {
 		return nil, err
 	}
 	return &card, nil
@@ -374,7 +383,7 @@ func (c *Client) ListCardholders(ctx context.Context, pageNum, pageSize int) (*C
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var result CardholdersResponse
@@ -398,11 +407,11 @@ func (c *Client) GetCardholder(ctx context.Context, cardholderID string) (*Cardh
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var ch Cardholder
-	if err := json.NewDecoder(resp.Body).Decode(&ch); err != nil {
+	if err := decodeResource(resp.Body, &ch); err != nil {
 		return nil, err
 	}
 	return &ch, nil
@@ -419,11 +428,11 @@ func (c *Client) CreateCardholder(ctx context.Context, req map[string]interface{
 
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var ch Cardholder
-	if err := json.NewDecoder(resp.Body).Decode(&ch); err != nil {
+	if err := decodeMutation(resp.Body, &ch); err != nil {
 		return nil, err
 	}
 	return &ch, nil
@@ -443,46 +452,78 @@ func (c *Client) UpdateCardholder(ctx context.Context, cardholderID string, upda
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var ch Cardholder
-	if err := json.NewDecoder(resp.Body).Decode(&ch); err != nil {
+	if err := decodeMutation(resp.Body, &ch); err != nil {
 		return nil, err
 	}
+	if ch.CardholderID == "" {
+		ch.CardholderID = cardholderID
+	}
 	return &ch, nil
 }
 
+// IssuingTransactionListParams defines filters for listing issuing transactions.
+// PageNum is 1-based like the CLI; it is converted to the API's 0-based page.
+type IssuingTransactionListParams struct {
+	CardID          string
+	BillingCurrency string
+	TransactionType string
+	FromCreatedAt   string
+	ToCreatedAt     string
+	PageNum         int
+	PageSize        int
+}
+
 // ListTransactions lists issuing transactions
 func (c *Client) ListTransactions(ctx context.Context, cardID string, from, to string, pageNum, pageSize int) (*TransactionsResponse, error) {
-	if cardID != "" {
-		if err := ValidateResourceID(cardID, "card"); err != nil {
+	return c.ListIssuingTransactions(ctx, IssuingTransactionListParams{
+		CardID:        cardID,
+		FromCreatedAt: from,
+		ToCreatedAt:   to,
+		PageNum:       pageNum,
+		PageSize:      pageSize,
+	})
+}
+
+// ListIssuingTransactions lists issuing transactions with optional filters.
+func (c *Client) ListIssuingTransactions(ctx context.Context, params IssuingTransactionListParams) (*TransactionsResponse, error) {
+	if params.CardID != "" {
+		if err := ValidateResourceID(params.CardID, "card"); err != nil {
 			return nil, err
 		}
 	}
-	params := url.Values{}
-	if cardID != "" {
-		params.Set("card_id", cardID)
+	query := url.Values{}
+	if params.CardID != "" {
+		query.Set("card_id", params.CardID)
 	}
-	if from != "" {
-		params.Set("from_created_at", from)
+	if params.BillingCurrency != "" {
+		query.Set("billing_currency", params.BillingCurrency)
 	}
-	if to != "" {
-		params.Set("to_created_at", to)
+	if params.TransactionType != "" {
+		query.Set("transaction_type", params.TransactionType)
+	}
+	if params.FromCreatedAt != "" {
+		query.Set("from_created_at", params.FromCreatedAt)
+	}
+	if params.ToCreatedAt != "" {
+		query.Set("to_created_at", params.ToCreatedAt)
 	}
 	// Airwallex API requires both page_num and page_size together
-	if pageSize > 0 {
-		pageNum-- // CLI uses 1-based, API uses 0-based
+	if params.PageSize > 0 {
+		pageNum := params.PageNum - 1 // CLI uses 1-based, API uses 0-based
 		if pageNum < 0 {
 			pageNum = 0
 		}
-		params.Set("page_num", fmt.Sprintf("%d", pageNum))
-		params.Set("page_size", fmt.Sprintf("%d", pageSize))
+		query.Set("page_num", fmt.Sprintf("%d", pageNum))
+		query.Set("page_size", fmt.Sprintf("%d", params.PageSize))
 	}
 
-	path := "/api/v1/issuing/transactions"
-	if len(params) > 0 {
-		path += "?" + params.Encode()
+	path := Endpoints.CardTransactionsList.Path
+	if len(query) > 0 {
+		path += "?" + query.Encode()
 	}
 
 	resp, err := c.Get(ctx, path)
@@ -493,7 +534,7 @@ func (c *Client) ListTransactions(ctx context.Context, cardID string, from, to s
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var result TransactionsResponse
@@ -517,11 +558,11 @@ func (c *Client) GetTransaction(ctx context.Context, transactionID string) (*Tra
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var txn Transaction
-	if err := json.NewDecoder(resp.Body).Decode(&txn); err != nil {
+	if err := decodeResource(resp.Body, &txn); err != nil {
 		return nil, err
 	}
 	return &txn, nil
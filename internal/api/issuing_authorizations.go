@@ -91,7 +91,7 @@ func (c *Client) ListAuthorizations(ctx context.Context, params AuthorizationLis
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var result AuthorizationsResponse
@@ -115,11 +115,11 @@ func (c *Client) GetAuthorization(ctx context.Context, transactionID string) (*A
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var auth Authorization
-	if err := json.NewDecoder(resp.Body).Decode(&auth); err != nil {
+	if err := decodeResource(resp.Body, &auth); err != nil {
 		return nil, err
 	}
 	return &auth, nil
@@ -94,7 +94,7 @@ func (c *Client) ListTransactionDisputes(ctx context.Context, params Transaction
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var result TransactionDisputesResponse
@@ -118,11 +118,11 @@ func (c *Client) GetTransactionDispute(ctx context.Context, disputeID string) (*
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var dispute TransactionDispute
-	if err := json.NewDecoder(resp.Body).Decode(&dispute); err != nil {
+	if err := decodeResource(resp.Body, &dispute); err != nil {
 		return nil, err
 	}
 	return &dispute, nil
@@ -141,11 +141,11 @@ func (c *Client) CreateTransactionDispute(ctx context.Context, req map[string]in
 
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", Endpoints.TransactionDisputesCreate.Path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", Endpoints.TransactionDisputesCreate.Path, resp, ParseAPIError(body))
 	}
 
 	var dispute TransactionDispute
-	if err := json.NewDecoder(resp.Body).Decode(&dispute); err != nil {
+	if err := decodeMutation(resp.Body, &dispute); err != nil {
 		return nil, err
 	}
 	return &dispute, nil
@@ -166,13 +166,16 @@ func (c *Client) UpdateTransactionDispute(ctx context.Context, disputeID string,
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var dispute TransactionDispute
-	if err := json.NewDecoder(resp.Body).Decode(&dispute); err != nil {
+	if err := decodeMutation(resp.Body, &dispute); err != nil {
 		return nil, err
 	}
+	if dispute.DisputeID == "" {
+		dispute.DisputeID = disputeID
+	}
 	return &dispute, nil
 }
 
@@ -191,13 +194,16 @@ func (c *Client) SubmitTransactionDispute(ctx context.Context, disputeID string)
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var dispute TransactionDispute
-	if err := json.NewDecoder(resp.Body).Decode(&dispute); err != nil {
+	if err := decodeMutation(resp.Body, &dispute); err != nil {
 		return nil, err
 	}
+	if dispute.DisputeID == "" {
+		dispute.DisputeID = disputeID
+	}
 	return &dispute, nil
 }
 
@@ -216,12 +222,15 @@ func (c *Client) CancelTransactionDispute(ctx context.Context, disputeID string)
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var dispute TransactionDispute
-	if err := json.NewDecoder(resp.Body).Decode(&dispute); err != nil {
+	if err := decodeMutation(resp.Body, &dispute); err != nil {
 		return nil, err
 	}
+	if dispute.DisputeID == "" {
+		dispute.DisputeID = disputeID
+	}
 	return &dispute, nil
 }
@@ -337,3 +337,81 @@ func TestListTransactions_paginationOffset(t *testing.T) {
 		})
 	}
 }
+
+func TestListIssuingTransactions_FiltersAndParsing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/authentication/login" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"token":"t","expires_at":"2099-01-01T00:00:00Z"}`))
+			return
+		}
+		if r.URL.Path != "/api/v1/issuing/transactions" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		want := map[string]string{
+			"card_id":          "card_123",
+			"billing_currency": "USD",
+			"transaction_type": "CLEARING",
+			"from_created_at":  "2024-06-01T00:00:00Z",
+			"to_created_at":    "2024-06-30T23:59:59Z",
+			"page_num":         "1",
+			"page_size":        "50",
+		}
+		for key, value := range want {
+			if got := q.Get(key); got != value {
+				t.Errorf("%s = %q, want %q", key, got, value)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"items": [{
+				"transaction_id": "txn_1",
+				"card_id": "card_123",
+				"transaction_type": "CLEARING",
+				"transaction_amount": -12.34,
+				"transaction_currency": "USD",
+				"merchant": {"name": "COFFEE CO"},
+				"status": "APPROVED",
+				"transaction_date": "2024-06-02T10:00:00Z",
+				"posted_date": "2024-06-03T00:00:00Z"
+			}],
+			"has_more": true
+		}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	result, err := c.ListIssuingTransactions(context.Background(), IssuingTransactionListParams{
+		CardID:          "card_123",
+		BillingCurrency: "USD",
+		TransactionType: "CLEARING",
+		FromCreatedAt:   "2024-06-01T00:00:00Z",
+		ToCreatedAt:     "2024-06-30T23:59:59Z",
+		PageNum:         2,
+		PageSize:        50,
+	})
+	if err != nil {
+		t.Fatalf("ListIssuingTransactions() error: %v", err)
+	}
+	if !result.HasMore || len(result.Items) != 1 {
+		t.Fatalf("result = %+v, want 1 item with has_more", result)
+	}
+	txn := result.Items[0]
+	if txn.TransactionID != "txn_1" || txn.CardID != "card_123" || txn.Currency != "USD" || txn.Status != "APPROVED" {
+		t.Errorf("unexpected transaction: %+v", txn)
+	}
+	if txn.Amount.String() != "-12.34" {
+		t.Errorf("amount = %s, want -12.34", txn.Amount)
+	}
+	if txn.Merchant.Name != "COFFEE CO" {
+		t.Errorf("merchant = %q, want COFFEE CO", txn.Merchant.Name)
+	}
+	if txn.PostedAt != "2024-06-03T00:00:00Z" {
+		t.Errorf("PostedAt = %q, want 2024-06-03T00:00:00Z (from posted_date)", txn.PostedAt)
+	}
+
+	if _, err := c.ListIssuingTransactions(context.Background(), IssuingTransactionListParams{CardID: "bad/id"}); err == nil {
+		t.Error("expected error for invalid card ID")
+	}
+}
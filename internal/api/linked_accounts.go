@@ -58,7 +58,7 @@ func (c *Client) ListLinkedAccounts(ctx context.Context, pageNum, pageSize int)
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var result LinkedAccountsResponse
@@ -83,11 +83,11 @@ func (c *Client) GetLinkedAccount(ctx context.Context, accountID string) (*Linke
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var la LinkedAccount
-	if err := json.NewDecoder(resp.Body).Decode(&la); err != nil {
+	if err := decodeResource(resp.Body, &la); err != nil {
 		return nil, err
 	}
 	return &la, nil
@@ -107,11 +107,11 @@ func (c *Client) CreateLinkedAccount(ctx context.Context, req map[string]interfa
 
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var la LinkedAccount
-	if err := json.NewDecoder(resp.Body).Decode(&la); err != nil {
+	if err := decodeMutation(resp.Body, &la); err != nil {
 		return nil, err
 	}
 	return &la, nil
@@ -140,11 +140,11 @@ func (c *Client) InitiateDeposit(ctx context.Context, accountID string, amount f
 
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var di DepositInitiation
-	if err := json.NewDecoder(resp.Body).Decode(&di); err != nil {
+	if err := decodeMutation(resp.Body, &di); err != nil {
 		return nil, err
 	}
 	return &di, nil
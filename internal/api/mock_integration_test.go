@@ -113,11 +113,13 @@ func TestMockIntegration_GetBalances_ServerError(t *testing.T) {
 	ms.HandleError("GET", "/api/v1/balances/current", http.StatusInternalServerError, "Internal server error")
 
 	client := &Client{
-		baseURL:        ms.URL(),
-		clientID:       "test-id",
-		apiKey:         "test-key",
-		httpClient:     http.DefaultClient,
-		circuitBreaker: &circuitBreaker{},
+		baseURL:               ms.URL(),
+		clientID:              "test-id",
+		apiKey:                "test-key",
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		rateLimitBaseDelay:    testRateLimitBaseDelay,
+		serverErrorRetryDelay: testServerErrorRetryDelay,
 		token: &TokenCache{
 			Token:     "test-token",
 			ExpiresAt: time.Now().Add(10 * time.Minute),
@@ -158,11 +160,13 @@ func TestMockIntegration_RateLimitRetry(t *testing.T) {
 	})
 
 	client := &Client{
-		baseURL:        ms.URL(),
-		clientID:       "test-id",
-		apiKey:         "test-key",
-		httpClient:     http.DefaultClient,
-		circuitBreaker: &circuitBreaker{},
+		baseURL:               ms.URL(),
+		clientID:              "test-id",
+		apiKey:                "test-key",
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		rateLimitBaseDelay:    testRateLimitBaseDelay,
+		serverErrorRetryDelay: testServerErrorRetryDelay,
 		token: &TokenCache{
 			Token:     "test-token",
 			ExpiresAt: time.Now().Add(10 * time.Minute),
@@ -73,7 +73,7 @@ func (c *Client) ListPayers(ctx context.Context, params PayerListParams) (*Payer
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var result PayersResponse
@@ -98,11 +98,11 @@ func (c *Client) GetPayer(ctx context.Context, payerID string) (*Payer, error) {
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var payer Payer
-	if err := json.NewDecoder(resp.Body).Decode(&payer); err != nil {
+	if err := decodeResource(resp.Body, &payer); err != nil {
 		return nil, err
 	}
 	return &payer, nil
@@ -121,11 +121,11 @@ func (c *Client) CreatePayer(ctx context.Context, req map[string]interface{}) (*
 
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", Endpoints.PayersCreate.Path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", Endpoints.PayersCreate.Path, resp, ParseAPIError(body))
 	}
 
 	var payer Payer
-	if err := json.NewDecoder(resp.Body).Decode(&payer); err != nil {
+	if err := decodeMutation(resp.Body, &payer); err != nil {
 		return nil, err
 	}
 	return &payer, nil
@@ -146,13 +146,16 @@ func (c *Client) UpdatePayer(ctx context.Context, payerID string, req map[string
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var payer Payer
-	if err := json.NewDecoder(resp.Body).Decode(&payer); err != nil {
+	if err := decodeMutation(resp.Body, &payer); err != nil {
 		return nil, err
 	}
+	if payer.ID == "" {
+		payer.ID = payerID
+	}
 	return &payer, nil
 }
 
@@ -171,7 +174,7 @@ func (c *Client) DeletePayer(ctx context.Context, payerID string) error {
 
 	if resp.StatusCode != 200 && resp.StatusCode != 204 {
 		body, _ := io.ReadAll(resp.Body)
-		return WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 	return nil
 }
@@ -186,7 +189,7 @@ func (c *Client) ValidatePayer(ctx context.Context, req map[string]interface{})
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return WrapError("POST", Endpoints.PayersValidate.Path, resp.StatusCode, ParseAPIError(body))
+		return WrapResponseError("POST", Endpoints.PayersValidate.Path, resp, ParseAPIError(body))
 	}
 	return nil
 }
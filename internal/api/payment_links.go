@@ -50,7 +50,7 @@ func (c *Client) ListPaymentLinks(ctx context.Context, pageNum, pageSize int) (*
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var result PaymentLinksResponse
@@ -75,11 +75,11 @@ func (c *Client) GetPaymentLink(ctx context.Context, linkID string) (*PaymentLin
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var pl PaymentLink
-	if err := json.NewDecoder(resp.Body).Decode(&pl); err != nil {
+	if err := decodeResource(resp.Body, &pl); err != nil {
 		return nil, err
 	}
 	return &pl, nil
@@ -99,11 +99,11 @@ func (c *Client) CreatePaymentLink(ctx context.Context, req map[string]interface
 
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var pl PaymentLink
-	if err := json.NewDecoder(resp.Body).Decode(&pl); err != nil {
+	if err := decodeMutation(resp.Body, &pl); err != nil {
 		return nil, err
 	}
 	return &pl, nil
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRateLimitTestClient(t *testing.T, calls *atomic.Int32) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	return &Client{
+		baseURL:        server.URL,
+		clientID:       "test-id",
+		apiKey:         "test-key",
+		httpClient:     http.DefaultClient,
+		circuitBreaker: &circuitBreaker{},
+		token: &TokenCache{
+			Token:     "test-token",
+			ExpiresAt: time.Now().Add(10 * time.Minute),
+		},
+	}
+}
+
+func TestClient_SetRateLimit_PacesRequests(t *testing.T) {
+	var calls atomic.Int32
+	c := newRateLimitTestClient(t, &calls)
+	c.SetRateLimit(5)
+
+	start := time.Now()
+	for i := 0; i < 20; i++ {
+		resp, err := c.Get(context.Background(), "/api/v1/test")
+		if err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+		closeBody(resp)
+	}
+	elapsed := time.Since(start)
+
+	// A one-second burst of 5, then 15 more at 5/s.
+	if elapsed < 2900*time.Millisecond {
+		t.Errorf("20 requests at 5/s took %s, want at least ~3s", elapsed)
+	}
+	if elapsed > 6*time.Second {
+		t.Errorf("20 requests at 5/s took %s, want close to 3s", elapsed)
+	}
+	if got := calls.Load(); got != 20 {
+		t.Errorf("calls = %d, want 20", got)
+	}
+}
+
+func TestClient_SetRateLimit_HonorsCancellation(t *testing.T) {
+	var calls atomic.Int32
+	c := newRateLimitTestClient(t, &calls)
+	c.SetRateLimit(1)
+
+	resp, err := c.Get(context.Background(), "/api/v1/test")
+	if err != nil {
+		t.Fatalf("first request: %v", err)
+	}
+	closeBody(resp)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = c.Get(ctx, "/api/v1/test")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("cancelled wait took %s, want it to return promptly", elapsed)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("calls = %d, want the waiting request never sent", got)
+	}
+}
+
+func TestClient_SetRateLimit_ZeroIsUnlimited(t *testing.T) {
+	var calls atomic.Int32
+	c := newRateLimitTestClient(t, &calls)
+	c.SetRateLimit(1)
+	c.SetRateLimit(0)
+
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		resp, err := c.Get(context.Background(), "/api/v1/test")
+		if err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+		closeBody(resp)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("unlimited requests took %s", elapsed)
+	}
+}
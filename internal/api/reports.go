@@ -76,11 +76,11 @@ func (c *Client) CreateFinancialReport(ctx context.Context, req *CreateReportReq
 
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var report FinancialReport
-	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+	if err := decodeMutation(resp.Body, &report); err != nil {
 		return nil, err
 	}
 	return &report, nil
@@ -111,7 +111,7 @@ func (c *Client) ListFinancialReports(ctx context.Context, pageNum, pageSize int
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var result FinancialReportsResponse
@@ -135,11 +135,11 @@ func (c *Client) GetFinancialReport(ctx context.Context, reportID string) (*Fina
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var report FinancialReport
-	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+	if err := decodeResource(resp.Body, &report); err != nil {
 		return nil, err
 	}
 	return &report, nil
@@ -160,7 +160,7 @@ func (c *Client) DownloadFinancialReport(ctx context.Context, reportID string) (
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, "", WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, "", WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	content, err := io.ReadAll(resp.Body)
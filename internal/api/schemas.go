@@ -8,11 +8,43 @@ import (
 
 // SchemaFieldRule contains validation rules for a schema field
 type SchemaFieldRule struct {
-	Type      string   `json:"type,omitempty"`
-	Pattern   string   `json:"pattern,omitempty"`
-	Enum      []string `json:"enum"`
-	MinLength int      `json:"minLength,omitempty"`
-	MaxLength int      `json:"maxLength,omitempty"`
+	Type      string     `json:"type,omitempty"`
+	Pattern   string     `json:"pattern,omitempty"`
+	Enum      SchemaEnum `json:"enum"`
+	MinLength int        `json:"minLength,omitempty"`
+	MaxLength int        `json:"maxLength,omitempty"`
+}
+
+// SchemaEnum lists the values a schema field accepts. The API sends either
+// plain strings or {"key", "value"} options, where key is the value to submit
+// and value its display label.
+type SchemaEnum []string
+
+// UnmarshalJSON accepts both enum forms.
+func (e *SchemaEnum) UnmarshalJSON(data []byte) error {
+	var values []string
+	if err := json.Unmarshal(data, &values); err == nil {
+		*e = values
+		return nil
+	}
+
+	var options []struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(data, &options); err != nil {
+		return err
+	}
+	values = make([]string, 0, len(options))
+	for _, opt := range options {
+		if opt.Key != "" {
+			values = append(values, opt.Key)
+		} else {
+			values = append(values, opt.Value)
+		}
+	}
+	*e = values
+	return nil
 }
 
 // SchemaField represents a field in a dynamic schema
@@ -36,7 +68,7 @@ func (f SchemaField) Type() string {
 
 // Enum returns the enum values from the rule
 func (f SchemaField) Enum() []string {
-	return f.Rule.Enum
+	return []string(f.Rule.Enum)
 }
 
 // Pattern returns the validation pattern from the rule
@@ -79,7 +111,7 @@ func (c *Client) GetBeneficiarySchema(ctx context.Context, bankCountry, entityTy
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var schema Schema
@@ -108,7 +140,7 @@ func (c *Client) GetTransferSchema(ctx context.Context, sourceCurrency, destCurr
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var schema Schema
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -684,3 +685,14 @@ func TestGetBeneficiarySchema_Unauthorized(t *testing.T) {
 		t.Error("expected unauthorized error, got nil")
 	}
 }
+
+func TestSchemaEnum_UnmarshalOptions(t *testing.T) {
+	var field SchemaField
+	data := `{"key": "account_category", "rule": {"type": "string", "enum": [{"key": "Checking", "value": "Checking account"}, {"value": "Savings"}]}}`
+	if err := json.Unmarshal([]byte(data), &field); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if got, want := field.Enum(), []string{"Checking", "Savings"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("enum = %v, want %v", got, want)
+	}
+}
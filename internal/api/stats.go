@@ -0,0 +1,146 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RequestStats collects request timings and retry counts across every call a
+// client makes (for --stats). It is safe for concurrent use.
+type RequestStats struct {
+	mu           sync.Mutex
+	requests     int
+	httpCalls    int
+	totalLatency time.Duration
+	minLatency   time.Duration
+	maxLatency   time.Duration
+	attempts     map[int]int
+	circuit      *circuitBreaker // breaker of the client reporting here, if any
+}
+
+// StatsSummary is a point-in-time copy of RequestStats.
+type StatsSummary struct {
+	Requests   int           `json:"requests"`
+	HTTPCalls  int           `json:"http_calls"`
+	MinLatency time.Duration `json:"min_latency"`
+	AvgLatency time.Duration `json:"avg_latency"`
+	MaxLatency time.Duration `json:"max_latency"`
+	// Attempts maps the number of HTTP attempts a request needed (1 = no
+	// retry) to how many requests needed that many.
+	Attempts map[int]int `json:"attempts"`
+	// Circuit is the client's circuit breaker at the time of the summary.
+	Circuit *CircuitState `json:"circuit,omitempty"`
+}
+
+// recordLatency records the duration of a single HTTP round trip.
+func (s *RequestStats) recordLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.httpCalls++
+	s.totalLatency += d
+	if s.httpCalls == 1 || d < s.minLatency {
+		s.minLatency = d
+	}
+	if d > s.maxLatency {
+		s.maxLatency = d
+	}
+}
+
+func (s *RequestStats) setCircuit(cb *circuitBreaker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.circuit = cb
+}
+
+// recordRequest records a completed request and how many attempts it took.
+func (s *RequestStats) recordRequest(attempts int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+	if s.attempts == nil {
+		s.attempts = make(map[int]int)
+	}
+	s.attempts[attempts]++
+}
+
+// Summary returns a snapshot of the collected stats.
+func (s *RequestStats) Summary() StatsSummary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sum := StatsSummary{
+		Requests:   s.requests,
+		HTTPCalls:  s.httpCalls,
+		MinLatency: s.minLatency,
+		MaxLatency: s.maxLatency,
+		Attempts:   make(map[int]int, len(s.attempts)),
+	}
+	if s.httpCalls > 0 {
+		sum.AvgLatency = s.totalLatency / time.Duration(s.httpCalls)
+	}
+	for k, v := range s.attempts {
+		sum.Attempts[k] = v
+	}
+	if s.circuit != nil {
+		st := s.circuit.state()
+		sum.Circuit = &st
+	}
+	return sum
+}
+
+// Write prints a human-readable summary, e.g.
+//
+//	stats: 3 requests, 4 http calls
+//	stats: latency min=12ms avg=20ms max=41ms
+//	stats: attempts 1=2 2=1
+//	stats: circuit open, 5 consecutive failures, resets in 28s
+func (s *RequestStats) Write(w io.Writer) error {
+	sum := s.Summary()
+	if _, err := fmt.Fprintf(w, "stats: %d requests, %d http calls\n", sum.Requests, sum.HTTPCalls); err != nil {
+		return err
+	}
+	if sum.HTTPCalls == 0 {
+		return nil
+	}
+	if err := writeCircuit(w, sum.Circuit); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "stats: latency min=%s avg=%s max=%s\n",
+		roundLatency(sum.MinLatency), roundLatency(sum.AvgLatency), roundLatency(sum.MaxLatency)); err != nil {
+		return err
+	}
+
+	keys := make([]int, 0, len(sum.Attempts))
+	for k := range sum.Attempts {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%d=%d", k, sum.Attempts[k]))
+	}
+	_, err := fmt.Fprintf(w, "stats: attempts %s\n", strings.Join(parts, " "))
+	return err
+}
+
+func roundLatency(d time.Duration) time.Duration {
+	if d >= time.Millisecond {
+		return d.Round(time.Millisecond)
+	}
+	return d.Round(time.Microsecond)
+}
+
+func writeCircuit(w io.Writer, st *CircuitState) error {
+	if st == nil {
+		return nil
+	}
+	line := fmt.Sprintf("stats: circuit %s, %d consecutive failures", st, st.Failures)
+	if st.Open {
+		line += fmt.Sprintf(", resets in %s", st.ResetIn.Round(time.Second))
+	}
+	_, err := fmt.Fprintln(w, line)
+	return err
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_Stats(t *testing.T) {
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		// The first call fails so one request needs a retry.
+		if callCount == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		time.Sleep(2 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := &Client{
+		baseURL:               server.URL,
+		clientID:              "test-id",
+		apiKey:                "test-key",
+		httpClient:            http.DefaultClient,
+		circuitBreaker:        &circuitBreaker{},
+		rateLimitBaseDelay:    testRateLimitBaseDelay,
+		serverErrorRetryDelay: testServerErrorRetryDelay,
+		token: &TokenCache{
+			Token:     "test-token",
+			ExpiresAt: time.Now().Add(10 * time.Minute),
+		},
+	}
+	stats := &RequestStats{}
+	ctx := WithHooks(context.Background(), Hooks{Stats: stats})
+
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequest("GET", server.URL+"/test", nil)
+		resp, err := c.doWithRetry(ctx, req)
+		if err != nil {
+			t.Fatalf("doWithRetry() error: %v", err)
+		}
+		closeBody(resp)
+	}
+
+	sum := stats.Summary()
+	if sum.Requests != 3 || sum.HTTPCalls != 4 {
+		t.Errorf("requests=%d http_calls=%d, want 3 and 4", sum.Requests, sum.HTTPCalls)
+	}
+	if sum.Attempts[1] != 2 || sum.Attempts[2] != 1 {
+		t.Errorf("attempts = %v, want map[1:2 2:1]", sum.Attempts)
+	}
+	if sum.MinLatency <= 0 || sum.MaxLatency < 2*time.Millisecond {
+		t.Errorf("latency min=%s max=%s, want positive min and max >= 2ms", sum.MinLatency, sum.MaxLatency)
+	}
+	if sum.AvgLatency < sum.MinLatency || sum.AvgLatency > sum.MaxLatency {
+		t.Errorf("avg latency %s outside [%s, %s]", sum.AvgLatency, sum.MinLatency, sum.MaxLatency)
+	}
+
+	var buf bytes.Buffer
+	if err := stats.Write(&buf); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	for _, want := range []string{"3 requests, 4 http calls", "latency min=", "avg=", "max=", "attempts 1=2 2=1"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output %q missing %q", buf.String(), want)
+		}
+	}
+}
+
+func TestRequestStats_ReportsCircuitState(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	c := &Client{
+		baseURL:        server.URL,
+		clientID:       "test-id",
+		apiKey:         "test-key",
+		httpClient:     http.DefaultClient,
+		circuitBreaker: &circuitBreaker{},
+		token: &TokenCache{
+			Token:     "test-token",
+			ExpiresAt: time.Now().Add(10 * time.Minute),
+		},
+	}
+	c.SetMaxRetries(0)
+	stats := &RequestStats{}
+	ctx := WithHooks(context.Background(), Hooks{Stats: stats})
+
+	for i := 0; i < CircuitBreakerThreshold; i++ {
+		req, _ := http.NewRequest("GET", server.URL+"/test", nil)
+		if resp, err := c.doWithRetry(ctx, req); err == nil {
+			closeBody(resp)
+		}
+	}
+
+	sum := stats.Summary()
+	if sum.Circuit == nil {
+		t.Fatal("summary has no circuit state")
+	}
+	if got := sum.Circuit.String(); got != "open" {
+		t.Errorf("circuit = %q, want open", got)
+	}
+	if sum.Circuit.Failures != CircuitBreakerThreshold {
+		t.Errorf("failures = %d, want %d", sum.Circuit.Failures, CircuitBreakerThreshold)
+	}
+	if sum.Circuit.ResetIn <= 0 || sum.Circuit.ResetIn > CircuitBreakerResetTime {
+		t.Errorf("reset in = %s, want within (0, %s]", sum.Circuit.ResetIn, CircuitBreakerResetTime)
+	}
+
+	var buf bytes.Buffer
+	if err := stats.Write(&buf); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	if want := "circuit open, 5 consecutive failures, resets in"; !strings.Contains(buf.String(), want) {
+		t.Errorf("output %q missing %q", buf.String(), want)
+	}
+
+	// Once the reset time has passed the next request is a trial.
+	c.circuitBreaker.mu.Lock()
+	c.circuitBreaker.lastFailure = time.Now().Add(-CircuitBreakerResetTime - time.Second)
+	c.circuitBreaker.mu.Unlock()
+	if got := c.CircuitState(); !got.HalfOpen || got.Open || got.String() != "half-open" {
+		t.Errorf("after reset time, circuit = %+v (%s), want half-open", got, got)
+	}
+}
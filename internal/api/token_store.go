@@ -0,0 +1,87 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// TokenStore persists login tokens so separate CLI invocations can reuse a
+// still-valid token instead of logging in again.
+type TokenStore interface {
+	// Load returns the stored token, or nil if there is none.
+	Load() (*TokenCache, error)
+	Save(token *TokenCache) error
+	Clear() error
+}
+
+// FileTokenStore keeps a single token in a JSON file readable only by the
+// current user (mode 0600).
+type FileTokenStore struct {
+	Path string
+}
+
+type persistedToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Load reads the token file. A missing file yields a nil token.
+func (s *FileTokenStore) Load() (*TokenCache, error) {
+	//nolint:gosec // G304: path is derived from the config directory
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var p persistedToken
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid token cache %s: %w", s.Path, err)
+	}
+	if p.Token == "" {
+		return nil, fmt.Errorf("invalid token cache %s: empty token", s.Path)
+	}
+	return &TokenCache{Token: p.Token, ExpiresAt: p.ExpiresAt}, nil
+}
+
+// Save atomically replaces the token file, creating its directory if needed.
+func (s *FileTokenStore) Save(token *TokenCache) error {
+	dir := filepath.Dir(s.Path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(persistedToken{Token: token.Token, ExpiresAt: token.ExpiresAt})
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".token-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if err := tmp.Chmod(0o600); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// Clear removes the token file.
+func (s *FileTokenStore) Clear() error {
+	if err := os.Remove(s.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
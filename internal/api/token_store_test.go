@@ -0,0 +1,200 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTokenStoreTestClient returns a client whose server issues "fresh-token"
+// on login and accepts only the tokens in valid.
+func newTokenStoreTestClient(t *testing.T, store TokenStore, valid ...string) (*Client, *atomic.Int32) {
+	t.Helper()
+	var logins atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == Endpoints.Login.Path {
+			logins.Add(1)
+			_, _ = w.Write([]byte(`{"token": "fresh-token", "expires_at": "2099-01-01T00:00:00Z"}`))
+			return
+		}
+		for _, token := range valid {
+			if r.Header.Get("Authorization") == "Bearer "+token {
+				_, _ = w.Write([]byte(`{}`))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+
+	c := &Client{
+		baseURL:        server.URL,
+		clientID:       "test-id",
+		apiKey:         "test-key",
+		httpClient:     http.DefaultClient,
+		circuitBreaker: &circuitBreaker{},
+	}
+	c.SetTokenStore(store)
+	return c, &logins
+}
+
+func getStatus(t *testing.T, c *Client) int {
+	t.Helper()
+	resp, err := c.Get(context.Background(), "/api/v1/test")
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	closeBody(resp)
+	return resp.StatusCode
+}
+
+func TestFileTokenStore_ReusesStoredToken(t *testing.T) {
+	store := &FileTokenStore{Path: filepath.Join(t.TempDir(), "tokens", "acct.json")}
+	if err := store.Save(&TokenCache{Token: "stored-token", ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	c, logins := newTokenStoreTestClient(t, store, "stored-token")
+	if status := getStatus(t, c); status != http.StatusOK {
+		t.Errorf("status = %d, want 200", status)
+	}
+	if got := logins.Load(); got != 0 {
+		t.Errorf("login calls = %d, want 0 with a valid stored token", got)
+	}
+}
+
+func TestFileTokenStore_FallsBackToLogin(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(t *testing.T, store *FileTokenStore)
+	}{
+		{"missing", func(t *testing.T, store *FileTokenStore) {}},
+		{"expired", func(t *testing.T, store *FileTokenStore) {
+			if err := store.Save(&TokenCache{Token: "stale-token", ExpiresAt: time.Now().Add(-time.Minute)}); err != nil {
+				t.Fatal(err)
+			}
+		}},
+		{"within refresh buffer", func(t *testing.T, store *FileTokenStore) {
+			if err := store.Save(&TokenCache{Token: "stale-token", ExpiresAt: time.Now().Add(TokenRefreshBuffer / 2)}); err != nil {
+				t.Fatal(err)
+			}
+		}},
+		{"corrupt", func(t *testing.T, store *FileTokenStore) {
+			if err := os.WriteFile(store.Path, []byte("{not json"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &FileTokenStore{Path: filepath.Join(t.TempDir(), "acct.json")}
+			tt.write(t, store)
+
+			c, logins := newTokenStoreTestClient(t, store, "fresh-token")
+			if status := getStatus(t, c); status != http.StatusOK {
+				t.Errorf("status = %d, want 200", status)
+			}
+			if got := logins.Load(); got != 1 {
+				t.Errorf("login calls = %d, want 1", got)
+			}
+
+			saved, err := store.Load()
+			if err != nil || saved == nil || saved.Token != "fresh-token" {
+				t.Errorf("stored token = %+v, %v; want the fresh login", saved, err)
+			}
+		})
+	}
+}
+
+func TestFileTokenStore_RevokedTokenLogsInAgain(t *testing.T) {
+	store := &FileTokenStore{Path: filepath.Join(t.TempDir(), "acct.json")}
+	if err := store.Save(&TokenCache{Token: "revoked-token", ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+
+	c, logins := newTokenStoreTestClient(t, store, "fresh-token")
+	if status := getStatus(t, c); status != http.StatusOK {
+		t.Errorf("status = %d, want 200 after logging in again", status)
+	}
+	if got := logins.Load(); got != 1 {
+		t.Errorf("login calls = %d, want 1", got)
+	}
+
+	// A 401 for a token from a fresh login is returned as-is.
+	c.token.Token = "other-token"
+	if status := getStatus(t, c); status != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", status)
+	}
+	if got := logins.Load(); got != 1 {
+		t.Errorf("login calls = %d, want no further logins", got)
+	}
+}
+
+func TestFileTokenStore_ConcurrentRevokedToken(t *testing.T) {
+	store := &FileTokenStore{Path: filepath.Join(t.TempDir(), "acct.json")}
+	if err := store.Save(&TokenCache{Token: "revoked-token", ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+
+	c, logins := newTokenStoreTestClient(t, store, "fresh-token")
+	const workers = 10
+	var wg sync.WaitGroup
+	statuses := make(chan int, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := c.Get(context.Background(), "/api/v1/test")
+			if err != nil {
+				t.Errorf("Get() error: %v", err)
+				return
+			}
+			closeBody(resp)
+			statuses <- resp.StatusCode
+		}()
+	}
+	wg.Wait()
+	close(statuses)
+
+	for status := range statuses {
+		if status != http.StatusOK {
+			t.Errorf("status = %d, want 200 after logging in again", status)
+		}
+	}
+	if got := logins.Load(); got != 1 {
+		t.Errorf("login calls = %d, want 1", got)
+	}
+}
+
+func TestFileTokenStore_FileMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not enforced on Windows")
+	}
+	store := &FileTokenStore{Path: filepath.Join(t.TempDir(), "tokens", "acct.json")}
+	// Pre-existing loose permissions are replaced on save.
+	if err := os.MkdirAll(filepath.Dir(store.Path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(store.Path, []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Save(&TokenCache{Token: "secret", ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	info, err := os.Stat(store.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0o600 {
+		t.Errorf("token file mode = %o, want 600", mode)
+	}
+}
@@ -1,12 +1,14 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"time"
 
 	"github.com/salmonumbrella/airwallex-cli/internal/wait"
@@ -33,6 +35,23 @@ type Transfer struct {
 	Reference        string      `json:"reference"`
 	Reason           string      `json:"reason"`
 	CreatedAt        string      `json:"created_at"`
+	UpdatedAt        string      `json:"updated_at,omitempty"`
+	ConversionID     string      `json:"conversion_id,omitempty"`
+	FeeAmount        json.Number `json:"fee_amount,omitempty"`
+	FeeCurrency      string      `json:"fee_currency,omitempty"`
+	// FX is not returned by the API; the CLI fills it from the related
+	// conversion when --include-fx is set.
+	FX *TransferFX `json:"fx,omitempty"`
+}
+
+// TransferFX holds the conversion details of a cross-currency transfer.
+type TransferFX struct {
+	ConversionID string      `json:"conversion_id"`
+	Rate         json.Number `json:"rate"`
+	SellCurrency string      `json:"sell_currency"`
+	SellAmount   json.Number `json:"sell_amount"`
+	BuyCurrency  string      `json:"buy_currency"`
+	BuyAmount    json.Number `json:"buy_amount"`
 }
 
 type TransfersResponse struct {
@@ -40,6 +59,18 @@ type TransfersResponse struct {
 	HasMore bool       `json:"has_more"`
 }
 
+// TransferEvent is one status change in a transfer's history
+type TransferEvent struct {
+	Status    string `json:"status"`
+	CreatedAt string `json:"created_at"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+type TransferEventsResponse struct {
+	Items   []TransferEvent `json:"items"`
+	HasMore bool            `json:"has_more"`
+}
+
 // BeneficiaryAddress contains the beneficiary's address information
 type BeneficiaryAddress struct {
 	City          string `json:"city,omitempty"`
@@ -117,7 +148,7 @@ func (c *Client) ListTransfers(ctx context.Context, status string, pageNum, page
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var result TransfersResponse
@@ -143,6 +174,56 @@ func (c *Client) GetTransfer(ctx context.Context, transferID string) (*Transfer,
 	return &t, nil
 }
 
+// ListTransferEvents retrieves a transfer's status history, oldest first
+func (c *Client) ListTransferEvents(ctx context.Context, transferID string) ([]TransferEvent, error) {
+	if err := ValidateResourceID(transferID, "transfer"); err != nil {
+		return nil, err
+	}
+	path := "/api/v1/transfers/" + url.PathEscape(transferID) + "/events"
+	var result TransferEventsResponse
+	if err := c.doJSON(ctx, http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+	SortTransferEvents(result.Items)
+	return result.Items, nil
+}
+
+// SortTransferEvents orders events chronologically. Events whose timestamps
+// cannot be parsed keep their relative order after the ones that can.
+func SortTransferEvents(events []TransferEvent) {
+	at := make([]time.Time, len(events))
+	for i, e := range events {
+		at[i] = parseEventTime(e.CreatedAt)
+	}
+	idx := make([]int, len(events))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		ta, tb := at[idx[a]], at[idx[b]]
+		if ta.IsZero() || tb.IsZero() {
+			return !ta.IsZero() && tb.IsZero()
+		}
+		return ta.Before(tb)
+	})
+	sorted := make([]TransferEvent, len(events))
+	for i, j := range idx {
+		sorted[i] = events[j]
+	}
+	copy(events, sorted)
+}
+
+// parseEventTime parses an API timestamp, returning the zero time on failure.
+// Airwallex sends both RFC 3339 and "+0000"-style offsets.
+func parseEventTime(s string) time.Time {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05-0700", "2006-01-02T15:04:05.000-0700"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
 // CreateTransfer creates a new transfer
 func (c *Client) CreateTransfer(ctx context.Context, req map[string]interface{}) (*Transfer, error) {
 	ctx, cancel := withDefaultTimeout(ctx)
@@ -156,11 +237,11 @@ func (c *Client) CreateTransfer(ctx context.Context, req map[string]interface{})
 
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", Endpoints.TransfersCreate.Path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", Endpoints.TransfersCreate.Path, resp, ParseAPIError(body))
 	}
 
 	var t Transfer
-	if err := json.NewDecoder(resp.Body).Decode(&t); err != nil {
+	if err := decodeMutation(resp.Body, &t); err != nil {
 		return nil, err
 	}
 	return &t, nil
@@ -180,26 +261,36 @@ func (c *Client) CancelTransfer(ctx context.Context, transferID string) (*Transf
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var t Transfer
-	if err := json.NewDecoder(resp.Body).Decode(&t); err != nil {
+	if err := decodeMutation(resp.Body, &t); err != nil {
 		return nil, err
 	}
+	if t.TransferID == "" {
+		t.TransferID = transferID
+	}
 	return &t, nil
 }
 
 // WaitForTransfer polls until the transfer reaches a final status.
 // Uses the unified wait pattern for consistent polling behavior.
 func (c *Client) WaitForTransfer(ctx context.Context, transferID string, timeout time.Duration) (*Transfer, error) {
+	return c.WaitForTransferEvery(ctx, transferID, timeout, 2*time.Second)
+}
+
+// WaitForTransferEvery is WaitForTransfer with a caller-chosen poll interval.
+// On timeout or a failure state it returns the last transfer seen alongside
+// the error, so callers can still report where the transfer ended up.
+func (c *Client) WaitForTransferEvery(ctx context.Context, transferID string, timeout, interval time.Duration) (*Transfer, error) {
 	if err := ValidateResourceID(transferID, "transfer"); err != nil {
 		return nil, err
 	}
 
 	cfg := wait.Config{
 		Timeout:       timeout,
-		PollInterval:  2 * time.Second,
+		PollInterval:  interval,
 		SuccessStates: []string{"COMPLETED"},
 		FailureStates: []string{"FAILED", "CANCELLED", "RETURNED"},
 	}
@@ -219,6 +310,58 @@ func (c *Client) WaitForTransfer(ctx context.Context, transferID string, timeout
 
 // ListBeneficiaries lists all beneficiaries
 func (c *Client) ListBeneficiaries(ctx context.Context, pageNum, pageSize int) (*BeneficiariesResponse, error) {
+	return c.listBeneficiaries(ctx, beneficiaryPageParams(pageNum, pageSize))
+}
+
+// ListBeneficiariesAfter lists beneficiaries created after the given beneficiary ID.
+// ID-based continuation is stable when beneficiaries are added or removed between
+// requests, unlike page_num offsets which can skip or repeat items.
+func (c *Client) ListBeneficiariesAfter(ctx context.Context, afterID string, pageSize int) (*BeneficiariesResponse, error) {
+	params, err := beneficiaryAfterParams(afterID, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return c.listBeneficiaries(ctx, params)
+}
+
+// BeneficiariesRawResponse is a beneficiary list page whose items are kept
+// as the server sent them, including fields Beneficiary does not model.
+type BeneficiariesRawResponse struct {
+	Items   []map[string]interface{} `json:"items"`
+	HasMore bool                     `json:"has_more"`
+}
+
+// ListBeneficiariesRaw is ListBeneficiaries without decoding items into
+// Beneficiary. Numbers are kept as json.Number so they round-trip exactly.
+func (c *Client) ListBeneficiariesRaw(ctx context.Context, pageNum, pageSize int) (*BeneficiariesRawResponse, error) {
+	var result BeneficiariesRawResponse
+	if err := c.getBeneficiaryPage(ctx, beneficiaryPageParams(pageNum, pageSize), &result); err != nil {
+		return nil, err
+	}
+	if result.Items == nil {
+		result.Items = []map[string]interface{}{}
+	}
+	return &result, nil
+}
+
+// ListBeneficiariesRawAfter is ListBeneficiariesAfter without decoding items
+// into Beneficiary.
+func (c *Client) ListBeneficiariesRawAfter(ctx context.Context, afterID string, pageSize int) (*BeneficiariesRawResponse, error) {
+	params, err := beneficiaryAfterParams(afterID, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	var result BeneficiariesRawResponse
+	if err := c.getBeneficiaryPage(ctx, params, &result); err != nil {
+		return nil, err
+	}
+	if result.Items == nil {
+		result.Items = []map[string]interface{}{}
+	}
+	return &result, nil
+}
+
+func beneficiaryPageParams(pageNum, pageSize int) url.Values {
 	params := url.Values{}
 	// Airwallex API requires both page_num and page_size together
 	if pageSize > 0 {
@@ -228,25 +371,24 @@ func (c *Client) ListBeneficiaries(ctx context.Context, pageNum, pageSize int) (
 		params.Set("page_num", fmt.Sprintf("%d", pageNum))
 		params.Set("page_size", fmt.Sprintf("%d", pageSize))
 	}
+	return params
+}
 
-	path := "/api/v1/beneficiaries"
-	if len(params) > 0 {
-		path += "?" + params.Encode()
-	}
-
-	resp, err := c.Get(ctx, path)
-	if err != nil {
+func beneficiaryAfterParams(afterID string, pageSize int) (url.Values, error) {
+	if err := ValidateResourceID(afterID, "beneficiary"); err != nil {
 		return nil, err
 	}
-	defer closeBody(resp)
-
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+	params := url.Values{}
+	params.Set("after_id", afterID)
+	if pageSize > 0 {
+		params.Set("page_size", fmt.Sprintf("%d", pageSize))
 	}
+	return params, nil
+}
 
+func (c *Client) listBeneficiaries(ctx context.Context, params url.Values) (*BeneficiariesResponse, error) {
 	var result BeneficiariesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := c.getBeneficiaryPage(ctx, params, &result); err != nil {
 		return nil, err
 	}
 	if result.Items == nil {
@@ -258,6 +400,28 @@ func (c *Client) ListBeneficiaries(ctx context.Context, pageNum, pageSize int) (
 	return &result, nil
 }
 
+func (c *Client) getBeneficiaryPage(ctx context.Context, params url.Values, out interface{}) error {
+	path := "/api/v1/beneficiaries"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	resp, err := c.Get(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return WrapResponseError("GET", path, resp, ParseAPIError(body))
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber()
+	return dec.Decode(out)
+}
+
 // GetBeneficiary retrieves a single beneficiary by ID
 func (c *Client) GetBeneficiary(ctx context.Context, beneficiaryID string) (*Beneficiary, error) {
 	if err := ValidateResourceID(beneficiaryID, "beneficiary"); err != nil {
@@ -274,26 +438,48 @@ func (c *Client) GetBeneficiary(ctx context.Context, beneficiaryID string) (*Ben
 
 // GetBeneficiaryRaw returns the full beneficiary data as a map for merging with updates
 func (c *Client) GetBeneficiaryRaw(ctx context.Context, beneficiaryID string) (map[string]interface{}, error) {
+	result, _, err := c.GetBeneficiaryRawWithETag(ctx, beneficiaryID)
+	return result, err
+}
+
+// GetBeneficiaryRawWithETag is GetBeneficiaryRaw that also returns the
+// response ETag ("" when the API sends none) for a conditional update.
+func (c *Client) GetBeneficiaryRawWithETag(ctx context.Context, beneficiaryID string) (map[string]interface{}, string, error) {
+	body, etag, err := c.GetBeneficiaryJSON(ctx, beneficiaryID)
+	if err != nil {
+		return nil, "", err
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, "", err
+	}
+	return result, etag, nil
+}
+
+// GetBeneficiaryJSON returns the beneficiary response body exactly as the
+// server sent it, keeping its field order, along with the response ETag.
+func (c *Client) GetBeneficiaryJSON(ctx context.Context, beneficiaryID string) ([]byte, string, error) {
 	if err := ValidateResourceID(beneficiaryID, "beneficiary"); err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	path := "/api/v1/beneficiaries/" + url.PathEscape(beneficiaryID)
 	resp, err := c.Get(ctx, path)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer closeBody(resp)
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
 	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, "", WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
-
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+	if !json.Valid(body) {
+		return nil, "", fmt.Errorf("GET %s returned invalid JSON", path)
 	}
-	return result, nil
+	return body, resp.Header.Get("ETag"), nil
 }
 
 // CreateBeneficiary creates a new beneficiary
@@ -310,11 +496,11 @@ func (c *Client) CreateBeneficiary(ctx context.Context, req map[string]interface
 
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var b Beneficiary
-	if err := json.NewDecoder(resp.Body).Decode(&b); err != nil {
+	if err := decodeMutation(resp.Body, &b); err != nil {
 		return nil, err
 	}
 	nilGuardBeneficiary(&b)
@@ -323,26 +509,40 @@ func (c *Client) CreateBeneficiary(ctx context.Context, req map[string]interface
 
 // UpdateBeneficiary updates a beneficiary
 func (c *Client) UpdateBeneficiary(ctx context.Context, beneficiaryID string, update map[string]interface{}) (*Beneficiary, error) {
+	return c.UpdateBeneficiaryIfMatch(ctx, beneficiaryID, update, "")
+}
+
+// UpdateBeneficiaryIfMatch updates a beneficiary only if its ETag still
+// matches etag. A 412 response returns *PreconditionFailedError. An empty
+// etag makes the update unconditional. update may be a map or pre-encoded
+// JSON (json.RawMessage).
+func (c *Client) UpdateBeneficiaryIfMatch(ctx context.Context, beneficiaryID string, update interface{}, etag string) (*Beneficiary, error) {
 	if err := ValidateResourceID(beneficiaryID, "beneficiary"); err != nil {
 		return nil, err
 	}
 
 	path := "/api/v1/beneficiaries/" + url.PathEscape(beneficiaryID) + "/update"
-	resp, err := c.Post(ctx, path, update)
+	resp, err := c.PostIfMatch(ctx, path, update, etag)
 	if err != nil {
 		return nil, err
 	}
 	defer closeBody(resp)
 
+	if resp.StatusCode == http.StatusPreconditionFailed && etag != "" {
+		return nil, &PreconditionFailedError{Resource: "beneficiary", ID: beneficiaryID, ETag: etag}
+	}
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var b Beneficiary
-	if err := json.NewDecoder(resp.Body).Decode(&b); err != nil {
+	if err := decodeMutation(resp.Body, &b); err != nil {
 		return nil, err
 	}
+	if b.BeneficiaryID == "" {
+		b.BeneficiaryID = beneficiaryID
+	}
 	nilGuardBeneficiary(&b)
 	return &b, nil
 }
@@ -361,25 +561,35 @@ func (c *Client) DeleteBeneficiary(ctx context.Context, beneficiaryID string) er
 
 	if resp.StatusCode != 200 && resp.StatusCode != 204 {
 		body, _ := io.ReadAll(resp.Body)
-		return WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 	return nil
 }
 
-// ValidateBeneficiary validates beneficiary details without creating
-func (c *Client) ValidateBeneficiary(ctx context.Context, req map[string]interface{}) error {
+// ValidateBeneficiary validates beneficiary details without creating and
+// returns the API's validation response.
+func (c *Client) ValidateBeneficiary(ctx context.Context, req map[string]interface{}) (map[string]interface{}, error) {
 	path := "/api/v1/beneficiaries/validate"
 	resp, err := c.Post(ctx, path, req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer closeBody(resp)
 
+	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
-	return nil
+
+	// The response may carry warnings or normalized fields; an empty body
+	// just means the details are valid.
+	result := map[string]interface{}{}
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse validation response: %w", err)
+		}
+	}
+	return result, nil
 }
 
 // GetConfirmationLetter retrieves a transfer confirmation letter as PDF
@@ -401,7 +611,7 @@ func (c *Client) GetConfirmationLetter(ctx context.Context, transferID string, f
 
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	pdfData, err := io.ReadAll(resp.Body)
@@ -2,12 +2,45 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
 
+func TestTransfer_AmountsRoundTripExactly(t *testing.T) {
+	// None of these survive a float64 round trip.
+	in := `{"id":"tfr_1","transfer_amount":12345678901234.56,"source_amount":9007199254740993.01,"fee_amount":0.10}`
+
+	var tr Transfer
+	if err := json.Unmarshal([]byte(in), &tr); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if tr.TransferAmount != jn("12345678901234.56") || tr.SourceAmount != jn("9007199254740993.01") {
+		t.Errorf("amounts = %s/%s, want exact digits", tr.TransferAmount, tr.SourceAmount)
+	}
+
+	out, err := json.Marshal(tr)
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+	for _, want := range []string{`"transfer_amount":12345678901234.56`, `"source_amount":9007199254740993.01`, `"fee_amount":0.10`} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("marshalled transfer missing %s: %s", want, out)
+		}
+	}
+
+	var d TransactionDispute
+	if err := json.Unmarshal([]byte(`{"id":"dsp_1","amount":12345678901234.56}`), &d); err != nil {
+		t.Fatalf("Unmarshal(dispute) error: %v", err)
+	}
+	if out, _ := json.Marshal(d); !strings.Contains(string(out), `"amount":12345678901234.56`) {
+		t.Errorf("marshalled dispute lost precision: %s", out)
+	}
+}
+
 // =====================================================
 // Beneficiary Tests
 // =====================================================
@@ -134,6 +167,49 @@ func TestListBeneficiaries_WithoutPagination(t *testing.T) {
 	}
 }
 
+func TestListBeneficiariesAfter_SendsAfterID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if got := q.Get("after_id"); got != "ben_123" {
+			t.Errorf("after_id = %q, want 'ben_123'", got)
+		}
+		if got := q.Get("page_size"); got != "50" {
+			t.Errorf("page_size = %q, want '50'", got)
+		}
+		if q.Has("page_num") {
+			t.Errorf("page_num should not be sent with after_id, got %q", q.Get("page_num"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items": [{"id": "ben_124"}], "has_more": false}`))
+	}))
+	defer server.Close()
+
+	c := &Client{
+		baseURL:        server.URL,
+		clientID:       "test-id",
+		apiKey:         "test-key",
+		httpClient:     http.DefaultClient,
+		circuitBreaker: &circuitBreaker{},
+		token: &TokenCache{
+			Token:     "test-token",
+			ExpiresAt: time.Now().Add(10 * time.Minute),
+		},
+	}
+
+	result, err := c.ListBeneficiariesAfter(context.Background(), "ben_123", 50)
+	if err != nil {
+		t.Fatalf("ListBeneficiariesAfter() error: %v", err)
+	}
+	if len(result.Items) != 1 || result.Items[0].BeneficiaryID != "ben_124" {
+		t.Errorf("items = %+v, want [ben_124]", result.Items)
+	}
+
+	if _, err := c.ListBeneficiariesAfter(context.Background(), "bad/id", 50); err == nil {
+		t.Error("expected error for invalid after ID")
+	}
+}
+
 func TestGetBeneficiary_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/api/v1/beneficiaries/ben_123" {
@@ -643,8 +719,7 @@ func TestValidateBeneficiary_Success(t *testing.T) {
 		},
 	}
 
-	err := c.ValidateBeneficiary(context.Background(), req)
-	if err != nil {
+	if _, err := c.ValidateBeneficiary(context.Background(), req); err != nil {
 		t.Fatalf("ValidateBeneficiary() error: %v", err)
 	}
 }
@@ -678,7 +753,7 @@ func TestValidateBeneficiary_ValidationError(t *testing.T) {
 		},
 	}
 
-	err := c.ValidateBeneficiary(context.Background(), req)
+	_, err := c.ValidateBeneficiary(context.Background(), req)
 	if err == nil {
 		t.Error("expected validation error, got nil")
 	}
@@ -1216,6 +1291,96 @@ func TestCancelTransfer_AlreadyCancelled(t *testing.T) {
 	}
 }
 
+func TestCreateTransfer_EmptyBodyIsSuccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "0")
+		if strings.HasSuffix(r.URL.Path, "/create") {
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{
+		baseURL:        server.URL,
+		clientID:       "test-id",
+		apiKey:         "test-key",
+		httpClient:     http.DefaultClient,
+		circuitBreaker: &circuitBreaker{},
+		token: &TokenCache{
+			Token:     "test-token",
+			ExpiresAt: time.Now().Add(10 * time.Minute),
+		},
+	}
+
+	tr, err := c.CreateTransfer(context.Background(), map[string]interface{}{"beneficiary_id": "ben_123"})
+	if err != nil {
+		t.Fatalf("CreateTransfer() error on 201 with empty body: %v", err)
+	}
+	if tr == nil || tr.TransferID != "" {
+		t.Errorf("CreateTransfer() = %+v, want an empty transfer", tr)
+	}
+
+	// Actions on a known resource report its ID even without a body.
+	tr, err = c.CancelTransfer(context.Background(), "tfr_123")
+	if err != nil {
+		t.Fatalf("CancelTransfer() error on empty body: %v", err)
+	}
+	if tr.TransferID != "tfr_123" {
+		t.Errorf("CancelTransfer() ID = %q, want tfr_123", tr.TransferID)
+	}
+}
+
+func TestListTransferEvents_SortsChronologically(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/transfers/tfr_123/events" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"items": [
+				{"status": "COMPLETED", "created_at": "2025-01-16T09:00:00+0000"},
+				{"status": "NEW", "created_at": "2025-01-15T10:30:00Z"},
+				{"status": "PROCESSING", "created_at": "2025-01-15T12:00:00.250+00:00", "reason": "Sent to SWIFT"},
+				{"status": "SUSPENDED", "created_at": ""}
+			],
+			"has_more": false
+		}`))
+	}))
+	defer server.Close()
+
+	c := &Client{
+		baseURL:        server.URL,
+		clientID:       "test-id",
+		apiKey:         "test-key",
+		httpClient:     http.DefaultClient,
+		circuitBreaker: &circuitBreaker{},
+		token: &TokenCache{
+			Token:     "test-token",
+			ExpiresAt: time.Now().Add(10 * time.Minute),
+		},
+	}
+
+	events, err := c.ListTransferEvents(context.Background(), "tfr_123")
+	if err != nil {
+		t.Fatalf("ListTransferEvents() error: %v", err)
+	}
+	var got []string
+	for _, e := range events {
+		got = append(got, e.Status)
+	}
+	want := []string{"NEW", "PROCESSING", "COMPLETED", "SUSPENDED"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("statuses = %v, want %v", got, want)
+	}
+	if events[1].Reason != "Sent to SWIFT" {
+		t.Errorf("events[1].Reason = %q, want %q", events[1].Reason, "Sent to SWIFT")
+	}
+
+	if _, err := c.ListTransferEvents(context.Background(), "invalid/id"); err == nil {
+		t.Error("expected error for invalid transfer ID, got nil")
+	}
+}
+
 // =====================================================
 // Confirmation Letter Tests
 // =====================================================
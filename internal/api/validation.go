@@ -9,8 +9,14 @@ import (
 var (
 	// Generic ID pattern: alphanumeric with underscores/dashes, reasonable length
 	resourceIDPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,128}$`)
+
+	// Idempotency keys travel in a header: printable ASCII, no spaces
+	idempotencyKeyPattern = regexp.MustCompile(`^[\x21-\x7E]+$`)
 )
 
+// MaxIdempotencyKeyLength is the longest x-idempotency-key accepted.
+const MaxIdempotencyKeyLength = 128
+
 // ValidateResourceID validates that an ID follows expected patterns
 func ValidateResourceID(id, resourceType string) error {
 	if id == "" {
@@ -24,3 +30,17 @@ func ValidateResourceID(id, resourceType string) error {
 	}
 	return nil
 }
+
+// ValidateIdempotencyKey checks a caller-supplied x-idempotency-key.
+func ValidateIdempotencyKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("idempotency key cannot be empty")
+	}
+	if len(key) > MaxIdempotencyKeyLength {
+		return fmt.Errorf("idempotency key too long (max %d characters)", MaxIdempotencyKeyLength)
+	}
+	if !idempotencyKeyPattern.MatchString(key) {
+		return fmt.Errorf("idempotency key must be printable ASCII without spaces")
+	}
+	return nil
+}
@@ -154,3 +154,38 @@ func TestValidateResourceID(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateIdempotencyKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		wantErr     bool
+		errContains string
+	}{
+		{name: "uuid", key: "3f2b8c1e-9d4a-4e7b-8f6a-1c2d3e4f5a6b"},
+		{name: "punctuation allowed", key: "payroll:2024-05/run#1"},
+		{name: "exactly 128 characters", key: strings.Repeat("k", 128)},
+		{name: "empty", key: "", wantErr: true, errContains: "cannot be empty"},
+		{name: "too long", key: strings.Repeat("k", 129), wantErr: true, errContains: "too long"},
+		{name: "space", key: "run 1", wantErr: true, errContains: "printable ASCII"},
+		{name: "newline injection", key: "run1\r\nX-Evil: 1", wantErr: true, errContains: "printable ASCII"},
+		{name: "non-ASCII", key: "clé", wantErr: true, errContains: "printable ASCII"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateIdempotencyKey(tt.key)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ValidateIdempotencyKey() expected error but got nil")
+					return
+				}
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("ValidateIdempotencyKey() error = %v, want error containing %q", err, tt.errContains)
+				}
+			} else if err != nil {
+				t.Errorf("ValidateIdempotencyKey() unexpected error = %v", err)
+			}
+		})
+	}
+}
@@ -116,7 +116,7 @@ func (c *Client) ListWebhooks(ctx context.Context, pageNum, pageSize int) (*Webh
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var result WebhooksResponse
@@ -141,11 +141,11 @@ func (c *Client) GetWebhook(ctx context.Context, webhookID string) (*Webhook, er
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("GET", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("GET", path, resp, ParseAPIError(body))
 	}
 
 	var wh Webhook
-	if err := json.NewDecoder(resp.Body).Decode(&wh); err != nil {
+	if err := decodeResource(resp.Body, &wh); err != nil {
 		return nil, err
 	}
 	return &wh, nil
@@ -175,11 +175,11 @@ func (c *Client) CreateWebhook(ctx context.Context, webhookURL string, events []
 
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return nil, WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 
 	var wh Webhook
-	if err := json.NewDecoder(resp.Body).Decode(&wh); err != nil {
+	if err := decodeMutation(resp.Body, &wh); err != nil {
 		return nil, err
 	}
 	return &wh, nil
@@ -203,7 +203,7 @@ func (c *Client) DeleteWebhook(ctx context.Context, webhookID string) error {
 
 	if resp.StatusCode != 204 && resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return WrapError("POST", path, resp.StatusCode, ParseAPIError(body))
+		return WrapResponseError("POST", path, resp, ParseAPIError(body))
 	}
 	return nil
 }
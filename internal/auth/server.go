@@ -304,6 +304,12 @@ func (s *SetupServer) validateCredentials(ctx context.Context, accountName, clie
 		return fmt.Errorf("failed to create client: %v", err)
 	}
 
+	return CheckCredentials(ctx, client)
+}
+
+// CheckCredentials logs in with client and makes one authenticated read,
+// the check behind the setup page's "Test connection".
+func CheckCredentials(ctx context.Context, client *api.Client) error {
 	resp, err := client.Get(ctx, "/api/v1/balances/current")
 	if err != nil {
 		return fmt.Errorf("connection failed: %v", err)
@@ -55,7 +55,7 @@ func parseJSON(r io.Reader) ([]map[string]interface{}, error) {
 
 	// Try parsing as JSON array first
 	var items []map[string]interface{}
-	if err := json.Unmarshal(data, &items); err == nil {
+	if err := decodeItem(data, &items); err == nil {
 		// Check item count limit
 		if len(items) > MaxItemCount {
 			return nil, fmt.Errorf("too many items: batch contains %d items, maximum is %d", len(items), MaxItemCount)
@@ -74,7 +74,7 @@ func parseJSON(r io.Reader) ([]map[string]interface{}, error) {
 		}
 
 		var item map[string]interface{}
-		if err := json.Unmarshal([]byte(line), &item); err != nil {
+		if err := decodeItem([]byte(line), &item); err != nil {
 			return nil, fmt.Errorf("failed to parse JSON line: %w", err)
 		}
 		items = append(items, item)
@@ -96,10 +96,75 @@ func parseJSON(r io.Reader) ([]map[string]interface{}, error) {
 	return items, nil
 }
 
-// Result represents the result of a batch operation
+// decodeItem decodes one JSON value, keeping numbers as json.Number so
+// amounts are forwarded with exactly the digits given.
+func decodeItem(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return fmt.Errorf("unexpected data after JSON value")
+	}
+	return nil
+}
+
+// Decoder reads newline-delimited JSON items one line at a time, so callers
+// can act on each item as it arrives instead of buffering the whole input.
+type Decoder struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// LineError reports a line that is not a JSON object. The Decoder can keep
+// reading after it.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: failed to parse JSON line: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error { return e.Err }
+
+// NewDecoder returns a Decoder reading NDJSON from r. Each line may be up to
+// MaxInputSize bytes.
+func NewDecoder(r io.Reader) *Decoder {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxInputSize)
+	return &Decoder{scanner: scanner}
+}
+
+// Next returns the next item, skipping blank lines. It returns io.EOF at the
+// end of input and a *LineError for a line that does not parse.
+func (d *Decoder) Next() (map[string]interface{}, error) {
+	for d.scanner.Scan() {
+		d.line++
+		line := strings.TrimSpace(d.scanner.Text())
+		if line == "" {
+			continue
+		}
+		var item map[string]interface{}
+		if err := decodeItem([]byte(line), &item); err != nil {
+			return nil, &LineError{Line: d.line, Err: err}
+		}
+		return item, nil
+	}
+	if err := d.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan input: %w", err)
+	}
+	return nil, io.EOF
+}
+
+// Result represents the result of a batch operation. A dry-run item was
+// previewed rather than sent; it counts as a success with no ID.
 type Result struct {
 	Index   int                    `json:"index"`
 	Success bool                   `json:"success"`
+	DryRun  bool                   `json:"dry_run,omitempty"`
 	ID      string                 `json:"id,omitempty"`
 	Error   string                 `json:"error,omitempty"`
 	Input   map[string]interface{} `json:"input,omitempty"`
@@ -107,7 +172,20 @@ type Result struct {
 
 // Summary summarizes batch results
 type Summary struct {
-	Total   int `json:"total"`
-	Success int `json:"success"`
-	Failed  int `json:"failed"`
+	Total     int `json:"total"`
+	Success   int `json:"success"`
+	Failed    int `json:"failed"`
+	Previewed int `json:"previewed,omitempty"`
+}
+
+// Failures returns only the failed results, preserving their original order
+// and indexes so they can be matched back to the input.
+func Failures(results []Result) []Result {
+	failed := make([]Result, 0, len(results))
+	for _, r := range results {
+		if !r.Success {
+			failed = append(failed, r)
+		}
+	}
+	return failed
 }
@@ -1,7 +1,10 @@
 package batch
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -47,6 +50,31 @@ func TestReadItems_NDJSON(t *testing.T) {
 	}
 }
 
+func TestParseJSON_KeepsExactAmounts(t *testing.T) {
+	for name, input := range map[string]string{
+		"array":  `[{"transfer_amount": 12345678901234.56}]`,
+		"ndjson": "{\"transfer_amount\": 12345678901234.56}\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			items, err := parseJSON(strings.NewReader(input))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := items[0]["transfer_amount"]; got != json.Number("12345678901234.56") {
+				t.Errorf("transfer_amount = %#v, want the exact json.Number", got)
+			}
+		})
+	}
+
+	item, err := NewDecoder(strings.NewReader(`{"source_amount": 0.10}`)).Next()
+	if err != nil {
+		t.Fatalf("Next() error: %v", err)
+	}
+	if got := item["source_amount"]; got != json.Number("0.10") {
+		t.Errorf("source_amount = %#v, want json.Number 0.10", got)
+	}
+}
+
 func TestParseJSON_MaxInputSize(t *testing.T) {
 	// Create input larger than 10MB
 	largeInput := strings.Repeat(`{"id": "test"}`+"\n", 1000000) // ~14MB
@@ -96,3 +124,47 @@ func TestParseJSON_NDJSONLargeLine(t *testing.T) {
 		t.Errorf("expected data length %d, got %d", len(largeValue), len(items[0]["data"].(string)))
 	}
 }
+
+func TestFailures(t *testing.T) {
+	results := []Result{
+		{Index: 0, Success: true, ID: "a"},
+		{Index: 1, Success: false, Error: "boom"},
+		{Index: 2, Success: true, ID: "c"},
+		{Index: 3, Success: false, Error: "bang"},
+	}
+
+	failed := Failures(results)
+	if len(failed) != 2 {
+		t.Fatalf("expected 2 failures, got %d", len(failed))
+	}
+	if failed[0].Index != 1 || failed[1].Index != 3 {
+		t.Errorf("expected indexes [1 3], got [%d %d]", failed[0].Index, failed[1].Index)
+	}
+	if got := Failures(nil); len(got) != 0 {
+		t.Errorf("expected no failures for nil input, got %d", len(got))
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	d := NewDecoder(strings.NewReader("{\"a\": 1}\n\n not json\n{\"b\": 2}\n"))
+
+	item, err := d.Next()
+	if err != nil || item["a"] == nil {
+		t.Fatalf("first Next() = %v, %v", item, err)
+	}
+
+	_, err = d.Next()
+	var lineErr *LineError
+	if !errors.As(err, &lineErr) || lineErr.Line != 3 {
+		t.Fatalf("second Next() error = %v, want LineError on line 3", err)
+	}
+
+	item, err = d.Next()
+	if err != nil || item["b"] == nil {
+		t.Fatalf("Next() after a bad line = %v, %v", item, err)
+	}
+
+	if _, err := d.Next(); err != io.EOF {
+		t.Fatalf("Next() at end = %v, want io.EOF", err)
+	}
+}
@@ -0,0 +1,87 @@
+package batch
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ReadCSVItems reads items from a CSV file or stdin ("-" or empty). The first
+// row is the header; each later row becomes an item keyed by the lower-cased
+// header names. Empty cells are left out so they don't override defaults.
+func ReadCSVItems(filename string) ([]map[string]interface{}, error) {
+	var reader io.Reader
+
+	if filename == "" || filename == "-" {
+		reader = os.Stdin
+	} else {
+		//nolint:gosec // G304: filename comes from user input, intentional
+		f, err := os.Open(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open file: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		reader = f
+	}
+
+	return parseCSV(reader)
+}
+
+func parseCSV(r io.Reader) ([]map[string]interface{}, error) {
+	limitedReader := io.LimitReader(r, MaxInputSize+1)
+	data, err := io.ReadAll(limitedReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+	if len(data) > MaxInputSize {
+		return nil, fmt.Errorf("input too large: exceeds maximum size of %d bytes", MaxInputSize)
+	}
+
+	cr := csv.NewReader(strings.NewReader(strings.TrimPrefix(string(data), "\ufeff")))
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("no CSV header found in input")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+	for i, name := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(name))
+		if header[i] == "" {
+			return nil, fmt.Errorf("CSV header column %d is empty", i+1)
+		}
+	}
+
+	var items []map[string]interface{}
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CSV: %w", err)
+		}
+
+		item := make(map[string]interface{}, len(record))
+		for i, value := range record {
+			if value = strings.TrimSpace(value); value != "" {
+				item[header[i]] = value
+			}
+		}
+		items = append(items, item)
+
+		if len(items) > MaxItemCount {
+			return nil, fmt.Errorf("too many items: batch contains more than %d items", MaxItemCount)
+		}
+	}
+
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no rows found in CSV input")
+	}
+	return items, nil
+}
@@ -0,0 +1,59 @@
+package batch
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadCSVItems(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "payments.csv")
+	content := "\ufeffBeneficiary_ID, Amount,currency,reference,reason\n" +
+		"ben_1,100.50,USD,\"INV-1, part A\",payment_to_supplier\n" +
+		"ben_2,0.10,EUR,,\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := ReadCSVItems(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []map[string]interface{}{
+		{"beneficiary_id": "ben_1", "amount": "100.50", "currency": "USD", "reference": "INV-1, part A", "reason": "payment_to_supplier"},
+		{"beneficiary_id": "ben_2", "amount": "0.10", "currency": "EUR"},
+	}
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("items = %v, want %v", items, want)
+	}
+}
+
+func TestParseCSV_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"empty", "", "no CSV header"},
+		{"header only", "beneficiary_id,amount\n", "no rows"},
+		{"blank header", "beneficiary_id,,amount\nben_1,x,1\n", "column 2 is empty"},
+		{"ragged row", "beneficiary_id,amount\nben_1\n", "wrong number of fields"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseCSV(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseCSV_MaxItemCount(t *testing.T) {
+	input := "reference\n" + strings.Repeat("x\n", MaxItemCount+1)
+	if _, err := parseCSV(strings.NewReader(input)); err == nil || !strings.Contains(err.Error(), "too many items") {
+		t.Errorf("error = %v, want too many items", err)
+	}
+}
@@ -0,0 +1,91 @@
+package benroute
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Bank is an entry in the built-in bank directory.
+type Bank struct {
+	Name    string
+	Country string // ISO 3166-1 alpha-2
+	SWIFT   string
+	Aliases []string // other names the bank is commonly known by
+}
+
+// bankDirectory lists the head-office SWIFT codes of major banks. It is not
+// exhaustive; unknown banks need their code passed explicitly.
+var bankDirectory = []Bank{
+	{Name: "JPMorgan Chase Bank", Country: "US", SWIFT: "CHASUS33", Aliases: []string{"Chase", "JPMorgan", "JP Morgan Chase"}},
+	{Name: "Bank of America", Country: "US", SWIFT: "BOFAUS3N", Aliases: []string{"BofA"}},
+	{Name: "Citibank", Country: "US", SWIFT: "CITIUS33", Aliases: []string{"Citi"}},
+	{Name: "Wells Fargo Bank", Country: "US", SWIFT: "WFBIUS6S", Aliases: []string{"Wells Fargo"}},
+	{Name: "Barclays Bank", Country: "GB", SWIFT: "BARCGB22", Aliases: []string{"Barclays"}},
+	{Name: "HSBC UK Bank", Country: "GB", SWIFT: "HBUKGB4B", Aliases: []string{"HSBC"}},
+	{Name: "Lloyds Bank", Country: "GB", SWIFT: "LOYDGB2L", Aliases: []string{"Lloyds"}},
+	{Name: "National Westminster Bank", Country: "GB", SWIFT: "NWBKGB2L", Aliases: []string{"NatWest"}},
+	{Name: "Deutsche Bank", Country: "DE", SWIFT: "DEUTDEFF"},
+	{Name: "Commerzbank", Country: "DE", SWIFT: "COBADEFF"},
+	{Name: "BNP Paribas", Country: "FR", SWIFT: "BNPAFRPP"},
+	{Name: "Societe Generale", Country: "FR", SWIFT: "SOGEFRPP", Aliases: []string{"Société Générale"}},
+	{Name: "MUFG Bank", Country: "JP", SWIFT: "BOTKJPJT", Aliases: []string{"Bank of Tokyo-Mitsubishi UFJ", "MUFG"}},
+	{Name: "Mizuho Bank", Country: "JP", SWIFT: "MHCBJPJT", Aliases: []string{"Mizuho"}},
+	{Name: "Sumitomo Mitsui Banking Corporation", Country: "JP", SWIFT: "SMBCJPJT", Aliases: []string{"SMBC"}},
+	{Name: "The Hongkong and Shanghai Banking Corporation", Country: "HK", SWIFT: "HSBCHKHH", Aliases: []string{"HSBC"}},
+	{Name: "Bank of China (Hong Kong)", Country: "HK", SWIFT: "BKCHHKHH", Aliases: []string{"Bank of China", "BOCHK"}},
+	{Name: "Hang Seng Bank", Country: "HK", SWIFT: "HASEHKHH", Aliases: []string{"Hang Seng"}},
+	{Name: "DBS Bank", Country: "SG", SWIFT: "DBSSSGSG", Aliases: []string{"DBS"}},
+	{Name: "Oversea-Chinese Banking Corporation", Country: "SG", SWIFT: "OCBCSGSG", Aliases: []string{"OCBC"}},
+	{Name: "United Overseas Bank", Country: "SG", SWIFT: "UOVBSGSG", Aliases: []string{"UOB"}},
+	{Name: "Commonwealth Bank of Australia", Country: "AU", SWIFT: "CTBAAU2S", Aliases: []string{"CommBank", "CBA"}},
+	{Name: "Westpac Banking Corporation", Country: "AU", SWIFT: "WPACAU2S", Aliases: []string{"Westpac"}},
+	{Name: "Australia and New Zealand Banking Group", Country: "AU", SWIFT: "ANZBAU3M", Aliases: []string{"ANZ"}},
+	{Name: "National Australia Bank", Country: "AU", SWIFT: "NATAAU33", Aliases: []string{"NAB"}},
+	{Name: "Royal Bank of Canada", Country: "CA", SWIFT: "ROYCCAT2", Aliases: []string{"RBC"}},
+	{Name: "Toronto-Dominion Bank", Country: "CA", SWIFT: "TDOMCATTTOR", Aliases: []string{"TD", "TD Bank"}},
+	{Name: "Bank of Nova Scotia", Country: "CA", SWIFT: "NOSCCATT", Aliases: []string{"Scotiabank"}},
+	{Name: "Bank of Montreal", Country: "CA", SWIFT: "BOFMCAM2", Aliases: []string{"BMO"}},
+	{Name: "Industrial and Commercial Bank of China", Country: "CN", SWIFT: "ICBKCNBJ", Aliases: []string{"ICBC"}},
+	{Name: "Bank of China", Country: "CN", SWIFT: "BKCHCNBJ", Aliases: []string{"BOC"}},
+	{Name: "China Construction Bank", Country: "CN", SWIFT: "PCBCCNBJ", Aliases: []string{"CCB"}},
+}
+
+// LookupBank returns the directory banks in country matching name. A bank
+// whose name or alias equals name (ignoring case and punctuation) is returned
+// alone; otherwise every bank whose name or alias contains name is returned.
+func LookupBank(country, name string) []Bank {
+	query := normalizeBankName(name)
+	if query == "" {
+		return nil
+	}
+
+	var partial []Bank
+	for _, b := range bankDirectory {
+		if !strings.EqualFold(b.Country, country) {
+			continue
+		}
+		contains := false
+		for _, n := range append([]string{b.Name}, b.Aliases...) {
+			norm := normalizeBankName(n)
+			if norm == query {
+				return []Bank{b}
+			}
+			if strings.Contains(norm, query) {
+				contains = true
+			}
+		}
+		if contains {
+			partial = append(partial, b)
+		}
+	}
+	return partial
+}
+
+// normalizeBankName lower-cases name and reduces it to letters and digits
+// separated by single spaces.
+func normalizeBankName(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
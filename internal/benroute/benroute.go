@@ -0,0 +1,306 @@
+// Package benroute validates and resolves beneficiary bank routing details.
+//
+// Field maps are keyed by CLI flag name (e.g. "sort-code", "zengin-bank-code")
+// so commands can pass their collected flag values straight through.
+package benroute
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/flagmap"
+)
+
+var (
+	reDigits3     = regexp.MustCompile(`^\d{3}$`)          // institution-number, zengin-branch-code, korea-bank-code, hk-bank-code
+	reDigits4     = regexp.MustCompile(`^\d{4}$`)          // zengin-bank-code
+	reDigits5     = regexp.MustCompile(`^\d{5}$`)          // transit-number
+	reDigits6     = regexp.MustCompile(`^\d{6}$`)          // sort-code, bsb
+	reDigits7     = regexp.MustCompile(`^\d{7}$`)          // sg-bank-code
+	reDigits9     = regexp.MustCompile(`^\d{9}$`)          // routing-number
+	reDigits11    = regexp.MustCompile(`^\d{11}$`)         // cpf
+	reDigits12    = regexp.MustCompile(`^\d{12}$`)         // cnaps
+	reDigits14    = regexp.MustCompile(`^\d{14}$`)         // cnpj
+	reDigits18    = regexp.MustCompile(`^\d{18}$`)         // clabe
+	reDigits4or5  = regexp.MustCompile(`^\d{4,5}$`)        // clearing-number
+	reDigits7to9  = regexp.MustCompile(`^\d{7,9}$`)        // fps-id
+	reDigits9or11 = regexp.MustCompile(`^\d{9}$|^\d{11}$`) // payid-abn
+	rePhoneCA     = regexp.MustCompile(`^\+1-\d{10}$`)     // Canada phone
+	rePhoneAU     = regexp.MustCompile(`^\+61-\d{9}$`)     // Australia PayID phone
+	reIFSC        = regexp.MustCompile(`^[A-Z]{4}0[A-Z0-9]{6}$`)
+	reNRIC        = regexp.MustCompile(`^[STFG]\d{7}[A-Z]$`)
+	reEmail       = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	reIBAN        = regexp.MustCompile(`^[A-Z]{2}\d{2}[A-Z0-9]{11,30}$`)
+)
+
+// formatRule checks a single flag's value against a pattern.
+type formatRule struct {
+	flag    string
+	re      *regexp.Regexp
+	upper   bool // match against the upper-cased value
+	message string
+}
+
+// formatRules are checked in order for every flag that is set.
+var formatRules = []formatRule{
+	{flag: "phone", re: rePhoneCA, message: "--phone must match format +1-nnnnnnnnnn (e.g., +1-4165551234)"},
+	{flag: "institution-number", re: reDigits3, message: "--institution-number must be exactly 3 digits"},
+	{flag: "transit-number", re: reDigits5, message: "--transit-number must be exactly 5 digits"},
+	{flag: "routing-number", re: reDigits9, message: "--routing-number must be exactly 9 digits"},
+	{flag: "sort-code", re: reDigits6, message: "--sort-code must be exactly 6 digits"},
+	{flag: "bsb", re: reDigits6, message: "--bsb must be exactly 6 digits"},
+	{flag: "clabe", re: reDigits18, message: "--clabe must be exactly 18 digits"},
+	{flag: "ifsc", re: reIFSC, upper: true, message: "--ifsc must be 11 characters: 4 letters, 0, then 6 alphanumeric (e.g., SBIN0001234)"},
+	{flag: "zengin-bank-code", re: reDigits4, message: "--zengin-bank-code must be exactly 4 digits"},
+	{flag: "zengin-branch-code", re: reDigits3, message: "--zengin-branch-code must be exactly 3 digits"},
+	{flag: "cnaps", re: reDigits12, message: "--cnaps must be exactly 12 digits"},
+	{flag: "korea-bank-code", re: reDigits3, message: "--korea-bank-code must be exactly 3 digits"},
+	{flag: "cpf", re: reDigits11, message: "--cpf must be exactly 11 digits"},
+	{flag: "cnpj", re: reDigits14, message: "--cnpj must be exactly 14 digits"},
+	{flag: "nric", re: reNRIC, upper: true, message: "--nric must be 9 characters in format SnnnnnnnA (e.g., S1234567A)"},
+	{flag: "sg-bank-code", re: reDigits7, message: "--sg-bank-code must be exactly 7 digits"},
+	{flag: "payid-phone", re: rePhoneAU, message: "--payid-phone must be in format +61-nnnnnnnnn"},
+	{flag: "payid-email", re: reEmail, message: "--payid-email must be a valid email address"},
+	{flag: "payid-abn", re: reDigits9or11, message: "--payid-abn must be 9 or 11 digits"},
+	{flag: "clearing-number", re: reDigits4or5, message: "--clearing-number must be 4-5 digits"},
+	{flag: "hk-bank-code", re: reDigits3, message: "--hk-bank-code must be exactly 3 digits"},
+	{flag: "fps-id", re: reDigits7to9, message: "--fps-id must be 7-9 digits"},
+}
+
+// Validate checks the routing fields for a bank country and local clearing
+// system (method, e.g. INTERAC; may be empty). It returns every problem found,
+// or nil when the fields are valid.
+func Validate(country, method string, fields map[string]string) []error {
+	var errs []error
+	add := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	// Fields that only make sense together.
+	pairs := [][2]string{
+		{"institution-number", "transit-number"},
+		{"zengin-bank-code", "zengin-branch-code"},
+		{"zengin-branch-code", "zengin-bank-code"},
+	}
+	for _, p := range pairs {
+		if fields[p[0]] != "" && fields[p[1]] == "" {
+			add("--%s is required when --%s is provided", p[1], p[0])
+		}
+	}
+
+	if email := fields["email"]; email != "" {
+		parts := strings.Split(email, "@")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			add("--email must be a valid email address")
+		}
+	}
+
+	if strings.EqualFold(method, "INTERAC") {
+		if !strings.EqualFold(country, "CA") {
+			add("--clearing-system INTERAC is only valid with --bank-country CA")
+		}
+		if fields["email"] == "" && fields["phone"] == "" {
+			add("--email or --phone is required for Interac e-Transfer")
+		}
+	}
+
+	for _, r := range formatRules {
+		v := fields[r.flag]
+		if v == "" {
+			continue
+		}
+		if r.upper {
+			v = strings.ToUpper(v)
+		}
+		if !r.re.MatchString(v) {
+			errs = append(errs, errors.New(r.message))
+		}
+	}
+
+	// Check digits, once the length rules above have passed.
+	if rn := fields["routing-number"]; reDigits9.MatchString(rn) && !ValidABA(rn) {
+		add("--routing-number %q is not a valid ABA routing number (checksum does not match)", rn)
+	}
+	if clabe := fields["clabe"]; reDigits18.MatchString(clabe) && !ValidCLABE(clabe) {
+		add("--clabe %q is not a valid CLABE (control digit does not match)", clabe)
+	}
+	if cpf := fields["cpf"]; reDigits11.MatchString(cpf) && !ValidCPF(cpf) {
+		add("--cpf %q is not a valid CPF (check digits do not match)", cpf)
+	}
+	if cnpj := fields["cnpj"]; reDigits14.MatchString(cnpj) && !ValidCNPJ(cnpj) {
+		add("--cnpj %q is not a valid CNPJ (check digits do not match)", cnpj)
+	}
+	if iban := fields["iban"]; iban != "" && !ValidIBAN(iban) {
+		add("--iban %q is not a valid IBAN (check digits do not match)", iban)
+	}
+
+	if uen := fields["uen"]; uen != "" && (len(uen) < 8 || len(uen) > 13) {
+		add("--uen must be 8-13 characters")
+	}
+	if vpa := fields["paynow-vpa"]; len(vpa) > 21 {
+		add("--paynow-vpa must be 21 characters or fewer")
+	}
+
+	return errs
+}
+
+// ValidIBAN reports whether s is a well-formed IBAN whose ISO 13616 mod-97
+// check digits match. Spaces are ignored and letters may be lower case.
+func ValidIBAN(s string) bool {
+	s = strings.ToUpper(strings.ReplaceAll(s, " ", ""))
+	if !reIBAN.MatchString(s) {
+		return false
+	}
+	// Move the country code and check digits to the end, expand letters to
+	// 10..35, and reduce mod 97 one digit at a time to avoid big numbers.
+	rem := 0
+	for _, c := range s[4:] + s[:4] {
+		if c >= 'A' && c <= 'Z' {
+			n := int(c-'A') + 10
+			rem = (rem*100 + n) % 97
+		} else {
+			rem = (rem*10 + int(c-'0')) % 97
+		}
+	}
+	return rem == 1
+}
+
+// ValidABA reports whether s is a 9-digit US ABA routing number whose
+// weighted sum (weights 3, 7, 1 repeating) is divisible by 10.
+func ValidABA(s string) bool {
+	if !reDigits9.MatchString(s) {
+		return false
+	}
+	weights := [3]int{3, 7, 1}
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(s[i]-'0') * weights[i%3]
+	}
+	return sum%10 == 0
+}
+
+// ValidCLABE reports whether s is an 18-digit Mexican CLABE whose last digit
+// is the control digit: weights 3, 7, 1 over the first 17 digits, each
+// product taken mod 10, and the sum's complement to the next multiple of 10.
+func ValidCLABE(s string) bool {
+	if !reDigits18.MatchString(s) {
+		return false
+	}
+	weights := [3]int{3, 7, 1}
+	sum := 0
+	for i := 0; i < 17; i++ {
+		sum += int(s[i]-'0') * weights[i%3] % 10
+	}
+	return (10-sum%10)%10 == int(s[17]-'0')
+}
+
+// ValidCPF reports whether s is an 11-digit Brazilian CPF with matching
+// check digits. Repeated-digit numbers such as 00000000000 pass the checksum
+// but are never issued, so they are rejected.
+func ValidCPF(s string) bool {
+	if !reDigits11.MatchString(s) || allSameDigit(s) {
+		return false
+	}
+	return brCheckDigit(s[:9], []int{10, 9, 8, 7, 6, 5, 4, 3, 2}) == int(s[9]-'0') &&
+		brCheckDigit(s[:10], []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2}) == int(s[10]-'0')
+}
+
+// ValidCNPJ reports whether s is a 14-digit Brazilian CNPJ with matching
+// check digits, rejecting repeated-digit numbers like ValidCPF.
+func ValidCNPJ(s string) bool {
+	if !reDigits14.MatchString(s) || allSameDigit(s) {
+		return false
+	}
+	return brCheckDigit(s[:12], []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) == int(s[12]-'0') &&
+		brCheckDigit(s[:13], []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) == int(s[13]-'0')
+}
+
+// brCheckDigit is the mod-11 check digit shared by CPF and CNPJ: the
+// weighted sum's remainder r gives 0 when r < 2, else 11 - r.
+func brCheckDigit(digits string, weights []int) int {
+	sum := 0
+	for i, w := range weights {
+		sum += int(digits[i]-'0') * w
+	}
+	if r := sum % 11; r >= 2 {
+		return 11 - r
+	}
+	return 0
+}
+
+func allSameDigit(s string) bool {
+	return strings.Count(s, s[:1]) == len(s)
+}
+
+// Routing is the resolved account_routing_type/value pairs for a beneficiary.
+type Routing struct {
+	Type1  string
+	Value1 string
+	Type2  string
+	Value2 string
+}
+
+// primaryRouting lists, in priority order, the flags that can become
+// account_routing_value1 and the flag that supplies value2, if any.
+var primaryRouting = []struct {
+	flag      string
+	secondary string
+}{
+	{flag: "routing-number"},
+	{flag: "sort-code"},
+	{flag: "bsb"},
+	{flag: "ifsc"},
+	{flag: "bank-code"},
+	{flag: "email"},
+	{flag: "phone"},
+	{flag: "institution-number", secondary: "transit-number"},
+	{flag: "zengin-bank-code", secondary: "zengin-branch-code"},
+	{flag: "cnaps"},
+	{flag: "korea-bank-code"},
+	{flag: "nric"},
+	{flag: "uen"},
+	{flag: "paynow-vpa"},
+	{flag: "sg-bank-code"},
+	{flag: "clearing-number"},
+	{flag: "hk-bank-code"},
+	{flag: "fps-id"},
+	{flag: "hkid"},
+	{flag: "payid-phone"},
+	{flag: "payid-email"},
+	{flag: "payid-abn"},
+}
+
+// Resolve picks the primary routing method from fields. The first set flag
+// in priority order wins; the zero Routing means none was set.
+func Resolve(fields map[string]string) Routing {
+	for _, p := range primaryRouting {
+		v := fields[p.flag]
+		if v == "" {
+			continue
+		}
+		if p.flag == "nric" {
+			v = strings.ToUpper(v)
+		}
+		r := Routing{Type1: RoutingType(p.flag), Value1: v}
+		if p.secondary != "" && fields[p.secondary] != "" {
+			r.Type2 = RoutingType(p.secondary)
+			r.Value2 = fields[p.secondary]
+		}
+		return r
+	}
+	return Routing{}
+}
+
+// RoutingType returns the API account_routing_type for a routing flag, or ""
+// when the flag carries no routing type.
+func RoutingType(flag string) string {
+	if mapping, ok := flagmap.GetMapping(flag); ok && mapping.RoutingType != "" {
+		return mapping.RoutingType
+	}
+	if flag == "bank-code" {
+		return "bank_code"
+	}
+	return ""
+}
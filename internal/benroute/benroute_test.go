@@ -0,0 +1,250 @@
+package benroute
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		country string
+		method  string
+		fields  map[string]string
+		wantErr []string // substrings, one per expected error; nil means valid
+	}{
+		{"US ACH", "US", "", map[string]string{"routing-number": "021000021"}, nil},
+		{"US ACH bad routing checksum", "US", "", map[string]string{"routing-number": "021000022"}, []string{`--routing-number "021000022" is not a valid ABA routing number`}},
+		{"US ACH short routing number", "US", "", map[string]string{"routing-number": "02100002"}, []string{"--routing-number must be exactly 9 digits"}},
+		{"GB sort code", "GB", "", map[string]string{"sort-code": "123456"}, nil},
+		{"GB sort code with dashes", "GB", "", map[string]string{"sort-code": "12-34-56"}, []string{"--sort-code must be exactly 6 digits"}},
+		{"AU BSB", "AU", "", map[string]string{"bsb": "062000"}, nil},
+		{"AU PayID", "AU", "", map[string]string{"payid-phone": "+61-412345678", "payid-abn": "12345678901"}, nil},
+		{"AU PayID bad phone", "AU", "", map[string]string{"payid-phone": "0412345678"}, []string{"--payid-phone must be in format +61-nnnnnnnnn"}},
+		{"CA EFT", "CA", "", map[string]string{"institution-number": "001", "transit-number": "12345"}, nil},
+		{"CA EFT missing transit", "CA", "", map[string]string{"institution-number": "001"}, []string{"--transit-number is required when --institution-number is provided"}},
+		{"CA Interac email", "CA", "INTERAC", map[string]string{"email": "jane@example.com"}, nil},
+		{"CA Interac without contact", "CA", "INTERAC", map[string]string{}, []string{"--email or --phone is required for Interac e-Transfer"}},
+		{"Interac outside Canada", "US", "INTERAC", map[string]string{"phone": "+1-4165551234"}, []string{"--clearing-system INTERAC is only valid with --bank-country CA"}},
+		{"IN IFSC lower case", "IN", "", map[string]string{"ifsc": "sbin0001234"}, nil},
+		{"IN IFSC invalid", "IN", "", map[string]string{"ifsc": "SBIN1001234"}, []string{"--ifsc must be 11 characters"}},
+		{"MX CLABE", "MX", "", map[string]string{"clabe": "032180000118359719"}, nil},
+		{"MX CLABE bad control digit", "MX", "", map[string]string{"clabe": "032180000118359718"}, []string{`--clabe "032180000118359718" is not a valid CLABE`}},
+		{"JP Zengin", "JP", "", map[string]string{"zengin-bank-code": "0001", "zengin-branch-code": "001"}, nil},
+		{"JP Zengin missing branch", "JP", "", map[string]string{"zengin-bank-code": "0001"}, []string{"--zengin-branch-code is required when --zengin-bank-code is provided"}},
+		{"CN CNAPS", "CN", "", map[string]string{"cnaps": "102100099996"}, nil},
+		{"KR bank code", "KR", "", map[string]string{"korea-bank-code": "04"}, []string{"--korea-bank-code must be exactly 3 digits"}},
+		{"BR CPF and CNPJ", "BR", "", map[string]string{"cpf": "12345678909", "cnpj": "12345678000195"}, nil},
+		{"BR CPF bad check digit", "BR", "", map[string]string{"cpf": "12345678901"}, []string{`--cpf "12345678901" is not a valid CPF`}},
+		{"BR CPF short reports length only", "BR", "", map[string]string{"cpf": "1234567890"}, []string{"--cpf must be exactly 11 digits"}},
+		{"BR CNPJ bad check digit", "BR", "", map[string]string{"cnpj": "12345678000196"}, []string{`--cnpj "12345678000196" is not a valid CNPJ`}},
+		{"SG PayNow NRIC", "SG", "", map[string]string{"nric": "s1234567a"}, nil},
+		{"SG PayNow bad UEN and VPA", "SG", "", map[string]string{"uen": "123", "paynow-vpa": strings.Repeat("x", 22)}, []string{"--uen must be 8-13 characters", "--paynow-vpa must be 21 characters or fewer"}},
+		{"SE clearing number", "SE", "", map[string]string{"clearing-number": "8327"}, nil},
+		{"HK FPS", "HK", "", map[string]string{"hk-bank-code": "004", "fps-id": "1234567"}, nil},
+		{"DE IBAN", "DE", "", map[string]string{"iban": "DE89370400440532013000"}, nil},
+		{"GB IBAN with spaces", "GB", "", map[string]string{"iban": "GB29 NWBK 6016 1331 9268 19"}, nil},
+		{"DE IBAN one digit off", "DE", "", map[string]string{"iban": "DE89370400440532013001"}, []string{`--iban "DE89370400440532013001" is not a valid IBAN`}},
+		{"HK FPS reports every issue", "HK", "", map[string]string{"hk-bank-code": "4", "fps-id": "123"}, []string{"--hk-bank-code must be exactly 3 digits", "--fps-id must be 7-9 digits"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Validate(tt.country, tt.method, tt.fields)
+			if len(errs) != len(tt.wantErr) {
+				t.Fatalf("Validate() = %v, want %d error(s) %v", errs, len(tt.wantErr), tt.wantErr)
+			}
+			for i, want := range tt.wantErr {
+				if !strings.Contains(errs[i].Error(), want) {
+					t.Errorf("error %d = %q, want it to contain %q", i, errs[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidIBAN(t *testing.T) {
+	tests := []struct {
+		iban string
+		want bool
+	}{
+		{"DE89370400440532013000", true},
+		{"GB29NWBK60161331926819", true},
+		{"FR7630006000011234567890189", true},
+		{"gb29 nwbk 6016 1331 9268 19", true},
+		{"DE89370400440532013001", false}, // last digit changed
+		{"DE98370400440532013000", false}, // check digits transposed
+		{"GB29NWBK6016133192681", false},  // digit dropped
+		{"DE89", false},
+		{"89DE370400440532013000", false},
+		{"DE89-3704-0044-0532-0130-00", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.iban, func(t *testing.T) {
+			if got := ValidIBAN(tt.iban); got != tt.want {
+				t.Errorf("ValidIBAN(%q) = %v, want %v", tt.iban, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidABA(t *testing.T) {
+	tests := []struct {
+		routing string
+		want    bool
+	}{
+		{"021000021", true}, // JPMorgan Chase
+		{"011000015", true}, // Federal Reserve Bank of Boston
+		{"122105155", true},
+		{"021000022", false}, // last digit off by one
+		{"012000021", false}, // transposed digits
+		{"123456789", false},
+		{"02100002", false},
+		{"02100002a", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.routing, func(t *testing.T) {
+			if got := ValidABA(tt.routing); got != tt.want {
+				t.Errorf("ValidABA(%q) = %v, want %v", tt.routing, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidCLABE(t *testing.T) {
+	tests := []struct {
+		clabe string
+		want  bool
+	}{
+		{"032180000118359719", true},
+		{"002010077777777771", true},
+		{"646180157000000004", true},
+		{"032180000118359718", false}, // control digit off by one
+		{"032180000118395719", false}, // transposed account digits
+		{"123456789012345678", false},
+		{"03218000011835971", false},
+		{"03218000011835971x", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.clabe, func(t *testing.T) {
+			if got := ValidCLABE(tt.clabe); got != tt.want {
+				t.Errorf("ValidCLABE(%q) = %v, want %v", tt.clabe, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidCPF(t *testing.T) {
+	tests := []struct {
+		cpf  string
+		want bool
+	}{
+		{"12345678909", true},
+		{"52998224725", true},
+		{"11144477735", true},
+		{"12345678901", false}, // wrong check digits
+		{"52998224726", false}, // second check digit off by one
+		{"52998224735", false}, // first check digit off by one
+		{"00000000000", false}, // repeated digits pass the checksum
+		{"99999999999", false},
+		{"1234567890", false},
+		{"529.982.247-25", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cpf, func(t *testing.T) {
+			if got := ValidCPF(tt.cpf); got != tt.want {
+				t.Errorf("ValidCPF(%q) = %v, want %v", tt.cpf, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidCNPJ(t *testing.T) {
+	tests := []struct {
+		cnpj string
+		want bool
+	}{
+		{"12345678000195", true},
+		{"11222333000181", true},
+		{"12345678901230", true},
+		{"12345678901234", false}, // wrong check digits
+		{"11222333000182", false}, // second check digit off by one
+		{"11222333000191", false}, // first check digit off by one
+		{"00000000000000", false}, // repeated digits pass the checksum
+		{"11111111111111", false},
+		{"1122233300018", false},
+		{"11.222.333/0001-81", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cnpj, func(t *testing.T) {
+			if got := ValidCNPJ(tt.cnpj); got != tt.want {
+				t.Errorf("ValidCNPJ(%q) = %v, want %v", tt.cnpj, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields map[string]string
+		want   Routing
+	}{
+		{"none", map[string]string{"swift-code": "CHASUS33"}, Routing{}},
+		{"US ABA", map[string]string{"routing-number": "021000021"}, Routing{Type1: "aba", Value1: "021000021"}},
+		{"GB sort code", map[string]string{"sort-code": "123456"}, Routing{Type1: "sort_code", Value1: "123456"}},
+		{"AU BSB", map[string]string{"bsb": "062000"}, Routing{Type1: "bsb", Value1: "062000"}},
+		{"AU PayID ABN", map[string]string{"payid-abn": "12345678901"}, Routing{Type1: "australian_business_number", Value1: "12345678901"}},
+		{"CA EFT", map[string]string{"institution-number": "001", "transit-number": "12345"}, Routing{Type1: "institution_number", Value1: "001", Type2: "transit_number", Value2: "12345"}},
+		{"CA Interac email", map[string]string{"email": "jane@example.com"}, Routing{Type1: "email_address", Value1: "jane@example.com"}},
+		{"IN IFSC", map[string]string{"ifsc": "SBIN0001234"}, Routing{Type1: "ifsc", Value1: "SBIN0001234"}},
+		{"JP Zengin", map[string]string{"zengin-bank-code": "0001", "zengin-branch-code": "001"}, Routing{Type1: "bank_code", Value1: "0001", Type2: "branch_code", Value2: "001"}},
+		{"CN CNAPS", map[string]string{"cnaps": "102100099996"}, Routing{Type1: "cnaps", Value1: "102100099996"}},
+		{"SG NRIC upper-cased", map[string]string{"nric": "s1234567a"}, Routing{Type1: "personal_id_number", Value1: "S1234567A"}},
+		{"HK FPS ID", map[string]string{"fps-id": "1234567"}, Routing{Type1: "fps_identifier", Value1: "1234567"}},
+		{"generic bank code", map[string]string{"bank-code": "ABC"}, Routing{Type1: "bank_code", Value1: "ABC"}},
+		{"priority: routing number before email", map[string]string{"email": "a@b.co", "routing-number": "021000021"}, Routing{Type1: "aba", Value1: "021000021"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Resolve(tt.fields); got != tt.want {
+				t.Errorf("Resolve() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupBank(t *testing.T) {
+	tests := []struct {
+		country string
+		name    string
+		want    []string
+	}{
+		{"US", "JPMorgan Chase Bank", []string{"CHASUS33"}},
+		{"us", "chase", []string{"CHASUS33"}},
+		{"GB", "natwest", []string{"NWBKGB2L"}},
+		{"HK", "HSBC", []string{"HSBCHKHH"}},          // alias resolves per country
+		{"CN", "bank of china", []string{"BKCHCNBJ"}}, // exact name wins over partial matches
+		{"US", "bank", []string{"CHASUS33", "BOFAUS3N", "CITIUS33", "WFBIUS6S"}},
+		{"DE", "Chase", nil},
+		{"US", "  ", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.country+"/"+tt.name, func(t *testing.T) {
+			var got []string
+			for _, b := range LookupBank(tt.country, tt.name) {
+				got = append(got, b.SWIFT)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("LookupBank(%q, %q) = %v, want %v", tt.country, tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -80,7 +80,7 @@ func newAccountsGetCmd() *cobra.Command {
 				rows = append(rows, outfmt.KV{Key: "swift_code", Value: a.SwiftCode})
 			}
 			rows = append(rows, outfmt.KV{Key: "created_at", Value: a.CreatedAt})
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}, getClient)
 }
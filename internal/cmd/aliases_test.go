@@ -112,7 +112,7 @@ func TestSubcommandAliases(t *testing.T) {
 		{"transfers create", []string{"transfers", "create"}, []string{"cr"}},
 		{"transfers batch-create", []string{"transfers", "batch-create"}, []string{"bc"}},
 		{"transfers cancel", []string{"transfers", "cancel"}, []string{"x"}},
-		{"transfers confirmation", []string{"transfers", "confirmation"}, []string{"conf"}},
+		{"transfers confirmation", []string{"transfers", "confirmation"}, []string{"conf", "letter"}},
 
 		// beneficiaries subcommands
 		{"beneficiaries list", []string{"beneficiaries", "list"}, []string{"ls", "l"}},
@@ -381,12 +381,12 @@ func TestMultiLetterFlagAliases(t *testing.T) {
 		{"global --template/--tmpl", nil, "template", "tmpl"},
 		{"global --items-only/--io", nil, "items-only", "io"},
 		{"global --results-only/--ro", nil, "results-only", "ro"},
+		{"global --dry-run/--dr", nil, "dry-run", "dr"},
 
 		// Per-command
 		{"transfers create --beneficiary-id/--bid", []string{"transfers", "create"}, "beneficiary-id", "bid"},
 		{"transfers create --transfer-currency/--tc", []string{"transfers", "create"}, "transfer-currency", "tc"},
 		{"transfers create --source-currency/--sc", []string{"transfers", "create"}, "source-currency", "sc"},
-		{"transfers create --dry-run/--dr", []string{"transfers", "create"}, "dry-run", "dr"},
 		{"cards list --cardholder-id/--chid", []string{"cards", "list"}, "cardholder-id", "chid"},
 		{"transactions list --card-id/--cid", []string{"transactions", "list"}, "card-id", "cid"},
 		{"webhooks create --events/--ev", []string{"webhooks", "create"}, "events", "ev"},
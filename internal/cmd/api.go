@@ -1,28 +1,40 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
 	"github.com/salmonumbrella/airwallex-cli/internal/outfmt"
 )
 
+// DefaultAPIMaxBodySize is the default cap on buffered api command responses (100MB).
+const DefaultAPIMaxBodySize int64 = 100 * 1024 * 1024
+
 func newAPICmd() *cobra.Command {
 	var (
 		method      string
 		data        string
 		dataFile    string
+		formFields  []string
 		headers     []string
 		queryParams []string
 		silent      bool
 		include     bool
+		stream      bool
+		paginate    bool
+		maxBodySize int64
 	)
 
 	cmd := &cobra.Command{
@@ -59,8 +71,22 @@ Examples:
   # POST with file
   airwallex api post /api/v1/transfers --data-file transfer.json
 
-  # Include response headers
-  airwallex api /api/v1/balances/current -i`,
+  # POST a form-encoded body (application/x-www-form-urlencoded)
+  airwallex api post /api/v1/legacy/endpoint --form name=Acme --form country=US
+
+  # Re-submit JSON produced by another command (body from stdin)
+  airwallex beneficiaries get ben_123 --output json | \
+    airwallex api post /api/v1/beneficiaries/ben_123/update --body-file -
+
+  # Include response status and headers (sensitive headers are redacted);
+  # with --output json they are wrapped as {"status", "headers", "body"}
+  airwallex api /api/v1/balances/current -i
+
+  # Follow every page of a list endpoint and print one combined items array
+  airwallex api /api/v1/issuing/transaction_disputes --paginate
+
+  # Stream a large response straight to a file without buffering
+  airwallex api /api/v1/financial_transactions --stream > transactions.json`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			resolvedMethod, endpoint, resolvedQueryParams, err := parseAPIInvocation(cmd, args, method, queryParams)
@@ -69,6 +95,14 @@ Examples:
 			}
 			method = resolvedMethod
 			queryParams = resolvedQueryParams
+			if paginate {
+				if !strings.EqualFold(method, http.MethodGet) {
+					return fmt.Errorf("--paginate only supports GET requests")
+				}
+				if stream {
+					return fmt.Errorf("--paginate cannot be combined with --stream")
+				}
+			}
 
 			client, err := getClient(cmd.Context())
 			if err != nil {
@@ -77,62 +111,110 @@ Examples:
 
 			// Build request body
 			var body io.Reader
-			if data != "" {
-				body = strings.NewReader(data)
-			} else if dataFile != "" {
-				if dataFile == "-" {
-					body = os.Stdin
+			contentType := ""
+			if len(formFields) > 0 {
+				if data != "" || dataFile != "" {
+					return fmt.Errorf("--form cannot be combined with --data or --data-file")
+				}
+				form, err := parseAPIFormFields(formFields)
+				if err != nil {
+					return err
+				}
+				body = strings.NewReader(form.Encode())
+				contentType = "application/x-www-form-urlencoded"
+			} else {
+				payload, err := readAPIRequestBody(cmd.Context(), data, dataFile)
+				if err != nil {
+					return err
+				}
+				if payload != nil {
+					// bytes.Reader lets net/http set GetBody so retries can replay the body.
+					body = bytes.NewReader(payload)
+				}
+			}
+
+			// Build query params (properly encoded)
+			params := url.Values{}
+			for _, qp := range queryParams {
+				parts := strings.SplitN(qp, "=", 2)
+				if len(parts) == 2 {
+					params.Add(parts[0], parts[1])
 				} else {
-					f, err := os.Open(dataFile)
-					if err != nil {
-						return fmt.Errorf("failed to open data file: %w", err)
-					}
-					defer func() { _ = f.Close() }()
-					body = f
+					// Handle key without value (e.g., "flag" becomes "flag=")
+					params.Add(parts[0], "")
 				}
 			}
 
-			// Build URL with query params (properly encoded)
-			reqURL := client.BaseURL() + endpoint
-			if len(queryParams) > 0 {
-				params := url.Values{}
-				for _, qp := range queryParams {
-					parts := strings.SplitN(qp, "=", 2)
+			send := func(params url.Values, body io.Reader) (*http.Response, error) {
+				reqURL := client.BaseURL() + endpoint
+				if len(params) > 0 {
+					reqURL += "?" + params.Encode()
+				}
+
+				req, err := http.NewRequestWithContext(cmd.Context(), method, reqURL, body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create request: %w", err)
+				}
+				if contentType != "" {
+					req.Header.Set("Content-Type", contentType)
+				}
+
+				// Add custom headers
+				for _, h := range headers {
+					parts := strings.SplitN(h, ":", 2)
 					if len(parts) == 2 {
-						params.Add(parts[0], parts[1])
-					} else {
-						// Handle key without value (e.g., "flag" becomes "flag=")
-						params.Add(parts[0], "")
+						req.Header.Set(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
 					}
 				}
-				reqURL += "?" + params.Encode()
+
+				return client.Do(cmd.Context(), req)
 			}
 
-			// Create request
-			req, err := http.NewRequestWithContext(cmd.Context(), method, reqURL, body)
+			// Execute request
+			resp, err := send(params, body)
 			if err != nil {
-				return fmt.Errorf("failed to create request: %w", err)
+				return err
 			}
+			defer func() { _ = resp.Body.Close() }()
 
-			// Add custom headers
-			for _, h := range headers {
-				parts := strings.SplitN(h, ":", 2)
-				if len(parts) == 2 {
-					req.Header.Set(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+			if stream && !silent {
+				if include {
+					writeResponseHeaders(cmd.ErrOrStderr(), resp)
 				}
+				if _, err := io.Copy(cmd.OutOrStdout(), resp.Body); err != nil {
+					return fmt.Errorf("failed to read response: %w", err)
+				}
+				if resp.StatusCode >= 400 {
+					return fmt.Errorf("request failed with status %d", resp.StatusCode)
+				}
+				return nil
 			}
 
-			// Execute request
-			resp, err := client.Do(cmd.Context(), req)
+			// Read response
+			respBody, err := readAPIResponseBody(resp.Body, maxBodySize)
 			if err != nil {
 				return err
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			// Read response
-			respBody, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return fmt.Errorf("failed to read response: %w", err)
+			if paginate && resp.StatusCode < 400 {
+				respBody, err = paginateAPIResponse(respBody, params, func(params url.Values) ([]byte, error) {
+					resp, err := send(params, nil)
+					if err != nil {
+						return nil, err
+					}
+					defer func() { _ = resp.Body.Close() }()
+					pageBody, err := readAPIResponseBody(resp.Body, maxBodySize)
+					if err != nil {
+						return nil, err
+					}
+					if resp.StatusCode >= 400 {
+						return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(pageBody)))
+					}
+					return pageBody, nil
+				})
+				if err != nil {
+					return err
+				}
 			}
 
 			if silent {
@@ -143,19 +225,43 @@ Examples:
 				return nil
 			}
 
-			// Print headers if requested
-			if include {
-				errOut := cmd.ErrOrStderr()
-				_, _ = fmt.Fprintf(errOut, "HTTP/%d.%d %s\n", resp.ProtoMajor, resp.ProtoMinor, resp.Status)
-				for k, v := range resp.Header {
-					_, _ = fmt.Fprintf(errOut, "%s: %s\n", k, strings.Join(v, ", "))
+			// Output response body
+			out := cmd.OutOrStdout()
+
+			// With --include in JSON mode, wrap status, headers, and body in
+			// one document; otherwise headers go to stderr ahead of the body.
+			if include && outfmt.IsJSON(cmd.Context()) {
+				var parsed interface{} = string(respBody)
+				var v interface{}
+				if err := json.Unmarshal(respBody, &v); err == nil {
+					parsed = v
+				}
+				if err := writeJSONOutputTo(cmd.Context(), out, map[string]interface{}{
+					"status":  resp.StatusCode,
+					"headers": responseHeaderMap(resp),
+					"body":    parsed,
+				}); err != nil {
+					return err
+				}
+				if resp.StatusCode >= 400 {
+					return fmt.Errorf("request failed with status %d", resp.StatusCode)
 				}
-				_, _ = fmt.Fprintln(errOut)
+				return nil
+			}
+			if include {
+				writeResponseHeaders(cmd.ErrOrStderr(), resp)
 			}
 
-			// Output response body
-			out := cmd.OutOrStdout()
-			if outfmt.IsJSON(cmd.Context()) || isJSONResponse(resp) {
+			if outfmt.GetTemplate(cmd.Context()) != "" && isJSONResponse(resp) {
+				// Render the decoded body (a raw map) through --template.
+				var v interface{}
+				if err := json.Unmarshal(respBody, &v); err != nil {
+					return fmt.Errorf("failed to decode response for --template: %w", err)
+				}
+				if err := outfmt.FromContext(cmd.Context(), outfmt.WithWriter(out)).Output(v); err != nil {
+					return err
+				}
+			} else if outfmt.IsJSON(cmd.Context()) || isJSONResponse(resp) {
 				// Emit JSON according to context format/query (json or jsonl).
 				var prettyJSON interface{}
 				if err := json.Unmarshal(respBody, &prettyJSON); err == nil {
@@ -182,14 +288,224 @@ Examples:
 	cmd.Flags().StringVarP(&method, "method", "X", "GET", "HTTP method")
 	cmd.Flags().StringVarP(&data, "data", "d", "", "Request body (JSON)")
 	cmd.Flags().StringVar(&dataFile, "data-file", "", "Read request body from file (- for stdin)")
+	cmd.Flags().StringArrayVar(&formFields, "form", nil, "Form field for an application/x-www-form-urlencoded body (key=value, repeatable)")
 	cmd.Flags().StringArrayVarP(&headers, "header", "H", nil, "Custom headers (key: value)")
 	cmd.Flags().StringArrayVarP(&queryParams, "query", "q", nil, "Query parameters (key=value)")
 	cmd.Flags().BoolVarP(&silent, "silent", "s", false, "Don't print response body")
-	cmd.Flags().BoolVarP(&include, "include", "i", false, "Include response headers in output")
+	cmd.Flags().BoolVarP(&include, "include", "i", false, "Include response status and headers (JSON mode wraps them with the body)")
+	cmd.Flags().BoolVar(&paginate, "paginate", false, "Follow has_more pages (page_num or page cursor) and combine all items into one array (GET only)")
+	cmd.Flags().BoolVar(&stream, "stream", false, "Write the response body as it arrives (no buffering, formatting, or size limit)")
+	cmd.Flags().Int64Var(&maxBodySize, "max-body-size", DefaultAPIMaxBodySize, "Maximum response size in bytes before aborting (0 = no limit)")
+	flagAlias(cmd.Flags(), "data-file", "body-file")
 
 	return cmd
 }
 
+// paginateAPIResponse follows pagination for a list response ({"items": [...],
+// "has_more": true}) and returns a single {"items", "has_more": false} body
+// holding every page's items. The style is detected from the response: a
+// non-empty page_after cursor is passed back as "page" (disputes-style);
+// otherwise page_num is incremented, with page_size defaulting to the size of
+// the first page since the API requires the two together. Bodies that are not
+// list responses are returned unchanged.
+func paginateAPIResponse(first []byte, params url.Values, fetch func(url.Values) ([]byte, error)) ([]byte, error) {
+	page, ok := decodeAPIListPage(first)
+	if !ok {
+		return first, nil
+	}
+
+	params = cloneValues(params)
+	items := page.items
+	pageNum := 1
+	if v := params.Get("page_num"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid page_num %q: %w", v, err)
+		}
+		pageNum = n
+	}
+	if params.Get("page_size") == "" && page.cursor == "" && len(page.items) > 0 {
+		params.Set("page_size", strconv.Itoa(len(page.items)))
+	}
+
+	for page.hasMore {
+		if page.cursor != "" {
+			if page.cursor == params.Get("page") {
+				return nil, fmt.Errorf("pagination cursor %q repeated; stopping", page.cursor)
+			}
+			params.Set("page", page.cursor)
+		} else {
+			if len(page.items) == 0 {
+				break
+			}
+			pageNum++
+			params.Set("page_num", strconv.Itoa(pageNum))
+		}
+
+		body, err := fetch(params)
+		if err != nil {
+			return nil, fmt.Errorf("partial results: stopped after %d items: %w", len(items), err)
+		}
+		next, ok := decodeAPIListPage(body)
+		if !ok {
+			return nil, fmt.Errorf("page %d is not a list response", pageNum)
+		}
+		items = append(items, next.items...)
+		page = next
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"items":    items,
+		"has_more": false,
+	})
+}
+
+// apiListPage is the pagination-relevant part of a list response.
+type apiListPage struct {
+	items   []json.RawMessage
+	hasMore bool
+	cursor  string // page_after, for cursor-paginated endpoints
+}
+
+func decodeAPIListPage(body []byte) (apiListPage, bool) {
+	var raw struct {
+		Items     *[]json.RawMessage `json:"items"`
+		HasMore   bool               `json:"has_more"`
+		PageAfter string             `json:"page_after"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil || raw.Items == nil {
+		return apiListPage{}, false
+	}
+	return apiListPage{items: *raw.Items, hasMore: raw.HasMore, cursor: raw.PageAfter}, true
+}
+
+func cloneValues(v url.Values) url.Values {
+	out := make(url.Values, len(v))
+	for k, vals := range v {
+		out[k] = append([]string(nil), vals...)
+	}
+	return out
+}
+
+// parseAPIFormFields turns repeated --form key=value flags into form values,
+// preserving repeated keys.
+func parseAPIFormFields(fields []string) (url.Values, error) {
+	form := url.Values{}
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --form value %q: expected key=value", field)
+		}
+		form.Add(key, value)
+	}
+	return form, nil
+}
+
+// maxAPIRequestBodySize caps the request body read from --data or --data-file (10MB).
+const maxAPIRequestBodySize = 10 * 1024 * 1024
+
+// readAPIRequestBody loads the raw request body from --data or --data-file
+// ("-" reads stdin); --data wins when both are given. Valid JSON is compacted so output piped from another
+// command (pretty-printed, trailing newline) is sent unchanged in content and
+// key order; anything else is sent as-is.
+func readAPIRequestBody(ctx context.Context, data, dataFile string) ([]byte, error) {
+	var reader io.Reader
+	switch {
+	case data != "":
+		reader = strings.NewReader(data)
+	case dataFile == "-":
+		reader = iocontext.GetIO(ctx).In
+	case dataFile != "":
+		//nolint:gosec // G304: filename comes from user input, intentional
+		f, err := os.Open(dataFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open data file: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		reader = f
+	default:
+		return nil, nil
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(reader, maxAPIRequestBodySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	if len(payload) > maxAPIRequestBodySize {
+		return nil, fmt.Errorf("request body too large: exceeds maximum size of %d bytes", maxAPIRequestBodySize)
+	}
+
+	payload = bytes.TrimPrefix(payload, []byte("\xef\xbb\xbf"))
+	if json.Valid(payload) {
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, payload); err == nil {
+			return compacted.Bytes(), nil
+		}
+	}
+	return payload, nil
+}
+
+// readAPIResponseBody buffers the response, failing once it exceeds maxSize
+// bytes. A maxSize of 0 or less disables the limit.
+func readAPIResponseBody(r io.Reader, maxSize int64) ([]byte, error) {
+	if maxSize <= 0 {
+		body, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+		return body, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if int64(len(body)) > maxSize {
+		return nil, fmt.Errorf("response body exceeds --max-body-size of %d bytes (raise the limit or use --stream)", maxSize)
+	}
+	return body, nil
+}
+
+// sensitiveResponseHeaders are redacted from --include output.
+var sensitiveResponseHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"X-Api-Key":           true,
+	"X-Client-Id":         true,
+}
+
+const redactedHeaderValue = "[REDACTED]"
+
+// responseHeaderMap flattens response headers (repeated values joined with
+// ", ") and redacts sensitive ones.
+func responseHeaderMap(resp *http.Response) map[string]string {
+	headers := make(map[string]string, len(resp.Header))
+	for k, v := range resp.Header {
+		if sensitiveResponseHeaders[http.CanonicalHeaderKey(k)] {
+			headers[k] = redactedHeaderValue
+			continue
+		}
+		headers[k] = strings.Join(v, ", ")
+	}
+	return headers
+}
+
+func writeResponseHeaders(w io.Writer, resp *http.Response) {
+	_, _ = fmt.Fprintf(w, "HTTP/%d.%d %s\n", resp.ProtoMajor, resp.ProtoMinor, resp.Status)
+	headers := responseHeaderMap(resp)
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		_, _ = fmt.Fprintf(w, "%s: %s\n", k, headers[k])
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
 func isJSONResponse(resp *http.Response) bool {
 	ct := resp.Header.Get("Content-Type")
 	return strings.Contains(ct, "application/json")
@@ -226,8 +542,7 @@ func parseAPIInvocation(cmd *cobra.Command, args []string, method string, queryP
 
 	resolvedQueryParams, remapped := remapFinancialTransactionsQueryParams(endpoint, resolvedQueryParams)
 	if remapped {
-		errOut := cmd.ErrOrStderr()
-		_, _ = fmt.Fprintln(errOut, "warning: remapped from_posted_at/to_posted_at to from_created_at/to_created_at for /api/v1/financial_transactions")
+		warnf(cmd.Context(), cmd.ErrOrStderr(), "remapped from_posted_at/to_posted_at to from_created_at/to_created_at for /api/v1/financial_transactions")
 	}
 
 	return resolvedMethod, endpoint, resolvedQueryParams, nil
@@ -1,9 +1,17 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
 	"net/url"
+	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
 )
 
 func TestAPICommand_Flags(t *testing.T) {
@@ -26,6 +34,9 @@ func TestAPICommand_Flags(t *testing.T) {
 		{"query", "q"},
 		{"silent", "s"},
 		{"include", "i"},
+		{"stream", ""},
+		{"max-body-size", ""},
+		{"form", ""},
 	}
 
 	for _, ef := range expectedFlags {
@@ -266,3 +277,385 @@ func TestRemapFinancialTransactionsQueryParams(t *testing.T) {
 		t.Fatalf("missing to_created_at remap in %v", q)
 	}
 }
+
+func TestAPICommand_BodyFromStdinRoundTripsGetOutput(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	const beneficiaryJSON = `{"beneficiary_id":"ben_pipe","nickname":"Piped","beneficiary":{"entity_type":"COMPANY","company_name":"Acme","bank_details":{"account_name":"Acme","bank_country_code":"US","account_currency":"USD"}},"transfer_methods":["LOCAL"]}`
+	testMockServer.Handle("GET", "/api/v1/beneficiaries/ben_pipe", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(beneficiaryJSON))
+	})
+
+	var received []byte
+	testMockServer.Handle("POST", "/api/v1/beneficiaries/ben_pipe/update", func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(received)
+	})
+
+	// Step 1: fetch as JSON.
+	var getOut bytes.Buffer
+	getCtx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &getOut, ErrOut: io.Discard, In: strings.NewReader("")})
+	root := NewRootCmd()
+	root.SetArgs([]string{"beneficiaries", "get", "ben_pipe", "--output", "json"})
+	if err := root.ExecuteContext(getCtx); err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+
+	// Step 2: pipe the JSON into api POST via stdin.
+	var postOut bytes.Buffer
+	postCtx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &postOut, ErrOut: io.Discard, In: bytes.NewReader(getOut.Bytes())})
+	root = NewRootCmd()
+	root.SetArgs([]string{"api", "post", "/api/v1/beneficiaries/ben_pipe/update", "--body-file", "-"})
+	if err := root.ExecuteContext(postCtx); err != nil {
+		t.Fatalf("api post failed: %v", err)
+	}
+
+	var want, got interface{}
+	if err := json.Unmarshal(getOut.Bytes(), &want); err != nil {
+		t.Fatalf("get output is not JSON: %v\n%s", err, getOut.String())
+	}
+	if err := json.Unmarshal(received, &got); err != nil {
+		t.Fatalf("posted body is not JSON: %v\n%s", err, string(received))
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("posted body differs from get output\nwant: %v\ngot:  %v", want, got)
+	}
+	if bytes.ContainsAny(received, "\n") {
+		t.Errorf("expected compact body, got %q", string(received))
+	}
+}
+
+func TestReadAPIRequestBody(t *testing.T) {
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{In: strings.NewReader("{\n  \"a\": 1,\n  \"b\": [1, 2]\n}\n")})
+
+	body, err := readAPIRequestBody(ctx, "", "-")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(body) != `{"a":1,"b":[1,2]}` {
+		t.Errorf("body = %q", string(body))
+	}
+
+	body, err = readAPIRequestBody(ctx, "not json", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(body) != "not json" {
+		t.Errorf("non-JSON body should pass through, got %q", string(body))
+	}
+
+	body, err = readAPIRequestBody(ctx, `{"c": 3}`, "testdata/missing.json")
+	if err != nil {
+		t.Fatalf("--data should take precedence over --data-file: %v", err)
+	}
+	if string(body) != `{"c":3}` {
+		t.Errorf("body = %q, want the --data value", string(body))
+	}
+
+	body, err = readAPIRequestBody(ctx, "", "")
+	if err != nil || body != nil {
+		t.Errorf("expected nil body without input, got %q, %v", body, err)
+	}
+}
+
+func TestAPICommand_MaxBodySize(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	payload := `{"data":"` + strings.Repeat("x", 256) + `"}`
+	testMockServer.Handle("GET", "/api/v1/large_payload", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(payload))
+	})
+
+	tests := []struct {
+		name        string
+		args        []string
+		errContains string
+	}{
+		{
+			name:        "over limit errors",
+			args:        []string{"--max-body-size", "100"},
+			errContains: "exceeds --max-body-size of 100 bytes",
+		},
+		{
+			name: "under limit succeeds",
+			args: []string{"--max-body-size", "1024"},
+		},
+		{
+			name: "stream bypasses limit",
+			args: []string{"--max-body-size", "100", "--stream"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			root := NewRootCmd()
+			root.SetOut(&out)
+			root.SetErr(io.Discard)
+			root.SetArgs(append([]string{"api", "/api/v1/large_payload"}, tt.args...))
+
+			err := root.Execute()
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(out.String(), strings.Repeat("x", 256)) {
+				t.Errorf("expected full payload in output, got %q", out.String())
+			}
+		})
+	}
+}
+
+func TestAPICommand_FormBody(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	var gotContentType string
+	var gotForm url.Values
+	testMockServer.Handle("POST", "/api/v1/legacy_form", func(w http.ResponseWriter, r *http.Request) {
+		gotContentType = r.Header.Get("Content-Type")
+		raw, _ := io.ReadAll(r.Body)
+		gotForm, _ = url.ParseQuery(string(raw))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	})
+
+	root := NewRootCmd()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"api", "post", "/api/v1/legacy_form", "--form", "name=Acme & Co", "--form", "tag=a", "--form", "tag=b"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotContentType != "application/x-www-form-urlencoded" {
+		t.Errorf("Content-Type = %q, want application/x-www-form-urlencoded", gotContentType)
+	}
+	if got := gotForm.Get("name"); got != "Acme & Co" {
+		t.Errorf("name = %q, want %q", got, "Acme & Co")
+	}
+	if got := gotForm["tag"]; len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("tag = %v, want [a b]", got)
+	}
+
+	root = NewRootCmd()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"api", "post", "/api/v1/legacy_form", "--form", "a=1", "-d", `{"a":1}`})
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "--form cannot be combined") {
+		t.Errorf("expected mutual exclusion error, got %v", err)
+	}
+}
+
+func TestAPICommand_IncludeStatusAndHeaders(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.Handle("GET", "/api/v1/include_test", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req_123")
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	})
+
+	t.Run("json wraps status, headers, and body", func(t *testing.T) {
+		var out bytes.Buffer
+		root := NewRootCmd()
+		root.SetOut(&out)
+		root.SetErr(io.Discard)
+		root.SetArgs([]string{"api", "/api/v1/include_test", "--include", "--output", "json"})
+		if err := root.Execute(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var got struct {
+			Status  int               `json:"status"`
+			Headers map[string]string `json:"headers"`
+			Body    map[string]bool   `json:"body"`
+		}
+		if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+			t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+		}
+		if got.Status != http.StatusOK {
+			t.Errorf("status = %d, want 200", got.Status)
+		}
+		if got.Headers["X-Request-Id"] != "req_123" {
+			t.Errorf("X-Request-Id = %q, want req_123", got.Headers["X-Request-Id"])
+		}
+		if got.Headers["Set-Cookie"] != redactedHeaderValue {
+			t.Errorf("Set-Cookie = %q, want it redacted", got.Headers["Set-Cookie"])
+		}
+		if !got.Body["ok"] {
+			t.Errorf("body = %v, want {ok:true}", got.Body)
+		}
+	})
+
+	t.Run("text prints headers before the body", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+		root := NewRootCmd()
+		root.SetOut(&out)
+		root.SetErr(&errOut)
+		root.SetArgs([]string{"api", "/api/v1/include_test", "-i", "--output", "text"})
+		if err := root.Execute(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		headers := errOut.String()
+		if !strings.HasPrefix(headers, "HTTP/1.1 200 OK\n") || !strings.Contains(headers, "X-Request-Id: req_123\n") {
+			t.Errorf("unexpected headers:\n%s", headers)
+		}
+		if strings.Contains(headers, "secret") {
+			t.Errorf("Set-Cookie not redacted:\n%s", headers)
+		}
+		if !strings.Contains(out.String(), `"ok"`) {
+			t.Errorf("body missing from stdout: %q", out.String())
+		}
+	})
+}
+
+func TestAPICommand_Paginate(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	run := func(t *testing.T, endpoint string) []string {
+		t.Helper()
+		var out bytes.Buffer
+		root := NewRootCmd()
+		root.SetOut(&out)
+		root.SetErr(io.Discard)
+		root.SetArgs([]string{"api", endpoint, "--paginate", "--output", "json"})
+		if err := root.Execute(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var got struct {
+			Items []struct {
+				ID string `json:"id"`
+			} `json:"items"`
+			HasMore bool `json:"has_more"`
+		}
+		if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+			t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+		}
+		if got.HasMore {
+			t.Error("has_more should be false after paginating")
+		}
+		ids := make([]string, 0, len(got.Items))
+		for _, item := range got.Items {
+			ids = append(ids, item.ID)
+		}
+		return ids
+	}
+
+	t.Run("page_num", func(t *testing.T) {
+		var queries []string
+		testMockServer.Handle("GET", "/api/v1/generic_things", func(w http.ResponseWriter, r *http.Request) {
+			queries = append(queries, r.URL.RawQuery)
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Query().Get("page_num") == "2" {
+				_, _ = w.Write([]byte(`{"items":[{"id":"c"}],"has_more":false}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"items":[{"id":"a"},{"id":"b"}],"has_more":true}`))
+		})
+		defer testMockServer.HandleError("GET", "/api/v1/generic_things", http.StatusNotFound, "endpoint not found")
+
+		if ids := run(t, "/api/v1/generic_things"); !reflect.DeepEqual(ids, []string{"a", "b", "c"}) {
+			t.Errorf("items = %v, want [a b c]", ids)
+		}
+		want := []string{"", "page_num=2&page_size=2"}
+		if !reflect.DeepEqual(queries, want) {
+			t.Errorf("queries = %q, want %q", queries, want)
+		}
+	})
+
+	t.Run("page cursor", func(t *testing.T) {
+		var pages []string
+		testMockServer.Handle("GET", "/api/v1/generic_disputes", func(w http.ResponseWriter, r *http.Request) {
+			pages = append(pages, r.URL.Query().Get("page"))
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Query().Get("page") == "cur_2" {
+				_, _ = w.Write([]byte(`{"items":[{"id":"d2"}],"has_more":false}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"items":[{"id":"d1"}],"has_more":true,"page_after":"cur_2"}`))
+		})
+		defer testMockServer.HandleError("GET", "/api/v1/generic_disputes", http.StatusNotFound, "endpoint not found")
+
+		if ids := run(t, "/api/v1/generic_disputes"); !reflect.DeepEqual(ids, []string{"d1", "d2"}) {
+			t.Errorf("items = %v, want [d1 d2]", ids)
+		}
+		if !reflect.DeepEqual(pages, []string{"", "cur_2"}) {
+			t.Errorf("page params = %q, want [\"\" cur_2]", pages)
+		}
+	})
+
+	t.Run("rejects non-GET", func(t *testing.T) {
+		root := NewRootCmd()
+		root.SetOut(io.Discard)
+		root.SetErr(io.Discard)
+		root.SetArgs([]string{"api", "post", "/api/v1/generic_things", "--paginate"})
+		if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "only supports GET") {
+			t.Errorf("expected GET-only error, got %v", err)
+		}
+	})
+}
+
+func TestAPICommand_Template(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.HandleJSON("GET", "/api/v1/balances/current", http.StatusOK, map[string]interface{}{
+		"items": []map[string]interface{}{
+			{"currency": "USD", "available_amount": 100},
+			{"currency": "EUR", "available_amount": 25.5},
+		},
+	})
+
+	var out bytes.Buffer
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+	root := NewRootCmd()
+	root.SetOut(&out)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"api", "/api/v1/balances/current", "--template", "{{range .items}}{{.currency}} {{.available_amount}}\n{{end}}"})
+	if err := root.ExecuteContext(ctx); err != nil {
+		t.Fatalf("api failed: %v", err)
+	}
+	if got := out.String(); got != "USD 100\nEUR 25.5\n" {
+		t.Errorf("output = %q", got)
+	}
+}
+
+func TestAPICommand_InvalidTemplateFailsBeforeRequest(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	var called bool
+	testMockServer.Handle("GET", "/api/v1/balances/current", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: io.Discard, ErrOut: io.Discard, In: strings.NewReader("")})
+	root := NewRootCmd()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"api", "/api/v1/balances/current", "--template", "{{.items"})
+	err := root.ExecuteContext(ctx)
+	if err == nil || !strings.Contains(err.Error(), "invalid template") {
+		t.Fatalf("expected invalid template error, got %v", err)
+	}
+	if called {
+		t.Error("request was made despite an invalid template")
+	}
+}
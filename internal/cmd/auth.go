@@ -14,6 +14,7 @@ import (
 	"golang.org/x/term"
 
 	"github.com/salmonumbrella/airwallex-cli/internal/auth"
+	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
 	"github.com/salmonumbrella/airwallex-cli/internal/outfmt"
 	"github.com/salmonumbrella/airwallex-cli/internal/secrets"
 	"github.com/salmonumbrella/airwallex-cli/internal/ui"
@@ -31,6 +32,7 @@ func newAuthCmd() *cobra.Command {
 	cmd.AddCommand(newAuthRemoveCmd())
 	cmd.AddCommand(newAuthRenameCmd())
 	cmd.AddCommand(newAuthTestCmd())
+	cmd.AddCommand(newAuthValidateCmd())
 	return cmd
 }
 
@@ -228,9 +230,15 @@ func newAuthRemoveCmd() *cobra.Command {
 				return fmt.Errorf("failed to open keyring: %w", err)
 			}
 
+			creds, credsErr := store.Get(name)
 			if err := store.Delete(name); err != nil {
 				return fmt.Errorf("failed to remove account: %w", err)
 			}
+			if credsErr == nil {
+				if err := clearTokenStore(name, creds); err != nil {
+					warnf(cmd.Context(), iocontext.GetIO(cmd.Context()).ErrOut, "could not remove cached token: %v", err)
+				}
+			}
 
 			u.Success(fmt.Sprintf("Removed account: %s", name))
 			return nil
@@ -280,6 +288,7 @@ Examples:
 				ClientID:  creds.ClientID,
 				APIKey:    creds.APIKey,
 				AccountID: creds.AccountID,
+				Env:       creds.Env,
 				CreatedAt: creds.CreatedAt,
 			})
 			if err != nil {
@@ -292,6 +301,10 @@ Examples:
 				_ = store.Delete(newName)
 				return fmt.Errorf("failed to remove old account: %w", err)
 			}
+			// The cached token is keyed by account name; the new name logs in afresh.
+			if err := clearTokenStore(oldName, creds); err != nil {
+				warnf(cmd.Context(), iocontext.GetIO(cmd.Context()).ErrOut, "could not remove cached token: %v", err)
+			}
 
 			u.Success(fmt.Sprintf("Renamed account: %s → %s", oldName, newName))
 			return nil
@@ -324,7 +337,7 @@ func newAuthTestCmd() *cobra.Command {
 			u.Info(fmt.Sprintf("Testing account: %s (client_id: %s)", account, creds.ClientID))
 
 			// Actually test the credentials by fetching a token
-			client, err := newClientForCreds(creds)
+			client, err := newConnectedClient(cmd.Context(), creds)
 			if err != nil {
 				u.Error(fmt.Sprintf("Failed to create client: %v", err))
 				return err
@@ -340,3 +353,88 @@ func newAuthTestCmd() *cobra.Command {
 		},
 	}
 }
+
+// authValidateResult mirrors the setup page's /validate response.
+type authValidateResult struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+func newAuthValidateCmd() *cobra.Command {
+	var clientID string
+	var apiKey string
+	var accountID string
+	var env string
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check credentials without saving them",
+		Long: `Check a Client ID and API key by logging in, the same check the browser
+setup runs before saving. Nothing is stored and no browser is opened.
+
+With --output json the result is {"success": true} or
+{"success": false, "error": "..."}; the exit code is non-zero on failure.
+
+Examples:
+  airwallex auth validate --client-id xxx --api-key "$AWX_API_KEY"
+  airwallex auth validate --client-id xxx --api-key yyy --account-id acct_xxx --env demo --output json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			err := validateCredentials(ctx, secrets.Credentials{
+				ClientID:  strings.TrimSpace(clientID),
+				APIKey:    strings.TrimSpace(apiKey),
+				AccountID: strings.TrimSpace(accountID),
+				Env:       strings.ToLower(strings.TrimSpace(env)),
+			})
+
+			if outfmt.IsJSON(ctx) {
+				result := authValidateResult{Success: err == nil}
+				if err != nil {
+					result.Error = err.Error()
+				}
+				if werr := writeJSONOutput(cmd, result); werr != nil {
+					return werr
+				}
+				return err
+			}
+
+			if err != nil {
+				return err
+			}
+			ui.FromContext(ctx).Success("Credentials valid")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&clientID, "client-id", "", "Airwallex Client ID (required)")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "Airwallex API Key (required)")
+	cmd.Flags().StringVar(&accountID, "account-id", "", "Airwallex Account ID for x-login-as (required for multi-account API keys)")
+	cmd.Flags().StringVar(&env, "env", envProduction, "API environment: production or demo")
+	mustMarkRequired(cmd, "client-id")
+	mustMarkRequired(cmd, "api-key")
+	return cmd
+}
+
+// validateCredentials applies the setup flow's format checks to creds and
+// then logs in with them.
+func validateCredentials(ctx context.Context, creds secrets.Credentials) error {
+	if err := auth.ValidateClientID(creds.ClientID); err != nil {
+		return fmt.Errorf("invalid client ID: %w", err)
+	}
+	if err := auth.ValidateAPIKey(creds.APIKey); err != nil {
+		return fmt.Errorf("invalid API key: %w", err)
+	}
+	switch creds.Env {
+	case envProduction:
+		creds.Env = ""
+	case envDemo:
+	default:
+		return fmt.Errorf("invalid --env %q: must be %s or %s", creds.Env, envProduction, envDemo)
+	}
+	client, err := newConnectedClient(ctx, creds)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	return auth.CheckCredentials(ctx, client)
+}
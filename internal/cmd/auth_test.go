@@ -1,11 +1,23 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/api"
+	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
+	"github.com/salmonumbrella/airwallex-cli/internal/secrets"
 )
 
 func TestAuthAddCommand(t *testing.T) {
@@ -279,6 +291,77 @@ func TestAuthTestCommand(t *testing.T) {
 	}
 }
 
+func TestAuthValidateCommand_JSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/v1/authentication/login" && r.Header.Get("x-api-key") == "good-key":
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"token":      "tok",
+				"expires_at": time.Now().Add(time.Hour).Format(time.RFC3339),
+			})
+		case r.URL.Path == "/api/v1/authentication/login":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code":"credentials_invalid","message":"Invalid API key"}`))
+		default:
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	defer srv.Close()
+
+	original := newClientForCreds
+	newClientForCreds = func(creds secrets.Credentials) (*api.Client, error) {
+		return api.NewClientWithBaseURL(srv.URL, creds.ClientID, creds.APIKey)
+	}
+	defer func() { newClientForCreds = original }()
+
+	tests := []struct {
+		name      string
+		apiKey    string
+		wantOK    bool
+		wantError string
+	}{
+		{name: "valid credentials", apiKey: "good-key", wantOK: true},
+		{name: "rejected credentials", apiKey: "bad-key", wantError: "Invalid API key"},
+		{name: "empty API key", apiKey: "", wantError: "API key cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+			root := NewRootCmd()
+			root.SetOut(&out)
+			root.SetErr(io.Discard)
+			root.SetArgs([]string{"auth", "validate", "--client-id", "cid", "--api-key", tt.apiKey, "--output", "json"})
+			err := root.ExecuteContext(ctx)
+
+			var got struct {
+				Success bool    `json:"success"`
+				Error   *string `json:"error"`
+			}
+			if jerr := json.Unmarshal(out.Bytes(), &got); jerr != nil {
+				t.Fatalf("invalid JSON output %q: %v", out.String(), jerr)
+			}
+			if got.Success != tt.wantOK {
+				t.Errorf("success = %v, want %v (output %s)", got.Success, tt.wantOK, out.String())
+			}
+			if tt.wantOK {
+				if err != nil || got.Error != nil {
+					t.Errorf("err = %v, error field = %v; want neither", err, got.Error)
+				}
+				return
+			}
+			if err == nil {
+				t.Error("expected a non-nil error so the exit code reports failure")
+			}
+			if got.Error == nil || !strings.Contains(*got.Error, tt.wantError) {
+				t.Errorf("error field = %v, want it to contain %q", got.Error, tt.wantError)
+			}
+		})
+	}
+}
+
 func TestAuthLoginCommand(t *testing.T) {
 	t.Skip("Skipping login test as it starts an actual HTTP server and waits for browser interaction")
 
@@ -300,7 +383,7 @@ func TestAuthCommandStructure(t *testing.T) {
 		t.Error("expected Short description to be set")
 	}
 
-	expectedSubcommands := []string{"login", "add", "list", "remove", "rename", "test"}
+	expectedSubcommands := []string{"login", "add", "list", "remove", "rename", "test", "validate"}
 	subcommands := authCmd.Commands()
 
 	if len(subcommands) != len(expectedSubcommands) {
@@ -522,6 +605,52 @@ func TestAuthAddMultipleAccounts(t *testing.T) {
 	}
 }
 
+func TestAuthRemoveAndRename_ClearCachedToken(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	store := memoryStore{}
+	original := openSecretsStore
+	openSecretsStore = func() (secrets.Store, error) { return store, nil }
+	defer func() { openSecretsStore = original }()
+
+	// seed stores credentials for name with a cached token and returns the
+	// token file path.
+	seed := func(name string) string {
+		t.Helper()
+		creds := secrets.Credentials{ClientID: "cid-" + name, APIKey: "key"}
+		store[name] = creds
+		tokens, err := tokenStoreFor(name, creds)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tokens.Save(&api.TokenCache{Token: "tok", ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+			t.Fatal(err)
+		}
+		return tokens.Path
+	}
+	run := func(args ...string) {
+		t.Helper()
+		root := NewRootCmd()
+		root.SetArgs(append([]string{"auth"}, args...))
+		if err := root.ExecuteContext(iocontext.WithIO(context.Background(), &iocontext.IO{Out: io.Discard, ErrOut: io.Discard, In: strings.NewReader("")})); err != nil {
+			t.Fatalf("auth %v failed: %v", args, err)
+		}
+	}
+
+	removed := seed("gone")
+	run("remove", "gone")
+	if _, err := os.Stat(removed); !os.IsNotExist(err) {
+		t.Errorf("auth remove left the token file behind: %v", err)
+	}
+
+	renamed := seed("old")
+	run("rename", "old", "new")
+	if _, err := os.Stat(renamed); !os.IsNotExist(err) {
+		t.Errorf("auth rename left the old token file behind: %v", err)
+	}
+}
+
 // TestAuthRemoveNonExistentAccount tests removing an account that doesn't exist
 func TestAuthRemoveNonExistentAccount(t *testing.T) {
 	cleanup := setupTestEnvironment(t)
@@ -1,45 +1,32 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/salmonumbrella/airwallex-cli/internal/api"
+	"github.com/salmonumbrella/airwallex-cli/internal/benroute"
 	"github.com/salmonumbrella/airwallex-cli/internal/flagmap"
+	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
 	"github.com/salmonumbrella/airwallex-cli/internal/outfmt"
 	"github.com/salmonumbrella/airwallex-cli/internal/reqbuilder"
 	"github.com/salmonumbrella/airwallex-cli/internal/schemavalidator"
 	"github.com/salmonumbrella/airwallex-cli/internal/ui"
 )
 
-// Pre-compiled regexes for beneficiary field validation.
-// Grouped by pattern: "digits of length N" share reDigitsN vars.
-var (
-	reDigits3     = regexp.MustCompile(`^\d{3}$`)          // institution-number, zengin-branch-code, korea-bank-code, hk-bank-code
-	reDigits4     = regexp.MustCompile(`^\d{4}$`)          // zengin-bank-code
-	reDigits5     = regexp.MustCompile(`^\d{5}$`)          // transit-number
-	reDigits6     = regexp.MustCompile(`^\d{6}$`)          // sort-code, bsb
-	reDigits7     = regexp.MustCompile(`^\d{7}$`)          // sg-bank-code
-	reDigits9     = regexp.MustCompile(`^\d{9}$`)          // routing-number
-	reDigits11    = regexp.MustCompile(`^\d{11}$`)         // cpf
-	reDigits12    = regexp.MustCompile(`^\d{12}$`)         // cnaps
-	reDigits14    = regexp.MustCompile(`^\d{14}$`)         // cnpj
-	reDigits18    = regexp.MustCompile(`^\d{18}$`)         // clabe
-	reDigits4or5  = regexp.MustCompile(`^\d{4,5}$`)        // clearing-number
-	reDigits7to9  = regexp.MustCompile(`^\d{7,9}$`)        // fps-id
-	reDigits9or11 = regexp.MustCompile(`^\d{9}$|^\d{11}$`) // payid-abn
-	rePhoneCA     = regexp.MustCompile(`^\+1-\d{10}$`)     // Canada phone
-	rePhoneAU     = regexp.MustCompile(`^\+61-\d{9}$`)     // Australia PayID phone
-	reIFSC        = regexp.MustCompile(`^[A-Z]{4}0[A-Z0-9]{6}$`)
-	reNRIC        = regexp.MustCompile(`^[STFG]\d{7}[A-Z]$`)
-	reEmail       = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
-)
+// reCountryCode validates ISO 3166-1 alpha-2 codes such as --nationality.
+// Routing field formats live in the benroute package.
+var reCountryCode = regexp.MustCompile(`^[A-Z]{2}$`)
 
 func newBeneficiariesCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -53,9 +40,55 @@ func newBeneficiariesCmd() *cobra.Command {
 	cmd.AddCommand(newBeneficiariesUpdateCmd())
 	cmd.AddCommand(newBeneficiariesDeleteCmd())
 	cmd.AddCommand(newBeneficiariesValidateCmd())
+	cmd.AddCommand(newBeneficiariesSchemaCmd())
+	cmd.AddCommand(newBeneficiariesExportCmd())
+	return cmd
+}
+
+// newBeneficiariesSchemaCmd exposes "schemas beneficiary" under the
+// beneficiaries group, where users tend to look for it.
+func newBeneficiariesSchemaCmd() *cobra.Command {
+	cmd := newSchemasBeneficiaryCmd()
+	cmd.Use = "schema"
+	cmd.Aliases = []string{"sc"}
+	cmd.Long = strings.ReplaceAll(cmd.Long, "airwallex schemas beneficiary", "airwallex beneficiaries schema")
 	return cmd
 }
 
+// beneficiaryListColumns are the table columns beneficiaries list can show.
+var beneficiaryListColumns = []ListColumn[api.Beneficiary]{
+	{Name: "id", Header: "BENEFICIARY_ID", Value: func(b api.Beneficiary) string { return b.BeneficiaryID }},
+	{Name: "type", Value: func(b api.Beneficiary) string { return b.Beneficiary.EntityType }},
+	{Name: "name", Value: func(b api.Beneficiary) string {
+		if b.Nickname != "" {
+			return b.Nickname
+		}
+		return b.Beneficiary.BankDetails.AccountName
+	}},
+	{Name: "nickname", Value: func(b api.Beneficiary) string { return b.Nickname }},
+	{Name: "company_name", Value: func(b api.Beneficiary) string { return b.Beneficiary.CompanyName }},
+	{Name: "first_name", Value: func(b api.Beneficiary) string { return b.Beneficiary.FirstName }},
+	{Name: "last_name", Value: func(b api.Beneficiary) string { return b.Beneficiary.LastName }},
+	{Name: "bank_country", Value: func(b api.Beneficiary) string { return b.Beneficiary.BankDetails.BankCountryCode }},
+	{Name: "bank_name", Value: func(b api.Beneficiary) string { return b.Beneficiary.BankDetails.BankName }},
+	{Name: "account_name", Value: func(b api.Beneficiary) string { return b.Beneficiary.BankDetails.AccountName }},
+	{Name: "account_number", Value: func(b api.Beneficiary) string { return b.Beneficiary.BankDetails.AccountNumber }},
+	{Name: "account_currency", Type: outfmt.ColumnCurrency, Value: func(b api.Beneficiary) string { return b.Beneficiary.BankDetails.AccountCurrency }},
+	{Name: "iban", Value: func(b api.Beneficiary) string { return b.Beneficiary.BankDetails.IBAN }},
+	{Name: "swift_code", Value: func(b api.Beneficiary) string { return b.Beneficiary.BankDetails.SwiftCode }},
+	{Name: "routing_type", Value: func(b api.Beneficiary) string { return b.Beneficiary.BankDetails.AccountRoutingType1 }},
+	{Name: "routing_value", Value: func(b api.Beneficiary) string { return b.Beneficiary.BankDetails.AccountRoutingValue1 }},
+	{Name: "clearing_system", Value: func(b api.Beneficiary) string { return b.Beneficiary.BankDetails.LocalClearingSystem }},
+	{Name: "methods", Value: func(b api.Beneficiary) string {
+		if len(b.TransferMethods) > 0 {
+			return b.TransferMethods[0]
+		}
+		return ""
+	}},
+	{Name: "transfer_methods", Value: func(b api.Beneficiary) string { return strings.Join(b.TransferMethods, ",") }},
+	{Name: "payment_methods", Value: func(b api.Beneficiary) string { return strings.Join(b.PaymentMethods, ",") }},
+}
+
 func newBeneficiariesListCmd() *cobra.Command {
 	return NewListCommand(ListConfig[api.Beneficiary]{
 		Use:     "list",
@@ -63,41 +96,41 @@ func newBeneficiariesListCmd() *cobra.Command {
 		Short:   "List beneficiaries",
 		Long: `List beneficiaries for payouts.
 
+Use --columns to pick table columns, e.g. --columns id,name,bank_country,
+or --columns all for every field.
 Use --output json with --query for advanced filtering using jq syntax.
 Tip: add --items-only to output just the array for jq piping.
 
+For large sets, --after-id resumes from a known beneficiary ID instead of a
+page offset, so items are not skipped or repeated if beneficiaries change
+between requests. Other list commands only support --page offsets.
+
 Examples:
   # List recent beneficiaries
   airwallex beneficiaries list --page-size 20
 
+  # Resume after the last beneficiary from a previous run
+  airwallex beneficiaries list --after-id ben_123 --all
+
   # Filter by nickname (case-insensitive) and show key fields
   airwallex beneficiaries list --output json --query \
     '.items[] | select((.nickname // "") | test("Jason|Jing Sen|Huang"; "i")) | {id: .id, nickname: .nickname, account_name: .beneficiary.bank_details.account_name}'`,
-		Headers:      []string{"BENEFICIARY_ID", "TYPE", "NAME", "BANK_COUNTRY", "METHODS"},
-		EmptyMessage: "No beneficiaries found",
-		RowFunc: func(b api.Beneficiary) []string {
-			name := b.Nickname
-			if name == "" {
-				name = b.Beneficiary.BankDetails.AccountName
-			}
-			methods := ""
-			if len(b.TransferMethods) > 0 {
-				methods = b.TransferMethods[0]
-			}
-			return []string{
-				b.BeneficiaryID,
-				b.Beneficiary.EntityType,
-				name,
-				b.Beneficiary.BankDetails.BankCountryCode,
-				methods,
-			}
-		},
+		EmptyMessage:   "No beneficiaries found",
+		Columns:        beneficiaryListColumns,
+		DefaultColumns: []string{"id", "type", "name", "bank_country", "methods"},
 		IDFunc: func(b api.Beneficiary) string {
 			return b.BeneficiaryID
 		},
 		LightFunc: func(b api.Beneficiary) any { return toLightBeneficiary(b) },
+		AfterID:   true,
 		Fetch: func(ctx context.Context, client *api.Client, opts ListOptions) (ListResult[api.Beneficiary], error) {
-			result, err := client.ListBeneficiaries(ctx, opts.Page, opts.Limit)
+			var result *api.BeneficiariesResponse
+			var err error
+			if opts.Cursor != "" {
+				result, err = client.ListBeneficiariesAfter(ctx, opts.Cursor, opts.Limit)
+			} else {
+				result, err = client.ListBeneficiaries(ctx, opts.Page, opts.Limit)
+			}
 			if err != nil {
 				return ListResult[api.Beneficiary]{}, err
 			}
@@ -110,7 +143,9 @@ Examples:
 }
 
 func newBeneficiariesGetCmd() *cobra.Command {
-	return NewGetCommand(GetConfig[*api.Beneficiary]{
+	var raw bool
+
+	cmd := NewGetCommand(GetConfig[*api.Beneficiary]{
 		Use:     "get <beneficiaryId>",
 		Aliases: []string{"g"},
 		Short:   "Get beneficiary details",
@@ -137,9 +172,36 @@ func newBeneficiariesGetCmd() *cobra.Command {
 				outfmt.KV{Key: "bank_name", Value: b.Beneficiary.BankDetails.BankName},
 				outfmt.KV{Key: "account_name", Value: b.Beneficiary.BankDetails.AccountName},
 			)
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}, getClient)
+
+	typedRunE := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if !raw {
+			return typedRunE(cmd, args)
+		}
+		if outfmt.GetQuery(cmd.Context()) != "" || outfmt.GetTemplate(cmd.Context()) != "" {
+			return fmt.Errorf("--raw cannot be combined with --query or --template")
+		}
+		client, err := getClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		body, _, err := client.GetBeneficiaryJSON(cmd.Context(), NormalizeIDArg(args[0]))
+		if err != nil {
+			return err
+		}
+		var out bytes.Buffer
+		if err := json.Indent(&out, body, "", "  "); err != nil {
+			return err
+		}
+		out.WriteByte('\n')
+		_, err = commandOutputWriter(cmd).Write(out.Bytes())
+		return err
+	}
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the API response exactly as returned (server field order, all fields)")
+	return cmd
 }
 
 func newBeneficiariesCreateCmd() *cobra.Command {
@@ -147,6 +209,12 @@ func newBeneficiariesCreateCmd() *cobra.Command {
 	var validateOnly bool
 	// Raw field overrides
 	var fieldOverrides []string
+	var fieldsFile string
+	// Audit copy of the submitted body
+	var saveRequest string
+	var skipIfExists bool
+	var bankLookup string
+	currencyFromCountry := true
 
 	mappings := flagmap.AllMappings()
 	mappingKeys := sortedMappingKeys(mappings)
@@ -197,7 +265,7 @@ Examples:
   # Mexico with CLABE
   airwallex beneficiaries create --entity-type COMPANY --bank-country MX \
     --company-name "Mexico SA" --account-name "Mexico SA" \
-    --account-currency MXN --clabe 012345678901234567
+    --account-currency MXN --clabe 012345678901234568
 
   # Canada EFT (bank transfer)
   airwallex beneficiaries create --entity-type PERSONAL --bank-country CA \
@@ -229,7 +297,7 @@ Examples:
   airwallex beneficiaries create --entity-type PERSONAL --bank-country BR \
     --first-name João --last-name Silva --account-name "João Silva" \
     --account-currency BRL --account-number 123456789 \
-    --swift-code BRASBRRJ --cpf 12345678901 --bank-branch 1234
+    --swift-code BRASBRRJ --cpf 12345678909 --bank-branch 1234
 
   # South Korea
   airwallex beneficiaries create --entity-type PERSONAL --bank-country KR \
@@ -266,6 +334,11 @@ Examples:
     --payid-abn "12345678901" --account-name "Acme Pty Ltd" \
     --company-name "Acme Pty Ltd"
 
+  # SWIFT code resolved from the bank name (prompts to confirm)
+  airwallex beneficiaries create --entity-type COMPANY --bank-country GB \
+    --company-name "Acme Ltd" --account-name "Acme Ltd" --account-currency USD \
+    --account-number 12345678 --bank-lookup Barclays --payment-method SWIFT
+
   # Sweden with clearing number
   airwallex beneficiaries create --entity-type PERSONAL --bank-country SE \
     --first-name Erik --last-name Svensson --account-name "Erik Svensson" \
@@ -278,7 +351,7 @@ Examples:
 				return err
 			}
 
-			overrideFields, err := parseFieldOverrides(fieldOverrides)
+			overrideFields, err := loadFieldOverrides(fieldsFile, fieldOverrides)
 			if err != nil {
 				return err
 			}
@@ -292,6 +365,20 @@ Examples:
 					flagValues["payment-method"] = transferMethod
 				}
 			}
+			// IBANs are often copied in print format ("GB29 NWBK 6016 ...").
+			if v := flagValues["iban"]; v != "" {
+				flagValues["iban"] = strings.ToUpper(strings.ReplaceAll(v, " ", ""))
+			}
+			if bankLookup != "" {
+				confirmed, err := applyBankLookup(cmd.Context(), flagValues, bankLookup)
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					u.Info("Beneficiary creation cancelled.")
+					return nil
+				}
+			}
 
 			entityType := flagValues["entity-type"]
 			entityType = normalizeEnumValue(entityType, []string{"COMPANY", "PERSONAL"})
@@ -299,13 +386,14 @@ Examples:
 			companyName := flagValues["company-name"]
 			firstName := flagValues["first-name"]
 			lastName := flagValues["last-name"]
+			dateOfBirth := flagValues["date-of-birth"]
+			nationality := strings.ToUpper(flagValues["nationality"])
 			nickname := flagValues["nickname"]
 			paymentMethod := flagValues["payment-method"]
 			accountCurrency := flagValues["account-currency"]
 			accountName := flagValues["account-name"]
 			accountNumber := flagValues["account-number"]
 			institutionNumber := flagValues["institution-number"]
-			transitNumber := flagValues["transit-number"]
 			email := flagValues["email"]
 			phone := flagValues["phone"]
 			localClearingSystem := flagValues["clearing-system"]
@@ -322,7 +410,6 @@ Examples:
 			branchCode := flagValues["branch-code"]
 			// Japan Zengin
 			zenginBankCode := flagValues["zengin-bank-code"]
-			zenginBranchCode := flagValues["zengin-branch-code"]
 			bankAccountCategory := flagValues["bank-account-category"]
 			if val := flagValues["account-category"]; val != "" {
 				bankAccountCategory = val
@@ -365,6 +452,14 @@ Examples:
 			addressState := flagValues["address-state"]
 			addressPostcode := flagValues["address-postcode"]
 
+			// Single-currency countries don't need --account-currency.
+			if currencyFromCountry && valueOrOverride(overrideFields, "beneficiary.bank_details.account_currency", accountCurrency) == "" {
+				if currency, ok := defaultCurrencyForCountry(bankCountry); ok {
+					accountCurrency = currency
+					_, _ = fmt.Fprintf(iocontext.GetIO(cmd.Context()).ErrOut, "Using account currency %s for bank country %s (set --account-currency to override)\n", currency, strings.ToUpper(bankCountry))
+				}
+			}
+
 			// Validation: Required fields based on entity type
 			accountNameValue := valueOrOverride(overrideFields, "beneficiary.bank_details.account_name", accountName)
 			accountCurrencyValue := valueOrOverride(overrideFields, "beneficiary.bank_details.account_currency", accountCurrency)
@@ -376,7 +471,7 @@ Examples:
 				return fmt.Errorf("--account-name is required")
 			}
 			if accountCurrencyValue == "" {
-				return fmt.Errorf("--account-currency is required")
+				return fmt.Errorf("--account-currency is required (bank country %s has no single default currency)", strings.ToUpper(bankCountry))
 			}
 
 			switch entityType {
@@ -421,40 +516,10 @@ Examples:
 				return fmt.Errorf("must provide at least one routing method (e.g., --swift-code, --iban, --routing-number, --sort-code, --bsb)")
 			}
 
-			// Validation: Canada EFT requires both institution and transit numbers
-			if institutionNumber != "" && transitNumber == "" {
-				return fmt.Errorf("--transit-number is required when --institution-number is provided")
-			}
-
-			// Validation: Phone number format
-			if phone != "" {
-				if !rePhoneCA.MatchString(phone) {
-					return fmt.Errorf("--phone must match format +1-nnnnnnnnnn (e.g., +1-4165551234)")
-				}
-			}
-
-			// Validation: Institution number format
-			if institutionNumber != "" {
-				if !reDigits3.MatchString(institutionNumber) {
-					return fmt.Errorf("--institution-number must be exactly 3 digits")
-				}
-			}
-
-			// Validation: Transit number format
-			if transitNumber != "" {
-				if !reDigits5.MatchString(transitNumber) {
-					return fmt.Errorf("--transit-number must be exactly 5 digits")
-				}
-			}
-
-			// Validation: Email format
-			if email != "" {
-				if !strings.Contains(email, "@") {
-					return fmt.Errorf("--email must be a valid email address")
-				}
-				parts := strings.Split(email, "@")
-				if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-					return fmt.Errorf("--email must be a valid email address")
+			// Validation: Only one primary routing method unless routing is set via --field
+			if !hasRoutingOverride {
+				if conflicts := conflictingRoutingFlags(flagValues); len(conflicts) > 1 {
+					return fmt.Errorf("conflicting routing flags %s: provide only one routing method, or set routing explicitly with --field beneficiary.bank_details.account_routing_*", strings.Join(conflicts, ", "))
 				}
 			}
 
@@ -469,14 +534,14 @@ Examples:
 					return fmt.Errorf("--clearing-system must be INTERAC when using --email or --phone for CA")
 				}
 			}
+
+			// Validation: per-country routing field formats
+			if errs := benroute.Validate(bankCountry, localClearingValue, flagValues); len(errs) > 0 {
+				return errors.Join(errs...)
+			}
+
 			if isInterac {
 				localClearingSystem = localClearingValue
-				if !strings.EqualFold(bankCountry, "CA") {
-					return fmt.Errorf("--clearing-system INTERAC is only valid with --bank-country CA")
-				}
-				if !hasEmail && !hasPhone {
-					return fmt.Errorf("--email or --phone is required for Interac e-Transfer")
-				}
 				addressCountryValue := valueOrOverride(overrideFields, "beneficiary.address.country_code", addressCountry)
 				addressStreetValue := valueOrOverride(overrideFields, "beneficiary.address.street_address", addressStreet)
 				addressCityValue := valueOrOverride(overrideFields, "beneficiary.address.city", addressCity)
@@ -485,153 +550,20 @@ Examples:
 				}
 			}
 
-			// Validation: Routing number format (US ABA - 9 digits)
-			if routingNumber != "" {
-				if !reDigits9.MatchString(routingNumber) {
-					return fmt.Errorf("--routing-number must be exactly 9 digits")
-				}
-			}
-
-			// Validation: Sort code format (UK - 6 digits)
-			if sortCode != "" {
-				if !reDigits6.MatchString(sortCode) {
-					return fmt.Errorf("--sort-code must be exactly 6 digits")
+			// Validation: date of birth (YYYY-MM-DD, not in the future)
+			if dateOfBirth != "" {
+				dob, err := time.Parse("2006-01-02", dateOfBirth)
+				if err != nil {
+					return fmt.Errorf("--date-of-birth must be a date in YYYY-MM-DD format, got %q", dateOfBirth)
 				}
-			}
-
-			// Validation: BSB format (Australia - 6 digits)
-			if bsb != "" {
-				if !reDigits6.MatchString(bsb) {
-					return fmt.Errorf("--bsb must be exactly 6 digits")
-				}
-			}
-
-			// Validation: CLABE format (Mexico - 18 digits)
-			if clabe != "" {
-				if !reDigits18.MatchString(clabe) {
-					return fmt.Errorf("--clabe must be exactly 18 digits")
-				}
-			}
-
-			// Validation: IFSC format (India - 11 chars: 4 letters, 0, 6 alphanumeric)
-			if ifsc != "" {
-				if !reIFSC.MatchString(strings.ToUpper(ifsc)) {
-					return fmt.Errorf("--ifsc must be 11 characters: 4 letters, 0, then 6 alphanumeric (e.g., SBIN0001234)")
-				}
-			}
-
-			// Validation: Japan Zengin bank code (4 digits)
-			if zenginBankCode != "" {
-				if !reDigits4.MatchString(zenginBankCode) {
-					return fmt.Errorf("--zengin-bank-code must be exactly 4 digits")
-				}
-				if zenginBranchCode == "" {
-					return fmt.Errorf("--zengin-branch-code is required when --zengin-bank-code is provided")
-				}
-			}
-
-			// Validation: Japan Zengin branch code (3 digits)
-			if zenginBranchCode != "" {
-				if !reDigits3.MatchString(zenginBranchCode) {
-					return fmt.Errorf("--zengin-branch-code must be exactly 3 digits")
-				}
-				if zenginBankCode == "" {
-					return fmt.Errorf("--zengin-bank-code is required when --zengin-branch-code is provided")
-				}
-			}
-
-			// Validation: China CNAPS (12 digits)
-			if cnaps != "" {
-				if !reDigits12.MatchString(cnaps) {
-					return fmt.Errorf("--cnaps must be exactly 12 digits")
-				}
-			}
-
-			// Validation: South Korea bank code (3 digits)
-			if koreaBankCode != "" {
-				if !reDigits3.MatchString(koreaBankCode) {
-					return fmt.Errorf("--korea-bank-code must be exactly 3 digits")
-				}
-			}
-
-			// Validation: Brazil CPF (11 digits)
-			if cpf != "" {
-				if !reDigits11.MatchString(cpf) {
-					return fmt.Errorf("--cpf must be exactly 11 digits")
-				}
-			}
-
-			// Validation: Brazil CNPJ (14 digits)
-			if cnpj != "" {
-				if !reDigits14.MatchString(cnpj) {
-					return fmt.Errorf("--cnpj must be exactly 14 digits")
-				}
-			}
-
-			// Validation: Singapore NRIC (9 chars, format SnnnnnnnA)
-			if nric != "" {
-				if !reNRIC.MatchString(strings.ToUpper(nric)) {
-					return fmt.Errorf("--nric must be 9 characters in format SnnnnnnnA (e.g., S1234567A)")
-				}
-			}
-
-			// Validation: Singapore UEN (8-13 chars)
-			if uen != "" {
-				if len(uen) < 8 || len(uen) > 13 {
-					return fmt.Errorf("--uen must be 8-13 characters")
-				}
-			}
-
-			// Validation: Singapore bank code (7 digits)
-			if sgBankCode != "" {
-				if !reDigits7.MatchString(sgBankCode) {
-					return fmt.Errorf("--sg-bank-code must be exactly 7 digits")
-				}
-			}
-
-			// Validation: Singapore PayNow VPA (up to 21 chars)
-			if paynowVPA != "" {
-				if len(paynowVPA) > 21 {
-					return fmt.Errorf("--paynow-vpa must be 21 characters or fewer")
-				}
-			}
-
-			// Australia PayID validation
-			if payidPhone != "" {
-				if !rePhoneAU.MatchString(payidPhone) {
-					return fmt.Errorf("--payid-phone must be in format +61-nnnnnnnnn")
-				}
-			}
-			if payidEmail != "" {
-				if !reEmail.MatchString(payidEmail) {
-					return fmt.Errorf("--payid-email must be a valid email address")
-				}
-			}
-			if payidABN != "" {
-				if !reDigits9or11.MatchString(payidABN) {
-					return fmt.Errorf("--payid-abn must be 9 or 11 digits")
-				}
-			}
-
-			// Validation: Sweden clearing number (4-5 digits)
-			if clearingNumber != "" {
-				if !reDigits4or5.MatchString(clearingNumber) {
-					return fmt.Errorf("--clearing-number must be 4-5 digits")
-				}
-			}
-
-			// Validation: Hong Kong bank code (3 digits)
-			if hkBankCode != "" {
-				if !reDigits3.MatchString(hkBankCode) {
-					return fmt.Errorf("--hk-bank-code must be exactly 3 digits")
+				if dob.After(time.Now()) {
+					return fmt.Errorf("--date-of-birth cannot be in the future")
 				}
 			}
 
-			// Validation: Hong Kong FPS ID (7-9 digits)
-			if fpsID != "" {
-				if !reDigits7to9.MatchString(fpsID) {
-					return fmt.Errorf("--fps-id must be 7-9 digits")
-				}
+			// Validation: nationality (ISO 3166-1 alpha-2)
+			if nationality != "" && !reCountryCode.MatchString(nationality) {
+				return fmt.Errorf("--nationality must be a 2-letter ISO country code (e.g., GB), got %q", nationality)
 			}
 
 			// Validation: China legal representative ID (15 or 18 chars)
@@ -642,104 +574,13 @@ Examples:
 			}
 
 			// Resolve routing unless overridden via --field.
-			routingType := ""
-			routingValue1 := ""
-			routingType2 := ""
-			routingValue2 := ""
 			hasRoutingOverride1 := overrideFields["beneficiary.bank_details.account_routing_value1"] != "" ||
 				overrideFields["beneficiary.bank_details.account_routing_type1"] != ""
 			hasRoutingOverride2 := overrideFields["beneficiary.bank_details.account_routing_value2"] != "" ||
 				overrideFields["beneficiary.bank_details.account_routing_type2"] != ""
-
-			routingTypeFor := func(flagName string) string {
-				if mapping, ok := mappings[flagName]; ok && mapping.RoutingType != "" {
-					return mapping.RoutingType
-				}
-				switch flagName {
-				case "bank-code":
-					return "bank_code"
-				default:
-					return ""
-				}
-			}
-
+			var routing benroute.Routing
 			if !hasRoutingOverride1 {
-				switch {
-				case routingNumber != "":
-					routingType = routingTypeFor("routing-number")
-					routingValue1 = routingNumber
-				case sortCode != "":
-					routingType = routingTypeFor("sort-code")
-					routingValue1 = sortCode
-				case bsb != "":
-					routingType = routingTypeFor("bsb")
-					routingValue1 = bsb
-				case ifsc != "":
-					routingType = routingTypeFor("ifsc")
-					routingValue1 = ifsc
-				case bankCode != "":
-					routingType = routingTypeFor("bank-code")
-					routingValue1 = bankCode
-				case email != "":
-					routingType = routingTypeFor("email")
-					routingValue1 = email
-				case phone != "":
-					routingType = routingTypeFor("phone")
-					routingValue1 = phone
-				case institutionNumber != "":
-					routingType = routingTypeFor("institution-number")
-					routingValue1 = institutionNumber
-					if transitNumber != "" {
-						routingType2 = routingTypeFor("transit-number")
-						routingValue2 = transitNumber
-					}
-				case zenginBankCode != "":
-					routingType = routingTypeFor("zengin-bank-code")
-					routingValue1 = zenginBankCode
-					if zenginBranchCode != "" {
-						routingType2 = routingTypeFor("zengin-branch-code")
-						routingValue2 = zenginBranchCode
-					}
-				case cnaps != "":
-					routingType = routingTypeFor("cnaps")
-					routingValue1 = cnaps
-				case koreaBankCode != "":
-					routingType = routingTypeFor("korea-bank-code")
-					routingValue1 = koreaBankCode
-				case nric != "":
-					routingType = routingTypeFor("nric")
-					routingValue1 = strings.ToUpper(nric)
-				case uen != "":
-					routingType = routingTypeFor("uen")
-					routingValue1 = uen
-				case paynowVPA != "":
-					routingType = routingTypeFor("paynow-vpa")
-					routingValue1 = paynowVPA
-				case sgBankCode != "":
-					routingType = routingTypeFor("sg-bank-code")
-					routingValue1 = sgBankCode
-				case clearingNumber != "":
-					routingType = routingTypeFor("clearing-number")
-					routingValue1 = clearingNumber
-				case hkBankCode != "":
-					routingType = routingTypeFor("hk-bank-code")
-					routingValue1 = hkBankCode
-				case fpsID != "":
-					routingType = routingTypeFor("fps-id")
-					routingValue1 = fpsID
-				case hkid != "":
-					routingType = routingTypeFor("hkid")
-					routingValue1 = hkid
-				case payidPhone != "":
-					routingType = routingTypeFor("payid-phone")
-					routingValue1 = payidPhone
-				case payidEmail != "":
-					routingType = routingTypeFor("payid-email")
-					routingValue1 = payidEmail
-				case payidABN != "":
-					routingType = routingTypeFor("payid-abn")
-					routingValue1 = payidABN
-				}
+				routing = benroute.Resolve(flagValues)
 			}
 
 			fields := map[string]string{
@@ -760,6 +601,8 @@ Examples:
 			addMapped("company-name", companyName)
 			addMapped("first-name", firstName)
 			addMapped("last-name", lastName)
+			addMapped("date-of-birth", dateOfBirth)
+			addMapped("nationality", nationality)
 
 			// Brazil convenience fields
 			if cpf != "" {
@@ -804,34 +647,37 @@ Examples:
 			addMapped("address-postcode", addressPostcode)
 
 			// Routing values
-			if routingValue1 != "" && !hasRoutingOverride1 {
-				fields["beneficiary.bank_details.account_routing_value1"] = routingValue1
-				if routingType != "" {
-					fields["beneficiary.bank_details.account_routing_type1"] = routingType
+			if routing.Value1 != "" {
+				fields["beneficiary.bank_details.account_routing_value1"] = routing.Value1
+				if routing.Type1 != "" {
+					fields["beneficiary.bank_details.account_routing_type1"] = routing.Type1
 				}
 			}
-			if routingValue2 != "" && !hasRoutingOverride2 {
-				fields["beneficiary.bank_details.account_routing_value2"] = routingValue2
-				if routingType2 != "" {
-					fields["beneficiary.bank_details.account_routing_type2"] = routingType2
+			if routing.Value2 != "" && !hasRoutingOverride2 {
+				fields["beneficiary.bank_details.account_routing_value2"] = routing.Value2
+				if routing.Type2 != "" {
+					fields["beneficiary.bank_details.account_routing_type2"] = routing.Type2
 				}
 			}
 
 			req := reqbuilder.BuildNestedMap(fields)
+			// Newer schemas require the singular transfer_method alongside the
+			// plural arrays. The schema fetch below is best-effort, so the
+			// field is sent regardless of what (or whether) it reports.
 			req = reqbuilder.MergeRequest(req, map[string]interface{}{
-				"transfer_method":  paymentMethod,
 				"payment_method":   paymentMethod,
+				"transfer_method":  paymentMethod,
 				"transfer_methods": []string{paymentMethod},
 				"payment_methods":  []string{paymentMethod},
 			})
-			if len(overrideFields) > 0 {
-				req = reqbuilder.MergeRequest(req, reqbuilder.BuildNestedMap(overrideFields))
-			}
 
 			provided := buildBeneficiaryProvidedFields(entityType, bankCountry, paymentMethod, fields, overrideFields)
 			if err := validateBeneficiarySchema(cmd.Context(), client, bankCountry, entityType, paymentMethod, provided, validateOnly); err != nil {
 				return err
 			}
+			if len(overrideFields) > 0 {
+				req = reqbuilder.MergeRequest(req, reqbuilder.BuildNestedMap(overrideFields))
+			}
 
 			if validateOnly {
 				// Show what would be sent
@@ -840,9 +686,35 @@ Examples:
 					return writeJSONOutput(cmd, req)
 				}
 				u.Info(fmt.Sprintf("Would create beneficiary in %s with %s routing", bankCountry, paymentMethod))
+				if dateOfBirth != "" {
+					u.Info(fmt.Sprintf("Date of birth: %s", dateOfBirth))
+				}
+				if nationality != "" {
+					u.Info(fmt.Sprintf("Nationality: %s", nationality))
+				}
 				return nil
 			}
 
+			if skipIfExists {
+				existing, err := findExistingBeneficiary(cmd.Context(), client, provided)
+				if err != nil {
+					return err
+				}
+				if existing != nil {
+					if outfmt.IsJSON(cmd.Context()) {
+						return writeJSONOutput(cmd, existing)
+					}
+					u.Success(fmt.Sprintf("Beneficiary already exists: %s (skipped create)", existing.BeneficiaryID))
+					return nil
+				}
+			}
+
+			if saveRequest != "" {
+				if err := saveRequestBody(saveRequest, req); err != nil {
+					return err
+				}
+			}
+
 			b, err := client.CreateBeneficiary(cmd.Context(), req)
 			if err != nil {
 				return enrichBeneficiaryCreateError(err)
@@ -870,6 +742,11 @@ Examples:
 	// Validation mode flag
 	cmd.Flags().BoolVar(&validateOnly, "validate", false, "Validate against schema without creating")
 	cmd.Flags().StringArrayVar(&fieldOverrides, "field", nil, "Set raw field (path=value)")
+	cmd.Flags().StringVar(&fieldsFile, "fields-file", "", "JSON file of {path: value} field overrides (- for stdin); inline --field wins")
+	cmd.Flags().StringVar(&saveRequest, "save-request", "", "Also write the exact JSON body sent to the API to this file")
+	cmd.Flags().BoolVar(&skipIfExists, "skip-if-exists", false, "Skip creation and print the existing ID if a beneficiary with the same account name, account number/IBAN, and bank country exists")
+	cmd.Flags().BoolVar(&currencyFromCountry, "currency-from-country", true, "Default --account-currency for single-currency bank countries (e.g. JP -> JPY)")
+	cmd.Flags().StringVar(&bankLookup, "bank-lookup", "", "Resolve --swift-code from this bank name and --bank-country using the built-in bank directory (asks for confirmation)")
 
 	mustMarkRequired(cmd, "entity-type")
 	mustMarkRequired(cmd, "bank-country")
@@ -897,6 +774,9 @@ Examples:
 
 func newBeneficiariesUpdateCmd() *cobra.Command {
 	var fieldOverrides []string
+	var fieldsFile string
+	var saveRequest string
+	var ifMatch string
 	updateFlagKeys := []string{
 		"nickname",
 		"company-name",
@@ -927,7 +807,7 @@ func newBeneficiariesUpdateCmd() *cobra.Command {
 				return err
 			}
 
-			hasUpdates := len(fieldOverrides) > 0
+			hasUpdates := len(fieldOverrides) > 0 || fieldsFile != ""
 			for _, flagName := range updateFlagKeys {
 				if cmd.Flags().Changed(flagName) {
 					hasUpdates = true
@@ -939,18 +819,26 @@ func newBeneficiariesUpdateCmd() *cobra.Command {
 				return fmt.Errorf("no updates specified")
 			}
 
-			overrideFields, err := parseFieldOverrides(fieldOverrides)
+			overrideFields, err := loadFieldOverrides(fieldsFile, fieldOverrides)
 			if err != nil {
 				return err
 			}
 
 			beneficiaryID := NormalizeIDArg(args[0])
 
-			// Fetch existing beneficiary data
-			existing, err := client.GetBeneficiaryRaw(cmd.Context(), beneficiaryID)
+			// Fetch existing beneficiary data. Its ETag guards the write below
+			// so a concurrent change is not silently overwritten.
+			rawExisting, etag, err := client.GetBeneficiaryJSON(cmd.Context(), beneficiaryID)
 			if err != nil {
 				return fmt.Errorf("failed to fetch existing beneficiary: %w", err)
 			}
+			var existing map[string]interface{}
+			if err := json.Unmarshal(rawExisting, &existing); err != nil {
+				return fmt.Errorf("failed to decode existing beneficiary: %w", err)
+			}
+			if ifMatch != "" {
+				etag = ifMatch
+			}
 
 			// Remove id field - API doesn't want it in update request
 			delete(existing, "id")
@@ -975,7 +863,19 @@ func newBeneficiariesUpdateCmd() *cobra.Command {
 			}
 			existing = reqbuilder.MergeRequest(existing, updateReq)
 
-			b, err := client.UpdateBeneficiary(cmd.Context(), beneficiaryID, existing)
+			// Keep the server's field order so saved requests diff cleanly.
+			body, err := reqbuilder.MarshalOrdered(existing, rawExisting)
+			if err != nil {
+				return err
+			}
+
+			if saveRequest != "" {
+				if err := saveRequestBody(saveRequest, body); err != nil {
+					return err
+				}
+			}
+
+			b, err := client.UpdateBeneficiaryIfMatch(cmd.Context(), beneficiaryID, body, etag)
 			if err != nil {
 				return err
 			}
@@ -991,6 +891,9 @@ func newBeneficiariesUpdateCmd() *cobra.Command {
 
 	registerMappedFlags(cmd, updateFlagKeys, nil, nil)
 	cmd.Flags().StringArrayVar(&fieldOverrides, "field", nil, "Set raw field (path=value)")
+	cmd.Flags().StringVar(&fieldsFile, "fields-file", "", "JSON file of {path: value} field overrides (- for stdin); inline --field wins")
+	cmd.Flags().StringVar(&saveRequest, "save-request", "", "Also write the exact JSON body sent to the API to this file")
+	cmd.Flags().StringVar(&ifMatch, "if-match", "", "Only update if the beneficiary's ETag matches (default: the ETag read before updating)")
 	flagAlias(cmd.Flags(), "nickname", "nn")
 	flagAlias(cmd.Flags(), "company-name", "cn")
 	flagAlias(cmd.Flags(), "first-name", "fn")
@@ -1003,15 +906,28 @@ func newBeneficiariesUpdateCmd() *cobra.Command {
 	return cmd
 }
 
+// beneficiaryDeleteResult is the JSON outcome of beneficiaries delete.
+type beneficiaryDeleteResult struct {
+	BeneficiaryID string `json:"beneficiary_id"`
+	Deleted       bool   `json:"deleted"`
+	Reason        string `json:"reason,omitempty"`
+}
+
 func newBeneficiariesDeleteCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "delete <beneficiaryId>",
 		Aliases: []string{"del", "rm"},
 		Short:   "Delete a beneficiary",
-		Args:    cobra.ExactArgs(1),
+		Long: `Delete a beneficiary.
+
+With --output json the outcome is printed as
+{"beneficiary_id": ..., "deleted": true}, or "deleted": false with a
+"reason" when the prompt is declined or the API call fails.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			u := ui.FromContext(cmd.Context())
 			beneficiaryID := NormalizeIDArg(args[0])
+			jsonMode := outfmt.IsJSON(cmd.Context())
 
 			// Prompt for confirmation (respects --yes flag and TTY detection)
 			prompt := fmt.Sprintf("Are you sure you want to delete beneficiary %s?", beneficiaryID)
@@ -1020,6 +936,9 @@ func newBeneficiariesDeleteCmd() *cobra.Command {
 				return err
 			}
 			if !confirmed {
+				if jsonMode {
+					return writeJSONOutput(cmd, beneficiaryDeleteResult{BeneficiaryID: beneficiaryID, Reason: "cancelled"})
+				}
 				u.Info("Deletion cancelled.")
 				return nil
 			}
@@ -1030,9 +949,20 @@ func newBeneficiariesDeleteCmd() *cobra.Command {
 			}
 
 			if err := client.DeleteBeneficiary(cmd.Context(), beneficiaryID); err != nil {
+				// --dry-run already wrote the previewed request as the
+				// command's only output.
+				if jsonMode && !errors.Is(err, api.ErrDryRun) {
+					if writeErr := writeJSONOutput(cmd, beneficiaryDeleteResult{BeneficiaryID: beneficiaryID, Reason: err.Error()}); writeErr != nil {
+						return writeErr
+					}
+				}
 				return err
 			}
 
+			if jsonMode {
+				return writeJSONOutput(cmd, beneficiaryDeleteResult{BeneficiaryID: beneficiaryID, Deleted: true})
+			}
+
 			u.Success(fmt.Sprintf("Deleted beneficiary: %s", beneficiaryID))
 			return nil
 		},
@@ -1061,10 +991,15 @@ func newBeneficiariesValidateCmd() *cobra.Command {
 				"bank_country_code": bankCountry,
 			}
 
-			if err := client.ValidateBeneficiary(cmd.Context(), req); err != nil {
+			result, err := client.ValidateBeneficiary(cmd.Context(), req)
+			if err != nil {
 				return err
 			}
 
+			if outfmt.IsJSON(cmd.Context()) {
+				return writeJSONOutput(cmd, result)
+			}
+
 			u.Success("Beneficiary details are valid")
 			return nil
 		},
@@ -1077,6 +1012,42 @@ func newBeneficiariesValidateCmd() *cobra.Command {
 	return cmd
 }
 
+// loadFieldOverrides merges a flat {path: value} JSON file with inline
+// --field entries, which take precedence over the file.
+func loadFieldOverrides(fieldsFile string, entries []string) (map[string]string, error) {
+	overrides := make(map[string]string)
+	if fieldsFile != "" {
+		payload, err := readJSONPayload("", fieldsFile)
+		if err != nil {
+			return nil, fmt.Errorf("--fields-file: %w", err)
+		}
+		for path, value := range payload {
+			if path == "" {
+				return nil, fmt.Errorf("--fields-file: empty field path")
+			}
+			switch v := value.(type) {
+			case string:
+				overrides[path] = v
+			case json.Number:
+				overrides[path] = v.String()
+			case bool:
+				overrides[path] = strconv.FormatBool(v)
+			default:
+				return nil, fmt.Errorf("--fields-file: value for %q must be a string, number, or boolean", path)
+			}
+		}
+	}
+
+	inline, err := parseFieldOverrides(entries)
+	if err != nil {
+		return nil, err
+	}
+	for path, value := range inline {
+		overrides[path] = value
+	}
+	return overrides, nil
+}
+
 func parseFieldOverrides(entries []string) (map[string]string, error) {
 	overrides := make(map[string]string)
 	for _, entry := range entries {
@@ -1146,6 +1117,51 @@ func valueOrOverride(overrides map[string]string, path, fallback string) string
 	return fallback
 }
 
+// primaryRoutingFlagGroups lists the flags that each select a distinct routing
+// method. Flags within a group describe the same method (e.g. Interac email or
+// phone alongside Canadian EFT) and may be combined; flags from different
+// groups conflict because only one can become account_routing_value1.
+var primaryRoutingFlagGroups = [][]string{
+	{"iban"},
+	{"routing-number"},
+	{"sort-code"},
+	{"bsb"},
+	{"ifsc"},
+	{"clabe"},
+	{"bank-code"},
+	{"email", "phone", "institution-number"},
+	{"zengin-bank-code"},
+	{"cnaps"},
+	{"korea-bank-code"},
+	{"nric", "uen", "paynow-vpa", "sg-bank-code"},
+	{"clearing-number"},
+	{"hk-bank-code", "fps-id", "hkid"},
+	{"payid-phone", "payid-email", "payid-abn"},
+}
+
+// conflictingRoutingFlags returns the set flags (as --name) when more than one
+// primary routing group is in use, or nil when the routing choice is unambiguous.
+func conflictingRoutingFlags(flagValues map[string]string) []string {
+	var set []string
+	groups := 0
+	for _, group := range primaryRoutingFlagGroups {
+		used := false
+		for _, name := range group {
+			if flagValues[name] != "" {
+				set = append(set, "--"+name)
+				used = true
+			}
+		}
+		if used {
+			groups++
+		}
+	}
+	if groups < 2 {
+		return nil
+	}
+	return set
+}
+
 func hasRoutingOverrideField(overrides map[string]string) bool {
 	for path, value := range overrides {
 		if value == "" {
@@ -1200,6 +1216,94 @@ func buildBeneficiaryProvidedFields(entityType, bankCountry, paymentMethod strin
 	return provided
 }
 
+// beneficiaryMatchKey identifies a beneficiary's bank account for duplicate
+// detection: account name, account number (or IBAN when there is none), and
+// bank country, normalized for case and spacing.
+type beneficiaryMatchKey struct {
+	accountName string
+	account     string
+	country     string
+}
+
+func newBeneficiaryMatchKey(accountName, accountNumber, iban, country string) beneficiaryMatchKey {
+	account := accountNumber
+	if account == "" {
+		account = iban
+	}
+	return beneficiaryMatchKey{
+		accountName: strings.ToLower(strings.Join(strings.Fields(accountName), " ")),
+		account:     strings.ToUpper(strings.Join(strings.Fields(account), "")),
+		country:     strings.ToUpper(strings.TrimSpace(country)),
+	}
+}
+
+// findExistingBeneficiary pages through all beneficiaries looking for one
+// with the same match key as the provided create fields. It returns nil when
+// there is no match.
+func findExistingBeneficiary(ctx context.Context, client *api.Client, provided map[string]string) (*api.Beneficiary, error) {
+	want := newBeneficiaryMatchKey(
+		provided["beneficiary.bank_details.account_name"],
+		provided["beneficiary.bank_details.account_number"],
+		provided["beneficiary.bank_details.iban"],
+		provided["beneficiary.bank_details.bank_country_code"],
+	)
+	if want.accountName == "" || want.account == "" || want.country == "" {
+		return nil, fmt.Errorf("--skip-if-exists needs an account name, account number or IBAN, and bank country to match on")
+	}
+
+	for page := 1; ; page++ {
+		result, err := client.ListBeneficiaries(ctx, page, 100)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check for existing beneficiaries: %w", err)
+		}
+		for i := range result.Items {
+			bank := result.Items[i].Beneficiary.BankDetails
+			if newBeneficiaryMatchKey(bank.AccountName, bank.AccountNumber, bank.IBAN, bank.BankCountryCode) == want {
+				return &result.Items[i], nil
+			}
+		}
+		if !result.HasMore || len(result.Items) == 0 {
+			return nil, nil
+		}
+	}
+}
+
+// applyBankLookup resolves name in --bank-country to a SWIFT code from the
+// built-in bank directory and, once confirmed, fills in --swift-code (and
+// --bank-name when unset). It reports false when the code is declined.
+func applyBankLookup(ctx context.Context, flagValues map[string]string, name string) (bool, error) {
+	if flagValues["swift-code"] != "" {
+		return false, fmt.Errorf("use only one of --bank-lookup or --swift-code")
+	}
+	country := strings.ToUpper(flagValues["bank-country"])
+	banks := benroute.LookupBank(country, name)
+	switch len(banks) {
+	case 0:
+		return false, fmt.Errorf("--bank-lookup: no bank matching %q in %s in the built-in directory; pass --swift-code instead", name, country)
+	case 1:
+	default:
+		matches := make([]string, 0, len(banks))
+		for _, b := range banks {
+			matches = append(matches, fmt.Sprintf("%s (%s)", b.Name, b.SWIFT))
+		}
+		return false, fmt.Errorf("--bank-lookup: %q matches several banks in %s: %s; use a more specific name", name, country, strings.Join(matches, ", "))
+	}
+
+	bank := banks[0]
+	confirmed, err := ConfirmOrYes(ctx, fmt.Sprintf("Use SWIFT code %s for %s?", bank.SWIFT, bank.Name))
+	if err != nil || !confirmed {
+		return false, err
+	}
+	ui.FromContext(ctx).Info(fmt.Sprintf("Using SWIFT code %s for %s", bank.SWIFT, bank.Name))
+	flagValues["swift-code"] = bank.SWIFT
+	if flagValues["bank-name"] == "" {
+		flagValues["bank-name"] = bank.Name
+	}
+	return true, nil
+}
+
+// validateBeneficiarySchema checks provided fields against the fetched schema.
+// When the schema cannot be fetched, validation is skipped unless strict.
 func validateBeneficiarySchema(ctx context.Context, client *api.Client, bankCountry, entityType, paymentMethod string, provided map[string]string, strict bool) error {
 	schema, err := client.GetBeneficiarySchema(ctx, bankCountry, entityType, paymentMethod)
 	if err != nil {
@@ -1209,39 +1313,22 @@ func validateBeneficiarySchema(ctx context.Context, client *api.Client, bankCoun
 		return nil
 	}
 
-	missing, err := schemavalidator.Validate(schema, provided)
+	verr, err := schemavalidator.ValidateFields(schema, provided)
 	if err != nil {
-		return fmt.Errorf("validation error: %w", err)
+		return err
 	}
-	if len(missing) > 0 {
-		return fmt.Errorf("%s", formatMissingFieldsWithHints(missing))
-	}
-
-	for _, field := range schema.Fields {
-		if field.Rule.Pattern == "" {
-			continue
-		}
-		path := field.Path
-		if path == "" {
-			path = field.Key
-		}
-		if value, ok := provided[path]; ok && value != "" {
-			if err := schemavalidator.ValidatePattern(value, field.Rule.Pattern); err != nil {
-				return fmt.Errorf("field %s: %w", field.Key, err)
-			}
-		}
+	if verr != nil {
+		return fmt.Errorf("%s", formatValidationErrorWithHints(verr))
 	}
 
 	return nil
 }
 
-func formatMissingFieldsWithHints(missing []schemavalidator.MissingField) string {
-	msg := strings.TrimSpace(schemavalidator.FormatMissingFields(missing))
-	if msg == "" {
-		return msg
-	}
-
-	hints := missingFieldFlagHints(missing)
+// formatValidationErrorWithHints lists every missing and invalid field,
+// followed by the flags that supply the missing ones.
+func formatValidationErrorWithHints(verr *schemavalidator.ValidationError) string {
+	msg := verr.Error()
+	hints := missingFieldFlagHints(verr.Missing)
 	if len(hints) == 0 {
 		return msg
 	}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/api"
+	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
+	"github.com/salmonumbrella/airwallex-cli/internal/ui"
+)
+
+// beneficiarySnapshot is the archival export format. Items are the raw API
+// objects, so fields the CLI does not model are archived too. Checksum is the
+// SHA-256 of Items encoded as compact JSON (sorted keys, no HTML escaping, no
+// trailing newline), so it can be recomputed with:
+// jq -cj .items snapshot.json | sha256sum
+type beneficiarySnapshot struct {
+	GeneratedAt string           `json:"generated_at"`
+	Count       int              `json:"count"`
+	Complete    bool             `json:"complete"`
+	Checksum    string           `json:"checksum"`
+	Items       []map[string]any `json:"items"`
+}
+
+// newBeneficiarySnapshot sorts items by ID and computes the checksum.
+func newBeneficiarySnapshot(items []map[string]any, complete bool, now time.Time) (*beneficiarySnapshot, error) {
+	sorted := append([]map[string]any(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return rawBeneficiaryID(sorted[i]) < rawBeneficiaryID(sorted[j])
+	})
+	sum, err := beneficiarySnapshotChecksum(sorted)
+	if err != nil {
+		return nil, err
+	}
+	return &beneficiarySnapshot{
+		GeneratedAt: now.UTC().Format(time.RFC3339),
+		Count:       len(sorted),
+		Complete:    complete,
+		Checksum:    sum,
+		Items:       sorted,
+	}, nil
+}
+
+func rawBeneficiaryID(item map[string]any) string {
+	id, _ := item["id"].(string)
+	return id
+}
+
+func beneficiarySnapshotChecksum(items []map[string]any) (string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(items); err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// fetchBeneficiariesForExport reads the first page, or every page when all is
+// set, continuing by ID so concurrent changes cannot skip or repeat items.
+func fetchBeneficiariesForExport(ctx context.Context, client *api.Client, pageSize int, all bool) ([]map[string]any, bool, error) {
+	result, err := client.ListBeneficiariesRaw(ctx, 1, pageSize)
+	if err != nil {
+		return nil, false, err
+	}
+	items := result.Items
+	for all && result.HasMore && len(result.Items) > 0 {
+		lastID := rawBeneficiaryID(result.Items[len(result.Items)-1])
+		result, err = client.ListBeneficiariesRawAfter(ctx, lastID, pageSize)
+		if err != nil {
+			return nil, false, err
+		}
+		items = append(items, result.Items...)
+	}
+	return items, !result.HasMore, nil
+}
+
+func newBeneficiariesExportCmd() *cobra.Command {
+	var out string
+	var pageAll bool
+	var pageSize int
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Write a checksummed beneficiary snapshot for archival",
+		Long: `Export beneficiaries to a JSON snapshot file for compliance archival.
+
+Each item is the full beneficiary object the API returned, including fields
+the CLI does not otherwise display. Items are sorted by ID, so repeated
+exports of unchanged data are identical apart from generated_at. The checksum is the SHA-256 of the items array as
+compact JSON and can be verified with:
+
+  echo "sha256:$(jq -cj .items snapshot.json | sha256sum | cut -d' ' -f1)"
+
+Without --page-all only the first page is exported and "complete" is false
+when more beneficiaries exist.
+
+Examples:
+  airwallex beneficiaries export --out snapshot.json --page-all`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if pageSize < 1 || pageSize > 100 {
+				return fmt.Errorf("--page-size must be between 1 and 100")
+			}
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			items, complete, err := fetchBeneficiariesForExport(cmd.Context(), client, pageSize, pageAll)
+			if err != nil {
+				return err
+			}
+			snap, err := newBeneficiarySnapshot(items, complete, time.Now())
+			if err != nil {
+				return err
+			}
+			data, err := json.MarshalIndent(snap, "", "  ")
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(out, append(data, '\n'), 0o600); err != nil {
+				return fmt.Errorf("failed to write snapshot: %w", err)
+			}
+
+			u := ui.FromContext(cmd.Context())
+			noun := "beneficiaries"
+			if snap.Count == 1 {
+				noun = "beneficiary"
+			}
+			u.Success(fmt.Sprintf("Exported %d %s to %s (%s)", snap.Count, noun, out, snap.Checksum))
+			if !complete {
+				warnf(cmd.Context(), iocontext.GetIO(cmd.Context()).ErrOut, "more beneficiaries are available; use --page-all for a complete snapshot")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&out, "out", "", "Snapshot file to write (required)")
+	cmd.Flags().BoolVar(&pageAll, "page-all", false, "Fetch every page (required for a complete snapshot)")
+	cmd.Flags().IntVarP(&pageSize, "page-size", "n", 100, "Page size (1-100)")
+	mustMarkRequired(cmd, "out")
+	return cmd
+}
@@ -1,12 +1,22 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
 
 	"github.com/salmonumbrella/airwallex-cli/internal/api"
+	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
 	"github.com/salmonumbrella/airwallex-cli/internal/schemavalidator"
 )
 
@@ -422,12 +432,12 @@ func TestBeneficiariesCreateNicknameAlias(t *testing.T) {
 	}
 }
 
-func TestFormatMissingFieldsWithHints_AddressFields(t *testing.T) {
+func TestFormatValidationErrorWithHints_AddressFields(t *testing.T) {
 	missing := []schemavalidator.MissingField{
 		{Path: "beneficiary.address.state", Key: "beneficiary.address.state"},
 		{Path: "beneficiary.address.postcode", Key: "beneficiary.address.postcode"},
 	}
-	msg := formatMissingFieldsWithHints(missing)
+	msg := formatValidationErrorWithHints(&schemavalidator.ValidationError{Missing: missing})
 	if !strings.Contains(msg, "--address-state") {
 		t.Fatalf("expected hint for --address-state, got: %s", msg)
 	}
@@ -467,11 +477,397 @@ func TestBuildBeneficiaryProvidedFields_TransferMethodCompatibility(t *testing.T
 	}
 }
 
-func TestFormatMissingFieldsWithHints_TransferMethodField(t *testing.T) {
+func TestBeneficiariesCreate_SendsTransferMethod(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	tests := []struct {
+		name   string
+		schema *api.Schema
+	}{
+		{name: "schema without transfer_method", schema: &api.Schema{}},
+		{name: "schema requires transfer_method", schema: &api.Schema{
+			Fields: []api.SchemaField{{Key: "transfer_method", Path: "transfer_method", Required: true}},
+		}},
+	}
+	// Restore the default "no schema" response for later tests.
+	defer testMockServer.HandleError("POST", "/api/v1/beneficiary_api_schemas/generate", http.StatusNotFound, "endpoint not found")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testMockServer.HandleJSON("POST", "/api/v1/beneficiary_api_schemas/generate", http.StatusOK, tt.schema)
+
+			var out bytes.Buffer
+			ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+			root := NewRootCmd()
+			root.SetArgs([]string{
+				"beneficiaries", "create",
+				"--entity-type", "COMPANY",
+				"--bank-country", "US",
+				"--company-name", "Test Corp",
+				"--account-name", "Test Corp",
+				"--account-currency", "USD",
+				"--account-number", "123456789",
+				"--routing-number", "021000021",
+				"--payment-method", "SWIFT",
+				"--validate",
+				"--output", "json",
+			})
+			if err := root.ExecuteContext(ctx); err != nil {
+				t.Fatalf("create --validate failed: %v", err)
+			}
+
+			var req map[string]interface{}
+			if err := json.Unmarshal(out.Bytes(), &req); err != nil {
+				t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+			}
+			if got := req["transfer_method"]; got != "SWIFT" {
+				t.Errorf("transfer_method = %v, want SWIFT (request: %v)", got, req)
+			}
+			if methods, _ := req["transfer_methods"].([]interface{}); len(methods) != 1 || methods[0] != "SWIFT" {
+				t.Errorf("transfer_methods = %v, want [SWIFT]", req["transfer_methods"])
+			}
+		})
+	}
+}
+
+func TestBeneficiariesCreate_DefaultCurrencyFromCountry(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.HandleJSON("POST", "/api/v1/beneficiary_api_schemas/generate", http.StatusOK, api.Schema{})
+	defer testMockServer.HandleError("POST", "/api/v1/beneficiary_api_schemas/generate", http.StatusNotFound, "endpoint not found")
+
+	run := func(country string, extra ...string) (map[string]interface{}, string, error) {
+		var out, errOut bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: &errOut, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetArgs(append([]string{
+			"beneficiaries", "create",
+			"--entity-type", "PERSONAL",
+			"--bank-country", country,
+			"--first-name", "Taro",
+			"--last-name", "Yamada",
+			"--account-name", "Yamada Taro",
+			"--account-number", "1234567",
+			"--validate",
+			"--output", "json",
+		}, extra...))
+		if err := root.ExecuteContext(ctx); err != nil {
+			return nil, errOut.String(), err
+		}
+		var req map[string]interface{}
+		if err := json.Unmarshal(out.Bytes(), &req); err != nil {
+			t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+		}
+		return req, errOut.String(), nil
+	}
+
+	req, stderr, err := run("JP", "--zengin-bank-code", "0001", "--zengin-branch-code", "001", "--account-category", "Savings")
+	if err != nil {
+		t.Fatalf("JP create --validate failed: %v", err)
+	}
+	bank, _ := req["beneficiary"].(map[string]interface{})["bank_details"].(map[string]interface{})
+	if bank["account_currency"] != "JPY" {
+		t.Errorf("account_currency = %v, want JPY", bank["account_currency"])
+	}
+	if !strings.Contains(stderr, "Using account currency JPY for bank country JP") {
+		t.Errorf("expected stderr notice, got %q", stderr)
+	}
+
+	_, _, err = run("US", "--routing-number", "021000021")
+	if err == nil || !strings.Contains(err.Error(), "--account-currency is required") {
+		t.Errorf("expected US to require --account-currency, got %v", err)
+	}
+
+	_, _, err = run("JP", "--zengin-bank-code", "0001", "--zengin-branch-code", "001", "--currency-from-country=false")
+	if err == nil || !strings.Contains(err.Error(), "--account-currency is required") {
+		t.Errorf("expected --currency-from-country=false to require --account-currency, got %v", err)
+	}
+}
+
+func TestBeneficiariesValidate_JSONEmitsServerPayload(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	payload := map[string]interface{}{
+		"warnings": []interface{}{"routing number will be normalized"},
+		"beneficiary": map[string]interface{}{
+			"bank_details": map[string]interface{}{"account_routing_value1": "021000021"},
+		},
+	}
+	testMockServer.HandleJSON("POST", "/api/v1/beneficiaries/validate", http.StatusOK, payload)
+	defer testMockServer.HandleError("POST", "/api/v1/beneficiaries/validate", http.StatusNotFound, "endpoint not found")
+
+	var out bytes.Buffer
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+	root := NewRootCmd()
+	root.SetArgs([]string{"beneficiaries", "validate", "--entity-type", "COMPANY", "--bank-country", "US", "--output", "json"})
+	if err := root.ExecuteContext(ctx); err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+	if !reflect.DeepEqual(got, payload) {
+		t.Errorf("output = %v, want %v", got, payload)
+	}
+}
+
+func TestBeneficiariesSchema_AsJSONSchema(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.HandleJSON("POST", "/api/v1/beneficiary_api_schemas/generate", http.StatusOK, api.Schema{
+		Fields: []api.SchemaField{
+			{Key: "swift_code", Path: "beneficiary.bank_details.swift_code", Required: true, Rule: api.SchemaFieldRule{Type: "string", Pattern: "^[A-Z]{6}[A-Z0-9]{2}([A-Z0-9]{3})?$"}},
+		},
+	})
+	defer testMockServer.HandleError("POST", "/api/v1/beneficiary_api_schemas/generate", http.StatusNotFound, "endpoint not found")
+
+	var out bytes.Buffer
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+	root := NewRootCmd()
+	root.SetArgs([]string{"beneficiaries", "schema", "--bank-country", "US", "--entity-type", "COMPANY", "--as", "json-schema"})
+	if err := root.ExecuteContext(ctx); err != nil {
+		t.Fatalf("schema --as json-schema failed: %v", err)
+	}
+
+	var doc struct {
+		Schema     string `json:"$schema"`
+		Properties struct {
+			Beneficiary struct {
+				Properties struct {
+					BankDetails struct {
+						Required   []string `json:"required"`
+						Properties map[string]struct {
+							Type    string `json:"type"`
+							Pattern string `json:"pattern"`
+						} `json:"properties"`
+					} `json:"bank_details"`
+				} `json:"properties"`
+			} `json:"beneficiary"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+	if doc.Schema == "" {
+		t.Error("missing $schema")
+	}
+	bank := doc.Properties.Beneficiary.Properties.BankDetails
+	if len(bank.Required) != 1 || bank.Required[0] != "swift_code" {
+		t.Errorf("required = %v, want [swift_code]", bank.Required)
+	}
+	if got := bank.Properties["swift_code"].Pattern; got != "^[A-Z]{6}[A-Z0-9]{2}([A-Z0-9]{3})?$" {
+		t.Errorf("pattern = %q", got)
+	}
+}
+
+func TestBeneficiariesCreate_SaveRequestMatchesPostedBody(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	var posted []byte
+	testMockServer.Handle("POST", "/api/v1/beneficiaries/create", func(w http.ResponseWriter, r *http.Request) {
+		posted, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"ben_saved"}`))
+	})
+	defer testMockServer.HandleError("POST", "/api/v1/beneficiaries/create", http.StatusNotFound, "endpoint not found")
+
+	saved := filepath.Join(t.TempDir(), "request.json")
+	root := NewRootCmd()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{
+		"beneficiaries", "create",
+		"--entity-type", "COMPANY",
+		"--bank-country", "US",
+		"--company-name", "Test Corp",
+		"--account-name", "Test Corp",
+		"--account-currency", "USD",
+		"--account-number", "123456789",
+		"--routing-number", "021000021",
+		"--field", "nickname=Audit",
+		"--save-request", saved,
+	})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	got, err := os.ReadFile(saved)
+	if err != nil {
+		t.Fatalf("failed to read saved request: %v", err)
+	}
+	if len(posted) == 0 {
+		t.Fatal("expected create request to be posted")
+	}
+	if !bytes.Equal(got, posted) {
+		t.Errorf("saved request differs from posted body\nsaved:  %s\nposted: %s", got, posted)
+	}
+}
+
+func TestBeneficiariesCreate_FieldsFileWithInlineOverride(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	var posted map[string]interface{}
+	testMockServer.Handle("POST", "/api/v1/beneficiaries/create", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&posted)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"ben_fields"}`))
+	})
+	defer testMockServer.HandleError("POST", "/api/v1/beneficiaries/create", http.StatusNotFound, "endpoint not found")
+
+	fieldsFile := filepath.Join(t.TempDir(), "overrides.json")
+	if err := os.WriteFile(fieldsFile, []byte(`{"nickname": "FromFile", "beneficiary.address.city": "Seattle"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	root := NewRootCmd()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{
+		"beneficiaries", "create",
+		"--entity-type", "COMPANY",
+		"--bank-country", "US",
+		"--company-name", "Test Corp",
+		"--account-name", "Test Corp",
+		"--account-currency", "USD",
+		"--account-number", "123456789",
+		"--routing-number", "021000021",
+		"--fields-file", fieldsFile,
+		"--field", "nickname=Inline",
+	})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	if got := posted["nickname"]; got != "Inline" {
+		t.Errorf("nickname = %v, want inline --field to win over --fields-file", got)
+	}
+	beneficiary, _ := posted["beneficiary"].(map[string]interface{})
+	address, _ := beneficiary["address"].(map[string]interface{})
+	if got := address["city"]; got != "Seattle" {
+		t.Errorf("beneficiary.address.city = %v, want Seattle from --fields-file", got)
+	}
+}
+
+func TestLoadFieldOverrides_RejectsNestedValues(t *testing.T) {
+	fieldsFile := filepath.Join(t.TempDir(), "overrides.json")
+	if err := os.WriteFile(fieldsFile, []byte(`{"beneficiary": {"nickname": "x"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err := loadFieldOverrides(fieldsFile, nil)
+	if err == nil || !strings.Contains(err.Error(), "must be a string, number, or boolean") {
+		t.Fatalf("expected flat-map error, got %v", err)
+	}
+}
+
+func TestBeneficiariesList_EmptyJSONIsArray(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.HandleJSON("GET", "/api/v1/beneficiaries", http.StatusOK, map[string]any{"items": nil, "has_more": false})
+	defer testMockServer.HandleError("GET", "/api/v1/beneficiaries", http.StatusNotFound, "endpoint not found")
+
+	run := func(args ...string) string {
+		t.Helper()
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetArgs(args)
+		if err := root.ExecuteContext(ctx); err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+		return strings.TrimSpace(out.String())
+	}
+
+	if got := run("beneficiaries", "list", "--output", "json", "--items-only"); got != "[]" {
+		t.Errorf("--items-only output = %q, want []", got)
+	}
+
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(run("beneficiaries", "list", "--output", "json")), &envelope); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got := string(envelope["items"]); got != "[]" {
+		t.Errorf("items = %s, want []", got)
+	}
+}
+
+func TestBeneficiariesCreate_SkipIfExists(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.HandleJSON("GET", "/api/v1/beneficiaries", http.StatusOK, map[string]any{
+		"items": []any{
+			map[string]any{"id": "ben_other", "beneficiary": map[string]any{"bank_details": map[string]any{
+				"account_name": "Test Corp", "account_number": "999999999", "bank_country_code": "US",
+			}}},
+			map[string]any{"id": "ben_existing", "beneficiary": map[string]any{"bank_details": map[string]any{
+				"account_name": "test  corp", "account_number": "123 456 789", "bank_country_code": "us",
+			}}},
+		},
+		"has_more": false,
+	})
+	defer testMockServer.HandleError("GET", "/api/v1/beneficiaries", http.StatusNotFound, "endpoint not found")
+
+	created := false
+	testMockServer.Handle("POST", "/api/v1/beneficiaries/create", func(w http.ResponseWriter, r *http.Request) {
+		created = true
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"ben_new"}`))
+	})
+	defer testMockServer.HandleError("POST", "/api/v1/beneficiaries/create", http.StatusNotFound, "endpoint not found")
+
+	run := func(accountNumber string) string {
+		t.Helper()
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetArgs([]string{
+			"beneficiaries", "create",
+			"--entity-type", "COMPANY",
+			"--bank-country", "US",
+			"--company-name", "Test Corp",
+			"--account-name", "Test Corp",
+			"--account-currency", "USD",
+			"--account-number", accountNumber,
+			"--routing-number", "021000021",
+			"--skip-if-exists",
+			"--output", "json",
+		})
+		if err := root.ExecuteContext(ctx); err != nil {
+			t.Fatalf("create failed: %v", err)
+		}
+		var got api.Beneficiary
+		if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+			t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+		}
+		return got.BeneficiaryID
+	}
+
+	if id := run("123456789"); id != "ben_existing" {
+		t.Errorf("id = %q, want existing ben_existing", id)
+	}
+	if created {
+		t.Error("beneficiary was created even though a match exists")
+	}
+
+	if id := run("555555555"); id != "ben_new" || !created {
+		t.Errorf("id = %q (created=%v), want a new beneficiary when nothing matches", id, created)
+	}
+}
+
+func TestFormatValidationErrorWithHints_TransferMethodField(t *testing.T) {
 	missing := []schemavalidator.MissingField{
 		{Path: "transfer_method", Key: "transfer_method"},
 	}
-	msg := formatMissingFieldsWithHints(missing)
+	msg := formatValidationErrorWithHints(&schemavalidator.ValidationError{Missing: missing})
 	if !strings.Contains(msg, "--payment-method") {
 		t.Fatalf("expected hint for --payment-method, got: %s", msg)
 	}
@@ -564,6 +960,75 @@ func TestBeneficiariesCreateValidationCombinations(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "conflicting routing methods - iban and sort-code",
+			args: []string{
+				"--entity-type", "COMPANY",
+				"--bank-country", "GB",
+				"--company-name", "UK Ltd",
+				"--account-name", "UK Ltd",
+				"--account-currency", "GBP",
+				"--iban", "GB29NWBK60161331926819",
+				"--sort-code", "601613",
+			},
+			wantErr:     true,
+			errContains: "conflicting routing flags --iban, --sort-code",
+		},
+		{
+			name: "conflicting routing methods - iban and routing-number",
+			args: []string{
+				"--entity-type", "COMPANY",
+				"--bank-country", "US",
+				"--company-name", "Test Corp",
+				"--account-name", "Test Corp",
+				"--account-currency", "USD",
+				"--account-number", "123456789",
+				"--iban", "DE89370400440532013000",
+				"--routing-number", "021000021",
+			},
+			wantErr:     true,
+			errContains: "provide only one routing method",
+		},
+		{
+			name: "IBAN with a wrong check digit",
+			args: []string{
+				"--entity-type", "COMPANY",
+				"--bank-country", "DE",
+				"--company-name", "Test GmbH",
+				"--account-name", "Test GmbH",
+				"--account-currency", "EUR",
+				"--iban", "DE89370400440532013001",
+			},
+			wantErr:     true,
+			errContains: "not a valid IBAN",
+		},
+		{
+			name: "IBAN in print format",
+			args: []string{
+				"--entity-type", "COMPANY",
+				"--bank-country", "DE",
+				"--company-name", "Test GmbH",
+				"--account-name", "Test GmbH",
+				"--account-currency", "EUR",
+				"--iban", "DE89 3704 0044 0532 0130 00",
+			},
+			wantErr: false,
+		},
+		{
+			name: "conflicting routing flags allowed with explicit routing override",
+			args: []string{
+				"--entity-type", "COMPANY",
+				"--bank-country", "GB",
+				"--company-name", "UK Ltd",
+				"--account-name", "UK Ltd",
+				"--account-currency", "GBP",
+				"--iban", "GB29NWBK60161331926819",
+				"--sort-code", "601613",
+				"--field", "beneficiary.bank_details.account_routing_type1=sort_code",
+				"--field", "beneficiary.bank_details.account_routing_value1=601613",
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -682,10 +1147,23 @@ func TestBeneficiariesCreate_InternationalRouting(t *testing.T) {
 				"--company-name", "Mexico SA",
 				"--account-name", "Mexico SA",
 				"--account-currency", "MXN",
-				"--clabe", "123456789012345678",
+				"--clabe", "123456789012345673",
 			},
 			wantErr: false,
 		},
+		{
+			name: "Mexico with CLABE control digit wrong",
+			args: []string{
+				"--entity-type", "COMPANY",
+				"--bank-country", "MX",
+				"--company-name", "Mexico SA",
+				"--account-name", "Mexico SA",
+				"--account-currency", "MXN",
+				"--clabe", "123456789012345678",
+			},
+			wantErr:     true,
+			errContains: "is not a valid CLABE",
+		},
 		{
 			name: "SWIFT only without account number",
 			args: []string{
@@ -755,6 +1233,20 @@ func TestBeneficiariesCreate_InternationalRouting(t *testing.T) {
 			wantErr:     true,
 			errContains: "--routing-number must be exactly 9 digits",
 		},
+		{
+			name: "invalid routing number - checksum",
+			args: []string{
+				"--entity-type", "COMPANY",
+				"--bank-country", "US",
+				"--company-name", "Test Corp",
+				"--account-name", "Test Corp",
+				"--account-currency", "USD",
+				"--account-number", "123456789",
+				"--routing-number", "123456789",
+			},
+			wantErr:     true,
+			errContains: "is not a valid ABA routing number",
+		},
 		{
 			name: "invalid sort code format - too short",
 			args: []string{
@@ -1081,7 +1573,7 @@ func TestBeneficiariesCreate_InternationalRouting(t *testing.T) {
 			errContains: "--cnpj must be exactly 14 digits",
 		},
 		{
-			name: "valid Brazil PERSONAL with CPF",
+			name: "cpf invalid - check digits",
 			args: []string{
 				"--entity-type", "PERSONAL",
 				"--bank-country", "BR",
@@ -1093,6 +1585,37 @@ func TestBeneficiariesCreate_InternationalRouting(t *testing.T) {
 				"--swift-code", "BRASBRRJ",
 				"--cpf", "12345678901",
 			},
+			wantErr:     true,
+			errContains: "is not a valid CPF",
+		},
+		{
+			name: "cnpj invalid - repeated digits",
+			args: []string{
+				"--entity-type", "COMPANY",
+				"--bank-country", "BR",
+				"--company-name", "Empresa LTDA",
+				"--account-name", "Empresa LTDA",
+				"--account-currency", "BRL",
+				"--account-number", "123456789",
+				"--swift-code", "BRASBRRJ",
+				"--cnpj", "00000000000000",
+			},
+			wantErr:     true,
+			errContains: "is not a valid CNPJ",
+		},
+		{
+			name: "valid Brazil PERSONAL with CPF",
+			args: []string{
+				"--entity-type", "PERSONAL",
+				"--bank-country", "BR",
+				"--first-name", "João",
+				"--last-name", "Silva",
+				"--account-name", "João Silva",
+				"--account-currency", "BRL",
+				"--account-number", "123456789",
+				"--swift-code", "BRASBRRJ",
+				"--cpf", "12345678909",
+			},
 			wantErr: false,
 		},
 		{
@@ -1105,7 +1628,7 @@ func TestBeneficiariesCreate_InternationalRouting(t *testing.T) {
 				"--account-currency", "BRL",
 				"--account-number", "123456789",
 				"--swift-code", "BRASBRRJ",
-				"--cnpj", "12345678901234",
+				"--cnpj", "12345678901230",
 			},
 			wantErr: false,
 		},
@@ -1120,7 +1643,7 @@ func TestBeneficiariesCreate_InternationalRouting(t *testing.T) {
 				"--account-currency", "BRL",
 				"--account-number", "123456789",
 				"--swift-code", "BRASBRRJ",
-				"--cpf", "12345678901",
+				"--cpf", "12345678909",
 				"--bank-branch", "1234",
 			},
 			wantErr: false,
@@ -1467,3 +1990,478 @@ func TestBeneficiariesCreate_InternationalRouting(t *testing.T) {
 		})
 	}
 }
+
+func TestBeneficiariesExport_FieldMask(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	ben := func(id string) map[string]any {
+		return map[string]any{
+			"id":       id,
+			"nickname": "Payroll",
+			"beneficiary": map[string]any{
+				"first_name":   "Jane",
+				"last_name":    "Doe",
+				"entity_type":  "PERSONAL",
+				"bank_details": map[string]any{"account_currency": "USD"},
+			},
+		}
+	}
+	testMockServer.HandleJSON("GET", "/api/v1/beneficiaries", http.StatusOK, map[string]any{"items": []any{ben("ben_1"), ben("ben_2")}, "has_more": false})
+	defer testMockServer.HandleError("GET", "/api/v1/beneficiaries", http.StatusNotFound, "endpoint not found")
+	testMockServer.HandleJSON("GET", "/api/v1/beneficiaries/ben_1", http.StatusOK, ben("ben_1"))
+	defer testMockServer.HandleError("GET", "/api/v1/beneficiaries/ben_1", http.StatusNotFound, "endpoint not found")
+
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(io.Discard)
+		root.SetErr(io.Discard)
+		root.SetArgs(args)
+		err := root.ExecuteContext(ctx)
+		return out.String(), err
+	}
+
+	out, err := run("beneficiaries", "list", "--output", "json", "--omit-fields", "beneficiary.first_name,beneficiary.last_name")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	var listed struct {
+		Items   []map[string]any `json:"items"`
+		HasMore *bool            `json:"has_more"`
+	}
+	if err := json.Unmarshal([]byte(out), &listed); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(listed.Items) != 2 || listed.HasMore == nil {
+		t.Fatalf("list envelope not preserved: %s", out)
+	}
+	for _, item := range listed.Items {
+		b, _ := item["beneficiary"].(map[string]any)
+		if _, ok := b["first_name"]; ok {
+			t.Errorf("first_name not omitted: %v", item)
+		}
+		if _, ok := b["last_name"]; ok {
+			t.Errorf("last_name not omitted: %v", item)
+		}
+		if b["entity_type"] != "PERSONAL" || item["nickname"] != "Payroll" {
+			t.Errorf("unrelated fields removed: %v", item)
+		}
+	}
+
+	out, err = run("beneficiaries", "get", "ben_1", "--output", "json", "--only-fields", "id,beneficiary.bank_details")
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	b, _ := got["beneficiary"].(map[string]any)
+	if len(got) != 2 || got["id"] != "ben_1" || len(b) != 1 || b["bank_details"] == nil {
+		t.Errorf("--only-fields output = %v, want only id and beneficiary.bank_details", got)
+	}
+
+	if _, err := run("beneficiaries", "list", "--omit-fields", "id"); err == nil {
+		t.Error("expected error for --omit-fields with text output")
+	}
+}
+
+func TestBeneficiariesCreate_DateOfBirthAndNationality(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.HandleJSON("POST", "/api/v1/beneficiary_api_schemas/generate", http.StatusOK, api.Schema{})
+	defer testMockServer.HandleError("POST", "/api/v1/beneficiary_api_schemas/generate", http.StatusNotFound, "endpoint not found")
+
+	run := func(extra ...string) (map[string]interface{}, error) {
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetArgs(append([]string{
+			"beneficiaries", "create",
+			"--entity-type", "PERSONAL",
+			"--bank-country", "GB",
+			"--first-name", "Jane",
+			"--last-name", "Doe",
+			"--account-name", "Jane Doe",
+			"--account-currency", "GBP",
+			"--account-number", "12345678",
+			"--sort-code", "123456",
+			"--validate",
+			"--output", "json",
+		}, extra...))
+		if err := root.ExecuteContext(ctx); err != nil {
+			return nil, err
+		}
+		var req map[string]interface{}
+		if err := json.Unmarshal(out.Bytes(), &req); err != nil {
+			t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+		}
+		return req, nil
+	}
+
+	req, err := run("--date-of-birth", "1990-04-01", "--nationality", "gb")
+	if err != nil {
+		t.Fatalf("create --validate failed: %v", err)
+	}
+	ben, _ := req["beneficiary"].(map[string]interface{})
+	if ben["date_of_birth"] != "1990-04-01" {
+		t.Errorf("beneficiary.date_of_birth = %v, want 1990-04-01", ben["date_of_birth"])
+	}
+	info, _ := ben["additional_info"].(map[string]interface{})
+	if info["personal_nationality"] != "GB" {
+		t.Errorf("beneficiary.additional_info.personal_nationality = %v, want GB", info["personal_nationality"])
+	}
+
+	// Omitted flags add nothing.
+	req, err = run()
+	if err != nil {
+		t.Fatalf("create --validate failed: %v", err)
+	}
+	ben, _ = req["beneficiary"].(map[string]interface{})
+	if _, ok := ben["date_of_birth"]; ok {
+		t.Errorf("date_of_birth sent without --date-of-birth: %v", ben)
+	}
+	if _, ok := ben["additional_info"]; ok {
+		t.Errorf("additional_info sent without --nationality: %v", ben)
+	}
+
+	if _, err := run("--date-of-birth", "01/04/1990"); err == nil || !strings.Contains(err.Error(), "YYYY-MM-DD") {
+		t.Errorf("expected malformed date error, got %v", err)
+	}
+	if _, err := run("--nationality", "GBR"); err == nil || !strings.Contains(err.Error(), "--nationality") {
+		t.Errorf("expected nationality error, got %v", err)
+	}
+}
+
+func TestBeneficiariesExport_Snapshot(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	ben := func(id, nickname string) map[string]any {
+		return map[string]any{"id": id, "nickname": nickname, "beneficiary": map[string]any{"entity_type": "COMPANY"}, "status": "VERIFIED", "sequence": json.Number("9007199254740993")}
+	}
+	testMockServer.Handle("GET", "/api/v1/beneficiaries", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		page := map[string]any{"items": []any{ben("ben_c", "Carol & Co <HK>"), ben("ben_a", "Acme")}, "has_more": true}
+		if r.URL.Query().Get("after_id") == "ben_a" {
+			page = map[string]any{"items": []any{ben("ben_b", "Bravo")}, "has_more": false}
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	})
+	defer testMockServer.HandleError("GET", "/api/v1/beneficiaries", http.StatusNotFound, "endpoint not found")
+
+	dir := t.TempDir()
+	var errOut bytes.Buffer
+	export := func(name string, extra ...string) beneficiarySnapshot {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		errOut.Reset()
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: io.Discard, ErrOut: &errOut, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(io.Discard)
+		root.SetErr(io.Discard)
+		root.SetArgs(append([]string{"beneficiaries", "export", "--out", path}, extra...))
+		if err := root.ExecuteContext(ctx); err != nil {
+			t.Fatalf("export failed: %v", err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read snapshot: %v", err)
+		}
+		var snap beneficiarySnapshot
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err := dec.Decode(&snap); err != nil {
+			t.Fatalf("invalid snapshot JSON: %v\n%s", err, data)
+		}
+		return snap
+	}
+
+	first := export("first.json", "--page-all")
+	var ids []string
+	for _, b := range first.Items {
+		ids = append(ids, rawBeneficiaryID(b))
+		if b["status"] != "VERIFIED" || fmt.Sprint(b["sequence"]) != "9007199254740993" {
+			t.Errorf("unmodelled fields not archived exactly: %v", b)
+		}
+	}
+	if want := []string{"ben_a", "ben_b", "ben_c"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+	if first.Count != 3 || !first.Complete || first.GeneratedAt == "" {
+		t.Errorf("unexpected snapshot header: count=%d complete=%v generated_at=%q", first.Count, first.Complete, first.GeneratedAt)
+	}
+	sum, err := beneficiarySnapshotChecksum(first.Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Checksum != sum || !strings.HasPrefix(sum, "sha256:") {
+		t.Errorf("checksum = %q, recomputed %q", first.Checksum, sum)
+	}
+
+	second := export("second.json", "--page-all")
+	if second.Checksum != first.Checksum || !reflect.DeepEqual(second.Items, first.Items) {
+		t.Errorf("snapshot not stable across runs: %q vs %q", first.Checksum, second.Checksum)
+	}
+
+	partial := export("partial.json")
+	if partial.Complete || partial.Count != 2 {
+		t.Errorf("first page only: complete=%v count=%d, want incomplete with 2 items", partial.Complete, partial.Count)
+	}
+	if !strings.Contains(errOut.String(), "warning: more beneficiaries are available") {
+		t.Errorf("stderr = %q, want an incomplete-snapshot warning", errOut.String())
+	}
+}
+
+func TestBeneficiariesUpdate_IfMatch(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.Handle("GET", "/api/v1/beneficiaries/ben_1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"id":"ben_1","nickname":"Old"}`))
+	})
+	defer testMockServer.HandleError("GET", "/api/v1/beneficiaries/ben_1", http.StatusNotFound, "endpoint not found")
+
+	var gotIfMatch string
+	currentETag := `"v1"`
+	testMockServer.Handle("POST", "/api/v1/beneficiaries/ben_1/update", func(w http.ResponseWriter, r *http.Request) {
+		gotIfMatch = r.Header.Get("If-Match")
+		w.Header().Set("Content-Type", "application/json")
+		if gotIfMatch != currentETag {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			_, _ = w.Write([]byte(`{"code":"precondition_failed","message":"etag mismatch"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"ben_1","nickname":"New"}`))
+	})
+	defer testMockServer.HandleError("POST", "/api/v1/beneficiaries/ben_1/update", http.StatusNotFound, "endpoint not found")
+
+	run := func(args ...string) error {
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: io.Discard, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(io.Discard)
+		root.SetErr(io.Discard)
+		root.SetArgs(append([]string{"beneficiaries", "update", "ben_1", "--nickname", "New"}, args...))
+		return root.ExecuteContext(ctx)
+	}
+
+	if err := run(); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if gotIfMatch != `"v1"` {
+		t.Errorf("If-Match = %q, want the captured ETag %q", gotIfMatch, `"v1"`)
+	}
+
+	// Another writer changed the beneficiary after it was read.
+	currentETag = `"v2"`
+	err := run()
+	if err == nil {
+		t.Fatal("expected a conflict error")
+	}
+	if !api.IsPreconditionFailedError(err) || !strings.Contains(err.Error(), "was modified since it was read") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := run("--if-match", `"v2"`); err != nil {
+		t.Fatalf("update with --if-match failed: %v", err)
+	}
+	if gotIfMatch != `"v2"` {
+		t.Errorf("If-Match = %q, want --if-match value %q", gotIfMatch, `"v2"`)
+	}
+}
+
+func TestBeneficiariesDelete_JSONOutcome(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.HandleJSON("POST", "/api/v1/beneficiaries/ben_gone/delete", http.StatusOK, map[string]any{})
+	testMockServer.HandleError("POST", "/api/v1/beneficiaries/ben_locked/delete", http.StatusBadRequest, "beneficiary is in use")
+
+	run := func(id string) (beneficiaryDeleteResult, error) {
+		t.Helper()
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(&out)
+		root.SetErr(io.Discard)
+		root.SetArgs([]string{"beneficiaries", "delete", id, "--yes", "--output", "json"})
+		err := root.ExecuteContext(ctx)
+		var got beneficiaryDeleteResult
+		if jsonErr := json.Unmarshal(out.Bytes(), &got); jsonErr != nil {
+			t.Fatalf("output is not JSON: %v\n%s", jsonErr, out.String())
+		}
+		return got, err
+	}
+
+	got, err := run("ben_gone")
+	if err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if want := (beneficiaryDeleteResult{BeneficiaryID: "ben_gone", Deleted: true}); got != want {
+		t.Errorf("outcome = %+v, want %+v", got, want)
+	}
+
+	got, err = run("ben_locked")
+	if err == nil {
+		t.Error("expected the failed delete to return an error")
+	}
+	if got.BeneficiaryID != "ben_locked" || got.Deleted || !strings.Contains(got.Reason, "beneficiary is in use") {
+		t.Errorf("outcome = %+v, want deleted=false with the API reason", got)
+	}
+}
+
+func TestBeneficiariesGetRaw_StableServerOrder(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	// Deliberately not in alphabetical order, with a field the typed struct drops.
+	const serverBody = `{"nickname":"Acme","id":"ben_1","beneficiary":{"entity_type":"COMPANY","company_name":"Acme Ltd","bank_details":{"bank_country_code":"US","account_name":"Acme"}},"status":"ACTIVE"}`
+	testMockServer.Handle("GET", "/api/v1/beneficiaries/ben_1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(serverBody))
+	})
+	defer testMockServer.HandleError("GET", "/api/v1/beneficiaries/ben_1", http.StatusNotFound, "endpoint not found")
+
+	var updateBody []byte
+	testMockServer.Handle("POST", "/api/v1/beneficiaries/ben_1/update", func(w http.ResponseWriter, r *http.Request) {
+		updateBody, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"ben_1","nickname":"Renamed"}`))
+	})
+	defer testMockServer.HandleError("POST", "/api/v1/beneficiaries/ben_1/update", http.StatusNotFound, "endpoint not found")
+
+	run := func(args ...string) string {
+		t.Helper()
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(&out)
+		root.SetErr(io.Discard)
+		root.SetArgs(args)
+		if err := root.ExecuteContext(ctx); err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+		return out.String()
+	}
+
+	first := run("beneficiaries", "get", "ben_1", "--raw")
+	for i := 0; i < 5; i++ {
+		if again := run("beneficiaries", "get", "ben_1", "--raw"); again != first {
+			t.Fatalf("--raw output changed between calls:\n%s\nvs\n%s", first, again)
+		}
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(first)); err != nil {
+		t.Fatalf("--raw output is not JSON: %v\n%s", err, first)
+	}
+	if compact.String() != serverBody {
+		t.Errorf("--raw output = %s, want the server body %s", compact.String(), serverBody)
+	}
+
+	run("beneficiaries", "update", "ben_1", "--nickname", "Renamed")
+	want := `{"nickname":"Renamed","beneficiary":{"entity_type":"COMPANY","company_name":"Acme Ltd","bank_details":{"bank_country_code":"US","account_name":"Acme"}},"status":"ACTIVE"}`
+	if string(updateBody) != want {
+		t.Errorf("update body = %s, want server field order %s", updateBody, want)
+	}
+}
+
+func TestBeneficiariesList_Columns(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.HandleJSON("GET", "/api/v1/beneficiaries", http.StatusOK, map[string]any{
+		"items": []any{map[string]any{
+			"id":               "ben_1",
+			"nickname":         "Acme",
+			"beneficiary":      map[string]any{"entity_type": "COMPANY", "bank_details": map[string]any{"bank_country_code": "US", "swift_code": "CHASUS33"}},
+			"transfer_methods": []string{"LOCAL", "SWIFT"},
+		}},
+		"has_more": false,
+	})
+	defer testMockServer.HandleError("GET", "/api/v1/beneficiaries", http.StatusNotFound, "endpoint not found")
+
+	run := func(args ...string) string {
+		t.Helper()
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(&out)
+		root.SetErr(io.Discard)
+		root.SetArgs(append([]string{"beneficiaries", "list", "--output", "csv"}, args...))
+		if err := root.ExecuteContext(ctx); err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+		return out.String()
+	}
+
+	if got, want := run(), "BENEFICIARY_ID,TYPE,NAME,BANK_COUNTRY,METHODS\nben_1,COMPANY,Acme,US,LOCAL\n"; got != want {
+		t.Errorf("default columns = %q, want %q", got, want)
+	}
+	if got, want := run("--columns", "name,swift_code,transfer_methods"), "NAME,SWIFT_CODE,TRANSFER_METHODS\nAcme,CHASUS33,\"LOCAL,SWIFT\"\n"; got != want {
+		t.Errorf("selected columns = %q, want %q", got, want)
+	}
+}
+
+func TestBeneficiariesCreate_BankLookup(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.HandleJSON("POST", "/api/v1/beneficiary_api_schemas/generate", http.StatusOK, api.Schema{})
+	defer testMockServer.HandleError("POST", "/api/v1/beneficiary_api_schemas/generate", http.StatusNotFound, "endpoint not found")
+
+	run := func(extra ...string) (map[string]interface{}, error) {
+		var out, errOut bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: &errOut, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetArgs(append([]string{
+			"beneficiaries", "create",
+			"--entity-type", "COMPANY",
+			"--bank-country", "US",
+			"--company-name", "Acme Inc",
+			"--account-name", "Acme Inc",
+			"--account-currency", "USD",
+			"--account-number", "123456789",
+			"--payment-method", "SWIFT",
+			"--validate",
+			"--output", "json",
+		}, extra...))
+		if err := root.ExecuteContext(ctx); err != nil {
+			return nil, err
+		}
+		var req map[string]interface{}
+		if err := json.Unmarshal(out.Bytes(), &req); err != nil {
+			t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+		}
+		return req, nil
+	}
+
+	req, err := run("--bank-lookup", "chase", "--yes")
+	if err != nil {
+		t.Fatalf("create --bank-lookup failed: %v", err)
+	}
+	bank, _ := req["beneficiary"].(map[string]interface{})["bank_details"].(map[string]interface{})
+	if bank["swift_code"] != "CHASUS33" {
+		t.Errorf("swift_code = %v, want CHASUS33", bank["swift_code"])
+	}
+	if bank["bank_name"] != "JPMorgan Chase Bank" {
+		t.Errorf("bank_name = %v, want JPMorgan Chase Bank", bank["bank_name"])
+	}
+
+	if _, err := run("--bank-lookup", "chase"); err == nil || !strings.Contains(err.Error(), "cannot prompt for confirmation") {
+		t.Errorf("expected the resolved code to need confirmation, got %v", err)
+	}
+	if _, err := run("--bank-lookup", "bank", "--yes"); err == nil || !strings.Contains(err.Error(), "matches several banks") {
+		t.Errorf("expected an ambiguous name to fail, got %v", err)
+	}
+	if _, err := run("--bank-lookup", "Nonexistent Savings", "--yes"); err == nil || !strings.Contains(err.Error(), "pass --swift-code instead") {
+		t.Errorf("expected an unknown bank to fail, got %v", err)
+	}
+	if _, err := run("--bank-lookup", "chase", "--swift-code", "BOFAUS3N", "--yes"); err == nil || !strings.Contains(err.Error(), "only one of --bank-lookup or --swift-code") {
+		t.Errorf("expected --bank-lookup with --swift-code to fail, got %v", err)
+	}
+}
@@ -141,7 +141,7 @@ func newBillingCustomersGetCmd() *cobra.Command {
 				{Key: "created_at", Value: customer.CreatedAt},
 				{Key: "updated_at", Value: customer.UpdatedAt},
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}, getClient)
 }
@@ -162,6 +162,10 @@ Examples:
 		SuccessMessage: func(customer *api.BillingCustomer) string {
 			return fmt.Sprintf("Created billing customer: %s", billingCustomerID(*customer))
 		},
+		ID: func(customer *api.BillingCustomer) string {
+			return billingCustomerID(*customer)
+		},
+		NoDetails: "Created billing customer (the API returned no details; find it with 'airwallex billing customers list')",
 	}, getClient)
 }
 
@@ -254,7 +258,7 @@ func newBillingProductsGetCmd() *cobra.Command {
 				{Key: "unit", Value: product.Unit},
 				{Key: "active", Value: fmt.Sprintf("%t", product.Active)},
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}, getClient)
 }
@@ -275,6 +279,10 @@ Examples:
 		SuccessMessage: func(product *api.BillingProduct) string {
 			return fmt.Sprintf("Created billing product: %s", billingProductID(*product))
 		},
+		ID: func(product *api.BillingProduct) string {
+			return billingProductID(*product)
+		},
+		NoDetails: "Created billing product (the API returned no details; find it with 'airwallex billing products list')",
 	}, getClient)
 }
 
@@ -399,7 +407,7 @@ func newBillingPricesGetCmd() *cobra.Command {
 			if price.Recurring != nil {
 				rows = append(rows, outfmt.KV{Key: "recurring", Value: fmt.Sprintf("%d %s", price.Recurring.Period, price.Recurring.PeriodUnit)})
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}, getClient)
 }
@@ -420,6 +428,10 @@ Examples:
 		SuccessMessage: func(price *api.BillingPrice) string {
 			return fmt.Sprintf("Created billing price: %s", billingPriceID(*price))
 		},
+		ID: func(price *api.BillingPrice) string {
+			return billingPriceID(*price)
+		},
+		NoDetails: "Created billing price (the API returned no details; find it with 'airwallex billing prices list')",
 	}, getClient)
 }
 
@@ -537,7 +549,7 @@ func newBillingInvoicesGetCmd() *cobra.Command {
 			if outfmt.MoneyFloat64(invoice.TotalAmount) != 0 || invoice.Currency != "" {
 				rows = append(rows, outfmt.KV{Key: "total", Value: outfmt.FormatMoney(invoice.TotalAmount) + " " + invoice.Currency})
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}, getClient)
 }
@@ -558,6 +570,10 @@ Examples:
 		SuccessMessage: func(invoice *api.BillingInvoice) string {
 			return fmt.Sprintf("Created billing invoice: %s", billingInvoiceID(*invoice))
 		},
+		ID: func(invoice *api.BillingInvoice) string {
+			return billingInvoiceID(*invoice)
+		},
+		NoDetails: "Created billing invoice (the API returned no details; find it with 'airwallex billing invoices list')",
 	}, getClient)
 }
 
@@ -601,7 +617,7 @@ Examples:
 			if outfmt.MoneyFloat64(preview.TotalAmount) != 0 || preview.Currency != "" {
 				rows = append(rows, outfmt.KV{Key: "total", Value: outfmt.FormatMoney(preview.TotalAmount) + " " + preview.Currency})
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}
 
@@ -687,7 +703,7 @@ func newBillingInvoiceItemsGetCmd() *cobra.Command {
 			if outfmt.MoneyFloat64(item.Amount) != 0 || item.Currency != "" {
 				rows = append(rows, outfmt.KV{Key: "amount", Value: outfmt.FormatMoney(item.Amount) + " " + item.Currency})
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}
 }
@@ -703,6 +719,8 @@ func newBillingSubscriptionsCmd() *cobra.Command {
 	cmd.AddCommand(newBillingSubscriptionsCreateCmd())
 	cmd.AddCommand(newBillingSubscriptionsUpdateCmd())
 	cmd.AddCommand(newBillingSubscriptionsCancelCmd())
+	cmd.AddCommand(newBillingSubscriptionsPauseCmd())
+	cmd.AddCommand(newBillingSubscriptionsResumeCmd())
 	cmd.AddCommand(newBillingSubscriptionItemsCmd())
 	return cmd
 }
@@ -782,7 +800,7 @@ func newBillingSubscriptionsGetCmd() *cobra.Command {
 				{Key: "created_at", Value: sub.CreatedAt},
 				{Key: "updated_at", Value: sub.UpdatedAt},
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}, getClient)
 }
@@ -803,6 +821,10 @@ Examples:
 		SuccessMessage: func(sub *api.BillingSubscription) string {
 			return fmt.Sprintf("Created billing subscription: %s", billingSubscriptionID(*sub))
 		},
+		ID: func(sub *api.BillingSubscription) string {
+			return billingSubscriptionID(*sub)
+		},
+		NoDetails: "Created billing subscription (the API returned no details; find it with 'airwallex billing subscriptions list')",
 	}, getClient)
 }
 
@@ -847,6 +869,46 @@ Examples:
 	}, getClient)
 }
 
+func newBillingSubscriptionsPauseCmd() *cobra.Command {
+	return NewPayloadCommand(PayloadCommandConfig[*api.BillingSubscription]{
+		Use:   "pause <subscriptionId>",
+		Short: "Pause a billing subscription",
+		Long: `Pause an active billing subscription.
+
+Examples:
+  airwallex billing subscriptions pause sub_123
+  airwallex billing subscriptions pause sub_123 --data '{"resume_at":"2025-03-01T00:00:00Z"}'`,
+		Args:        cobra.ExactArgs(1),
+		ReadPayload: readOptionalJSONPayload,
+		Run: func(ctx context.Context, client *api.Client, args []string, payload map[string]interface{}) (*api.BillingSubscription, error) {
+			return client.PauseBillingSubscription(ctx, NormalizeIDArg(args[0]), payload)
+		},
+		SuccessMessage: func(sub *api.BillingSubscription) string {
+			return fmt.Sprintf("Paused billing subscription: %s (status: %s)", billingSubscriptionID(*sub), sub.Status)
+		},
+	}, getClient)
+}
+
+func newBillingSubscriptionsResumeCmd() *cobra.Command {
+	return NewPayloadCommand(PayloadCommandConfig[*api.BillingSubscription]{
+		Use:   "resume <subscriptionId>",
+		Short: "Resume a paused billing subscription",
+		Long: `Resume a paused billing subscription.
+
+Examples:
+  airwallex billing subscriptions resume sub_123
+  airwallex billing subscriptions resume sub_123 --output json`,
+		Args:        cobra.ExactArgs(1),
+		ReadPayload: readOptionalJSONPayload,
+		Run: func(ctx context.Context, client *api.Client, args []string, payload map[string]interface{}) (*api.BillingSubscription, error) {
+			return client.ResumeBillingSubscription(ctx, NormalizeIDArg(args[0]), payload)
+		},
+		SuccessMessage: func(sub *api.BillingSubscription) string {
+			return fmt.Sprintf("Resumed billing subscription: %s (status: %s)", billingSubscriptionID(*sub), sub.Status)
+		},
+	}, getClient)
+}
+
 func newBillingSubscriptionItemsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "items",
@@ -924,7 +986,7 @@ func newBillingSubscriptionItemsGetCmd() *cobra.Command {
 			if item.Price != nil {
 				rows = append(rows, outfmt.KV{Key: "price_id", Value: item.Price.ID})
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}
 }
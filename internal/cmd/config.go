@@ -0,0 +1,383 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/auth"
+	"github.com/salmonumbrella/airwallex-cli/internal/config"
+	"github.com/salmonumbrella/airwallex-cli/internal/outfmt"
+	"github.com/salmonumbrella/airwallex-cli/internal/secrets"
+	"github.com/salmonumbrella/airwallex-cli/internal/ui"
+)
+
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "config",
+		Aliases: []string{"cfg"},
+		Short:   "Local CLI configuration",
+	}
+	cmd.AddCommand(newConfigAccountsCmd())
+	cmd.AddCommand(newConfigShowCmd())
+	return cmd
+}
+
+func newConfigAccountsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "accounts",
+		Aliases: []string{"account", "acc"},
+		Short:   "Configure stored accounts",
+	}
+	cmd.AddCommand(newConfigAccountsAddCmd())
+	cmd.AddCommand(newAuthListCmd())
+	cmd.AddCommand(newConfigAccountsDefaultCmd())
+	return cmd
+}
+
+// Account environments accepted by --env.
+const (
+	envProduction = "production"
+	envDemo       = "demo"
+)
+
+func newConfigAccountsAddCmd() *cobra.Command {
+	var (
+		name           string
+		clientID       string
+		apiKey         string
+		accountID      string
+		env            string
+		skipValidation bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Store account credentials without the browser setup",
+		Long: `Store account credentials non-interactively (for CI and scripts).
+
+The credentials are checked with a test login before they are saved unless
+--skip-validation is set. Use --env demo for sandbox credentials.
+
+Examples:
+  airwallex config accounts add --name ci --client-id xxx --api-key "$AWX_API_KEY"
+
+  # Sandbox account, multi-account API key, no network check
+  airwallex config accounts add --name sandbox --client-id xxx --api-key yyy \
+    --account-id acct_xxx --env demo --skip-validation`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			u := ui.FromContext(cmd.Context())
+
+			name = strings.TrimSpace(name)
+			if err := auth.ValidateAccountName(name); err != nil {
+				return fmt.Errorf("invalid account name: %w", err)
+			}
+			clientID = strings.TrimSpace(clientID)
+			if err := auth.ValidateClientID(clientID); err != nil {
+				return fmt.Errorf("invalid client ID: %w", err)
+			}
+			apiKey = strings.TrimSpace(apiKey)
+			if err := auth.ValidateAPIKey(apiKey); err != nil {
+				return fmt.Errorf("invalid API key: %w", err)
+			}
+			env = strings.ToLower(strings.TrimSpace(env))
+			if env != envProduction && env != envDemo {
+				return fmt.Errorf("invalid --env %q: must be %s or %s", env, envProduction, envDemo)
+			}
+			if env == envProduction {
+				env = ""
+			}
+
+			store, err := openSecretsStore()
+			if err != nil {
+				return fmt.Errorf("failed to open keyring: %w", err)
+			}
+			if _, err := store.Get(name); err == nil {
+				return fmt.Errorf("account already exists: %s", name)
+			}
+
+			creds := secrets.Credentials{
+				ClientID:  clientID,
+				APIKey:    apiKey,
+				AccountID: strings.TrimSpace(accountID),
+				Env:       env,
+			}
+
+			if !skipValidation {
+				client, err := newConnectedClient(cmd.Context(), creds)
+				if err != nil {
+					return fmt.Errorf("failed to create client: %w", err)
+				}
+				resp, err := client.Get(cmd.Context(), "/api/v1/balances/current")
+				if err != nil {
+					return fmt.Errorf("credential validation failed (use --skip-validation to store anyway): %w", err)
+				}
+				_ = resp.Body.Close()
+				if resp.StatusCode >= 400 {
+					return fmt.Errorf("credential validation failed with status %d (use --skip-validation to store anyway)", resp.StatusCode)
+				}
+			}
+
+			if err := store.Set(name, creds); err != nil {
+				return fmt.Errorf("failed to store credentials: %w", err)
+			}
+
+			u.Success(fmt.Sprintf("Added account: %s", name))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Account name (required)")
+	cmd.Flags().StringVar(&clientID, "client-id", "", "Airwallex Client ID (required)")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "Airwallex API Key (required)")
+	cmd.Flags().StringVar(&accountID, "account-id", "", "Airwallex Account ID for x-login-as (required for multi-account API keys)")
+	cmd.Flags().StringVar(&env, "env", envProduction, "API environment: production or demo")
+	cmd.Flags().BoolVar(&skipValidation, "skip-validation", false, "Store the credentials without a test login")
+	mustMarkRequired(cmd, "name")
+	mustMarkRequired(cmd, "client-id")
+	mustMarkRequired(cmd, "api-key")
+	return cmd
+}
+
+func newConfigAccountsDefaultCmd() *cobra.Command {
+	var clearDefault bool
+
+	cmd := &cobra.Command{
+		Use:   "default [name]",
+		Short: "Show, set, or clear the default account",
+		Long: `Show, set, or clear the default account.
+
+With no arguments, prints the active account name: --account or AWX_ACCOUNT
+if set, then the saved default, then the only configured account.
+
+Examples:
+  # Print the active account (for scripts)
+  airwallex config accounts default
+
+  # Save a default account
+  airwallex config accounts default prod
+
+  # Remove the saved default
+  airwallex config accounts default --clear`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			u := ui.FromContext(cmd.Context())
+
+			if clearDefault {
+				if len(args) > 0 {
+					return fmt.Errorf("--clear does not take an account name")
+				}
+				settings, err := config.LoadSettings()
+				if err != nil {
+					return err
+				}
+				settings.DefaultAccount = ""
+				if err := config.SaveSettings(settings); err != nil {
+					return err
+				}
+				u.Success("Cleared default account")
+				return nil
+			}
+
+			if len(args) == 1 {
+				name := strings.TrimSpace(args[0])
+				store, err := openSecretsStore()
+				if err != nil {
+					return fmt.Errorf("failed to open keyring: %w", err)
+				}
+				if _, err := store.Get(name); err != nil {
+					return fmt.Errorf("account not found: %s", name)
+				}
+				settings, err := config.LoadSettings()
+				if err != nil {
+					return err
+				}
+				settings.DefaultAccount = name
+				if err := config.SaveSettings(settings); err != nil {
+					return err
+				}
+				u.Success(fmt.Sprintf("Default account: %s", name))
+				return nil
+			}
+
+			account, err := requireAccount(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if outfmt.IsJSON(cmd.Context()) {
+				return writeJSONOutput(cmd, map[string]string{"account": account})
+			}
+			_, err = fmt.Fprintln(commandOutputWriter(cmd), account)
+			return err
+		},
+	}
+
+	cmd.Flags().BoolVar(&clearDefault, "clear", false, "Remove the saved default account")
+	return cmd
+}
+
+// Provenance of an effective configuration value.
+const (
+	sourceFlag    = "flag"
+	sourceEnv     = "env"
+	sourceFile    = "file"
+	sourceKeyring = "keyring"
+	sourceDefault = "default"
+)
+
+// rootFlagEnv maps global flags to the environment variables that set their
+// defaults.
+var rootFlagEnv = map[string]string{
+	"account":     "AWX_ACCOUNT",
+	"output":      "AWX_OUTPUT",
+	"color":       "AWX_COLOR",
+	"agent":       "AWX_AGENT",
+	"max-retries": "AWX_MAX_RETRIES",
+	"ca-cert":     "AWX_CA_CERT",
+}
+
+// configValue is one effective setting and where it came from.
+type configValue struct {
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+type effectiveConfig struct {
+	ConfigFile string                 `json:"config_file"`
+	Settings   map[string]configValue `json:"settings"`
+}
+
+// maskSecret hides all but the last four characters of a secret.
+func maskSecret(s string) string {
+	if len(s) <= 8 {
+		return "****"
+	}
+	return "****" + s[len(s)-4:]
+}
+
+// flagSource reports whether a global flag was set on the command line, by
+// its environment variable, or left at its default.
+func flagSource(cmd *cobra.Command, name string) string {
+	if flagOrAliasChanged(cmd, name) {
+		return sourceFlag
+	}
+	if env, ok := rootFlagEnv[name]; ok && os.Getenv(env) != "" {
+		return sourceEnv
+	}
+	return sourceDefault
+}
+
+// buildEffectiveConfig merges global flags, environment variables, the
+// settings file, and stored credentials for the active account.
+func buildEffectiveConfig(cmd *cobra.Command) (*effectiveConfig, error) {
+	path, err := config.SettingsPath()
+	if err != nil {
+		return nil, err
+	}
+	settings, err := config.LoadSettings()
+	if err != nil {
+		return nil, err
+	}
+	eff := &effectiveConfig{ConfigFile: path, Settings: map[string]configValue{}}
+
+	cmd.Root().PersistentFlags().VisitAll(func(f *pflag.Flag) {
+		if f.Hidden || f.Deprecated != "" || f.Name == "help" {
+			return
+		}
+		eff.Settings[f.Name] = configValue{Value: f.Value.String(), Source: flagSource(cmd, f.Name)}
+	})
+
+	// --json, --agent, and --query select JSON without touching --output.
+	if flags, ok := rootFlagsFromContext(cmd.Context()); ok && eff.Settings["output"].Source == sourceDefault && flags.Output != "text" {
+		switch {
+		case flagOrAliasChanged(cmd, "json"):
+			eff.Settings["output"] = configValue{Value: flags.Output, Source: sourceFlag}
+		case flags.Agent:
+			eff.Settings["output"] = configValue{Value: flags.Output, Source: eff.Settings["agent"].Source}
+		}
+	}
+
+	// AWX_MAX_RETRIES is applied after flag parsing, so the flag still holds 0.
+	if flags, ok := rootFlagsFromContext(cmd.Context()); ok && eff.Settings["max-retries"].Source == sourceEnv {
+		eff.Settings["max-retries"] = configValue{Value: strconv.Itoa(flags.MaxRetries), Source: sourceEnv}
+	}
+
+	account := eff.Settings["account"]
+	if account.Value == "" && settings.DefaultAccount != "" {
+		account = configValue{Value: settings.DefaultAccount, Source: sourceFile}
+	}
+	store, err := openSecretsStore()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open keyring: %w", err)
+	}
+	if account.Value == "" {
+		// The only configured account is selected automatically.
+		if accounts, err := store.List(); err == nil && len(accounts) == 1 {
+			account = configValue{Value: accounts[0].Name, Source: sourceDefault}
+		}
+	}
+	eff.Settings["account"] = account
+
+	if account.Value != "" {
+		if creds, err := store.Get(account.Value); err == nil {
+			env := creds.Env
+			if env == "" {
+				env = envProduction
+			}
+			eff.Settings["client_id"] = configValue{Value: creds.ClientID, Source: sourceKeyring}
+			eff.Settings["api_key"] = configValue{Value: maskSecret(creds.APIKey), Source: sourceKeyring}
+			eff.Settings["account_id"] = configValue{Value: creds.AccountID, Source: sourceKeyring}
+			eff.Settings["env"] = configValue{Value: env, Source: sourceKeyring}
+		}
+	}
+	return eff, nil
+}
+
+func newConfigShowCmd() *cobra.Command {
+	var effective bool
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the settings file or the effective configuration",
+		Long: `Show the settings file, or with --effective the merged configuration
+after flags, environment variables, the settings file, and defaults.
+
+Each effective value reports its source: flag, env, file, keyring (stored
+credentials for the active account), or default. The API key is masked.
+
+Examples:
+  airwallex config show
+  airwallex config show --effective
+  airwallex --output yaml config show --effective`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !effective {
+				path, err := config.SettingsPath()
+				if err != nil {
+					return err
+				}
+				settings, err := config.LoadSettings()
+				if err != nil {
+					return err
+				}
+				return writeJSONOutput(cmd, map[string]interface{}{
+					"config_file": path,
+					"settings":    settings,
+				})
+			}
+			eff, err := buildEffectiveConfig(cmd)
+			if err != nil {
+				return err
+			}
+			return writeJSONOutput(cmd, eff)
+		},
+	}
+
+	cmd.Flags().BoolVar(&effective, "effective", false, "Show merged settings with the source of each value")
+	return cmd
+}
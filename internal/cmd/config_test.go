@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/config"
+	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
+	"github.com/salmonumbrella/airwallex-cli/internal/secrets"
+)
+
+func runConfigCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+	root := NewRootCmd()
+	root.SetArgs(append([]string{"config", "accounts", "default"}, args...))
+	err := root.ExecuteContext(ctx)
+	return out.String(), err
+}
+
+func TestConfigAccountsDefault(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("AWX_ACCOUNT", "")
+
+	if _, err := runConfigCmd(t, "prod"); err != nil {
+		t.Fatalf("set default failed: %v", err)
+	}
+
+	out, err := runConfigCmd(t)
+	if err != nil {
+		t.Fatalf("print default failed: %v", err)
+	}
+	if strings.TrimSpace(out) != "prod" {
+		t.Errorf("printed %q, want %q", out, "prod")
+	}
+
+	// An explicit --account still wins over the saved default.
+	out, err = runConfigCmd(t, "--account", "staging")
+	if err != nil {
+		t.Fatalf("print with --account failed: %v", err)
+	}
+	if strings.TrimSpace(out) != "staging" {
+		t.Errorf("printed %q, want %q", out, "staging")
+	}
+
+	if _, err := runConfigCmd(t, "--clear"); err != nil {
+		t.Fatalf("clear failed: %v", err)
+	}
+	settings, err := config.LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings() error: %v", err)
+	}
+	if settings.DefaultAccount != "" {
+		t.Errorf("DefaultAccount = %q after --clear, want empty", settings.DefaultAccount)
+	}
+
+	if _, err := runConfigCmd(t, "--clear", "prod"); err == nil {
+		t.Error("expected error combining --clear with a name")
+	}
+}
+
+// memoryStore is an in-memory secrets.Store for commands that write credentials.
+type memoryStore map[string]secrets.Credentials
+
+func (m memoryStore) Keys() ([]string, error) {
+	keys := make([]string, 0, len(m))
+	for name := range m {
+		keys = append(keys, "account:"+name)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (m memoryStore) Set(name string, creds secrets.Credentials) error {
+	creds.Name = name
+	m[name] = creds
+	return nil
+}
+
+func (m memoryStore) Get(name string) (secrets.Credentials, error) {
+	creds, ok := m[name]
+	if !ok {
+		return secrets.Credentials{}, fmt.Errorf("not found: %s", name)
+	}
+	return creds, nil
+}
+
+func (m memoryStore) Delete(name string) error {
+	delete(m, name)
+	return nil
+}
+
+func (m memoryStore) List() ([]secrets.Credentials, error) {
+	keys, _ := m.Keys()
+	out := make([]secrets.Credentials, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, m[strings.TrimPrefix(k, "account:")])
+	}
+	return out, nil
+}
+
+func TestConfigAccountsAdd(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	store := memoryStore{}
+	original := openSecretsStore
+	openSecretsStore = func() (secrets.Store, error) { return store, nil }
+	defer func() { openSecretsStore = original }()
+
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(io.Discard)
+		root.SetErr(io.Discard)
+		root.SetArgs(append([]string{"config", "accounts"}, args...))
+		err := root.ExecuteContext(ctx)
+		return out.String(), err
+	}
+
+	testMockServer.HandleJSON("GET", "/api/v1/balances/current", http.StatusOK, []any{})
+	defer testMockServer.HandleError("GET", "/api/v1/balances/current", http.StatusNotFound, "endpoint not found")
+
+	if _, err := run("add", "--name", "ci", "--client-id", "cid", "--api-key", "key", "--account-id", "acct_1"); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+	if got := store["ci"]; got.ClientID != "cid" || got.APIKey != "key" || got.AccountID != "acct_1" || got.Env != "" {
+		t.Errorf("stored credentials = %+v", got)
+	}
+	if _, err := run("add", "--name", "ci", "--client-id", "cid", "--api-key", "key"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected duplicate name error, got %v", err)
+	}
+
+	// Failed validation stores nothing unless --skip-validation is set.
+	testMockServer.HandleError("GET", "/api/v1/balances/current", http.StatusUnauthorized, "unauthorized")
+	if _, err := run("add", "--name", "sandbox", "--client-id", "cid2", "--api-key", "key2", "--env", "demo"); err == nil {
+		t.Fatal("expected validation failure")
+	}
+	if _, ok := store["sandbox"]; ok {
+		t.Fatal("account stored despite failed validation")
+	}
+	if _, err := run("add", "--name", "sandbox", "--client-id", "cid2", "--api-key", "key2", "--env", "demo", "--skip-validation"); err != nil {
+		t.Fatalf("add --skip-validation failed: %v", err)
+	}
+	if got := store["sandbox"].Env; got != "demo" {
+		t.Errorf("Env = %q, want demo", got)
+	}
+
+	out, err := run("list", "--output", "json")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	var listed struct {
+		Accounts []secrets.Credentials `json:"accounts"`
+	}
+	if err := json.Unmarshal([]byte(out), &listed); err != nil {
+		t.Fatalf("list output is not JSON: %v\n%s", err, out)
+	}
+	var names []string
+	for _, c := range listed.Accounts {
+		names = append(names, c.Name)
+	}
+	if strings.Join(names, ",") != "ci,sandbox" {
+		t.Errorf("listed accounts = %v, want [ci sandbox]", names)
+	}
+	if strings.Contains(out, "key2") {
+		t.Error("list output leaked an API key")
+	}
+}
+
+func TestConfigShowEffective(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("AWX_ACCOUNT", "")
+	t.Setenv("AWX_COLOR", "never")
+	t.Setenv("AWX_OUTPUT", "")
+	t.Setenv("AWX_MAX_RETRIES", "2")
+	t.Setenv("AWX_CA_CERT", "/etc/corp/root.pem")
+
+	if err := config.SaveSettings(&config.Settings{DefaultAccount: "prod"}); err != nil {
+		t.Fatalf("SaveSettings() error: %v", err)
+	}
+
+	run := func(t *testing.T, args ...string) map[string]struct{ Value, Source string } {
+		t.Helper()
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetArgs(append([]string{"config", "show", "--effective"}, args...))
+		if err := root.ExecuteContext(ctx); err != nil {
+			t.Fatalf("config show --effective failed: %v", err)
+		}
+		var got struct {
+			Settings map[string]struct{ Value, Source string } `json:"settings"`
+		}
+		if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+			t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+		}
+		return got.Settings
+	}
+
+	settings := run(t, "--output-limit", "5")
+	for name, want := range map[string]struct{ Value, Source string }{
+		"output-limit": {"5", "flag"},
+		"color":        {"never", "env"},
+		"sort-by":      {"", "default"},
+		"max-retries":  {"2", "env"},
+		"ca-cert":      {"[/etc/corp/root.pem]", "env"},
+		"account":      {"prod", "file"},
+		"client_id":    {"test-client-id", "keyring"},
+		"api_key":      {"****-key", "keyring"},
+	} {
+		if got := settings[name]; got != want {
+			t.Errorf("%s = %+v, want %+v", name, got, want)
+		}
+	}
+
+	// A flag overrides the saved default and the environment.
+	settings = run(t, "--account", "staging", "--color", "always")
+	if got := settings["account"]; got.Value != "staging" || got.Source != "flag" {
+		t.Errorf("account = %+v, want staging from flag", got)
+	}
+	if got := settings["color"]; got.Value != "always" || got.Source != "flag" {
+		t.Errorf("color = %+v, want always from flag", got)
+	}
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import "strings"
+
+// countryDefaultCurrency maps bank countries with one obvious account
+// currency to that currency. Countries where accounts are commonly held in
+// several currencies (US, GB, SG, HK, CN, CA, AE, ...) are deliberately
+// absent so --account-currency stays required for them.
+var countryDefaultCurrency = map[string]string{
+	// Asia-Pacific
+	"AU": "AUD",
+	"NZ": "NZD",
+	"JP": "JPY",
+	"KR": "KRW",
+	"IN": "INR",
+	"ID": "IDR",
+	"MY": "MYR",
+	"PH": "PHP",
+	"TH": "THB",
+	"VN": "VND",
+	// Americas
+	"MX": "MXN",
+	"BR": "BRL",
+	// Europe (non-euro)
+	"SE": "SEK",
+	"NO": "NOK",
+	"DK": "DKK",
+	"PL": "PLN",
+	"CZ": "CZK",
+	"HU": "HUF",
+	"CH": "CHF",
+	// Eurozone
+	"AT": "EUR",
+	"BE": "EUR",
+	"DE": "EUR",
+	"ES": "EUR",
+	"FI": "EUR",
+	"FR": "EUR",
+	"IE": "EUR",
+	"IT": "EUR",
+	"NL": "EUR",
+	"PT": "EUR",
+	// Other
+	"IL": "ILS",
+	"TR": "TRY",
+	"ZA": "ZAR",
+}
+
+// defaultCurrencyForCountry returns the account currency to assume when
+// --account-currency is omitted, if the bank country has a single obvious one.
+func defaultCurrencyForCountry(country string) (string, bool) {
+	currency, ok := countryDefaultCurrency[strings.ToUpper(strings.TrimSpace(country))]
+	return currency, ok
+}
@@ -101,7 +101,7 @@ func newDepositsGetCmd() *cobra.Command {
 			if d.SettledAt != "" {
 				rows = append(rows, outfmt.KV{Key: "settled_at", Value: d.SettledAt})
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}, getClient)
 }
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/config"
+	"github.com/salmonumbrella/airwallex-cli/internal/outfmt"
+	"github.com/salmonumbrella/airwallex-cli/internal/secrets"
+)
+
+// Diagnostic check statuses.
+const (
+	checkOK   = "ok"
+	checkFail = "fail"
+	checkSkip = "skip"
+)
+
+// diagnosticCheck is one doctor check result.
+type diagnosticCheck struct {
+	Check     string `json:"check"`
+	Status    string `json:"status"`
+	Detail    string `json:"detail"`
+	LatencyMS int64  `json:"latency_ms"`
+}
+
+// diagnosticReport is the doctor output; Healthy is false if any check failed.
+type diagnosticReport struct {
+	Healthy bool              `json:"healthy"`
+	Checks  []diagnosticCheck `json:"checks"`
+}
+
+func newDoctorCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "doctor",
+		Aliases: []string{"health"},
+		Short:   "Check configuration, credentials, and API connectivity",
+		Long: `Run diagnostics on the local configuration, the selected account's
+credentials, and connectivity to the Airwallex API.
+
+Checks that depend on a failed check are reported as "skip". The command exits
+non-zero when any check fails, after printing the report.
+
+Examples:
+  airwallex doctor
+  airwallex health --output json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			var checks []diagnosticCheck
+			run := func(name string, fn func() (string, error)) bool {
+				start := time.Now()
+				detail, err := fn()
+				c := diagnosticCheck{Check: name, Status: checkOK, Detail: detail, LatencyMS: time.Since(start).Milliseconds()}
+				if err != nil {
+					c.Status = checkFail
+					c.Detail = err.Error()
+				}
+				checks = append(checks, c)
+				return err == nil
+			}
+			skip := func(name, reason string) {
+				checks = append(checks, diagnosticCheck{Check: name, Status: checkSkip, Detail: "skipped: " + reason + " failed"})
+			}
+
+			run("config", func() (string, error) {
+				if _, err := config.LoadSettings(); err != nil {
+					return "", err
+				}
+				return "settings loaded", nil
+			})
+
+			var store secrets.Store
+			keyringOK := run("keyring", func() (string, error) {
+				var err error
+				store, err = openSecretsStore()
+				if err != nil {
+					return "", err
+				}
+				return "keyring available", nil
+			})
+
+			var account string
+			accountOK := run("account", func() (string, error) {
+				var err error
+				account, err = requireAccount(ctx)
+				if err != nil {
+					return "", err
+				}
+				return account, nil
+			})
+
+			credsOK := false
+			switch {
+			case !keyringOK:
+				skip("credentials", "keyring")
+			case !accountOK:
+				skip("credentials", "account")
+			default:
+				credsOK = run("credentials", func() (string, error) {
+					if _, err := store.Get(account); err != nil {
+						return "", fmt.Errorf("account not found: %s", account)
+					}
+					return "credentials found for " + account, nil
+				})
+			}
+
+			if credsOK {
+				run("api", func() (string, error) {
+					client, err := getClient(ctx)
+					if err != nil {
+						return "", err
+					}
+					resp, err := client.Get(ctx, "/api/v1/balances/current")
+					if err != nil {
+						return "", err
+					}
+					_ = resp.Body.Close()
+					if resp.StatusCode >= 400 {
+						return "", fmt.Errorf("GET /api/v1/balances/current returned HTTP %d", resp.StatusCode)
+					}
+					return "authenticated and reached " + client.BaseURL(), nil
+				})
+			} else {
+				skip("api", "credentials")
+			}
+
+			report := diagnosticReport{Healthy: true, Checks: checks}
+			failed := 0
+			for _, c := range checks {
+				if c.Status == checkFail {
+					report.Healthy = false
+					failed++
+				}
+			}
+
+			if outfmt.IsJSON(ctx) {
+				if err := writeJSONOutput(cmd, report); err != nil {
+					return err
+				}
+			} else {
+				f := outfmt.FromContext(ctx)
+				f.StartTable([]string{"CHECK", "STATUS", "LATENCY_MS", "DETAIL"})
+				for _, c := range checks {
+					f.Row(c.Check, c.Status, strconv.FormatInt(c.LatencyMS, 10), c.Detail)
+				}
+				if err := f.EndTable(); err != nil {
+					return err
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+			}
+			return nil
+		},
+	}
+}
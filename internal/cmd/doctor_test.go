@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
+)
+
+func TestDoctor_JSONReport(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	run := func() (diagnosticReport, error) {
+		t.Helper()
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(io.Discard)
+		root.SetErr(io.Discard)
+		root.SetArgs([]string{"health", "--output", "json"})
+		err := root.ExecuteContext(ctx)
+
+		var raw map[string]json.RawMessage
+		if jsonErr := json.Unmarshal(out.Bytes(), &raw); jsonErr != nil {
+			t.Fatalf("output is not JSON: %v\n%s", jsonErr, out.String())
+		}
+		for _, key := range []string{"healthy", "checks"} {
+			if _, ok := raw[key]; !ok {
+				t.Errorf("report missing %q: %s", key, out.String())
+			}
+		}
+		var checks []map[string]json.RawMessage
+		if jsonErr := json.Unmarshal(raw["checks"], &checks); jsonErr != nil {
+			t.Fatalf("checks is not an array: %v", jsonErr)
+		}
+		for _, c := range checks {
+			for _, key := range []string{"check", "status", "detail", "latency_ms"} {
+				if _, ok := c[key]; !ok {
+					t.Errorf("check missing %q: %v", key, c)
+				}
+			}
+		}
+
+		var report diagnosticReport
+		_ = json.Unmarshal(out.Bytes(), &report)
+		return report, err
+	}
+	statusOf := func(r diagnosticReport, name string) string {
+		for _, c := range r.Checks {
+			if c.Check == name {
+				return c.Status
+			}
+		}
+		return ""
+	}
+
+	testMockServer.HandleJSON("GET", "/api/v1/balances/current", http.StatusOK, []any{})
+	defer testMockServer.HandleError("GET", "/api/v1/balances/current", http.StatusNotFound, "endpoint not found")
+
+	report, err := run()
+	if err != nil {
+		t.Fatalf("doctor failed on a healthy setup: %v", err)
+	}
+	if !report.Healthy || len(report.Checks) != 5 {
+		t.Errorf("healthy=%v checks=%d, want healthy with 5 checks", report.Healthy, len(report.Checks))
+	}
+	for _, c := range report.Checks {
+		if c.Status != checkOK {
+			t.Errorf("check %s = %s (%s), want ok", c.Check, c.Status, c.Detail)
+		}
+	}
+
+	testMockServer.HandleError("GET", "/api/v1/balances/current", http.StatusUnauthorized, "unauthorized")
+	report, err = run()
+	if err == nil {
+		t.Error("expected non-zero exit when a check fails")
+	}
+	if report.Healthy {
+		t.Error("healthy = true with a failing api check")
+	}
+	if got := statusOf(report, "api"); got != checkFail {
+		t.Errorf("api check status = %q, want fail", got)
+	}
+	if got := statusOf(report, "credentials"); got != checkOK {
+		t.Errorf("credentials check status = %q, want ok", got)
+	}
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/api"
+	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
+	"github.com/salmonumbrella/airwallex-cli/internal/outfmt"
+)
+
+// writeDryRunRequest prints a request held back by --dry-run: the request
+// object in structured output, otherwise the method, URL, and indented body.
+func writeDryRunRequest(ctx context.Context, r api.DryRunRequest) error {
+	out := iocontext.GetIO(ctx).Out
+	if outfmt.IsJSON(ctx) {
+		return writeJSONOutputTo(ctx, out, r)
+	}
+
+	_, _ = fmt.Fprintf(out, "[DRY-RUN] %s %s\n", r.Method, r.URL)
+	if len(r.Body) > 0 {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, r.Body, "", "  "); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, buf.String())
+	}
+	_, _ = fmt.Fprintln(iocontext.GetIO(ctx).ErrOut, "No changes made (dry-run mode)")
+	return nil
+}
+
+// addDryRunSupport makes every command under cmd treat api.ErrDryRun as
+// success, so a mutating command stops cleanly once --dry-run has printed
+// the request it would send.
+func addDryRunSupport(cmd *cobra.Command) {
+	if run := cmd.RunE; run != nil {
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			if err := run(cmd, args); err != nil && !errors.Is(err, api.ErrDryRun) {
+				return err
+			}
+			return nil
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		addDryRunSupport(sub)
+	}
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/api"
+	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
+	"github.com/salmonumbrella/airwallex-cli/internal/secrets"
+)
+
+func TestDryRun_MakesNoHTTPCalls(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		http.Error(w, "unexpected request", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	original := newClientForCreds
+	newClientForCreds = func(creds secrets.Credentials) (*api.Client, error) {
+		return api.NewClientWithBaseURL(srv.URL, creds.ClientID, creds.APIKey)
+	}
+	defer func() { newClientForCreds = original }()
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantLine string
+		wantBody map[string]interface{}
+	}{
+		{
+			name: "transfers create",
+			args: []string{
+				"transfers", "create",
+				"--beneficiary-id", "ben_123",
+				"--transfer-amount", "100",
+				"--transfer-currency", "USD",
+				"--source-currency", "USD",
+				"--reference", "Invoice 123",
+				"--reason", "payment_to_supplier",
+			},
+			wantLine: "[DRY-RUN] POST " + srv.URL + "/api/v1/transfers/create",
+			wantBody: map[string]interface{}{"beneficiary_id": "ben_123", "transfer_amount": float64(100)},
+		},
+		{
+			name:     "beneficiaries delete",
+			args:     []string{"beneficiaries", "delete", "ben_123"},
+			wantLine: "[DRY-RUN] POST " + srv.URL + "/api/v1/beneficiaries/ben_123/delete",
+		},
+		{
+			name:     "subscriptions cancel",
+			args:     []string{"billing", "subscriptions", "cancel", "sub_123", "--data", `{"cancel_at_period_end":true}`},
+			wantLine: "[DRY-RUN] POST " + srv.URL + "/api/v1/subscriptions/sub_123/cancel",
+			wantBody: map[string]interface{}{"cancel_at_period_end": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&calls, 0)
+			var out, errOut bytes.Buffer
+			ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: &errOut, In: strings.NewReader("")})
+			root := NewRootCmd()
+			root.SetArgs(append(tt.args, "--dry-run"))
+			if err := root.ExecuteContext(ctx); err != nil {
+				t.Fatalf("dry run failed: %v", err)
+			}
+			if n := atomic.LoadInt32(&calls); n != 0 {
+				t.Errorf("--dry-run made %d HTTP calls, want 0", n)
+			}
+
+			line, body, _ := strings.Cut(out.String(), "\n")
+			if line != tt.wantLine {
+				t.Errorf("first line = %q, want %q", line, tt.wantLine)
+			}
+			if tt.wantBody != nil {
+				var got map[string]interface{}
+				if err := json.Unmarshal([]byte(body), &got); err != nil {
+					t.Fatalf("invalid body %q: %v", body, err)
+				}
+				for k, want := range tt.wantBody {
+					if got[k] != want {
+						t.Errorf("body[%q] = %v, want %v", k, got[k], want)
+					}
+				}
+			}
+			if !strings.Contains(errOut.String(), "No changes made (dry-run mode)") {
+				t.Errorf("stderr = %q, want dry-run notice", errOut.String())
+			}
+		})
+	}
+}
+
+func TestDryRun_BeneficiariesDeleteJSONWritesOneDocument(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	var out bytes.Buffer
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+	root := NewRootCmd()
+	root.SetArgs([]string{"beneficiaries", "delete", "ben_123", "--dry-run", "--output", "json"})
+	if err := root.ExecuteContext(ctx); err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+
+	dec := json.NewDecoder(&out)
+	var req api.DryRunRequest
+	if err := dec.Decode(&req); err != nil {
+		t.Fatalf("output is not JSON: %v", err)
+	}
+	if req.Method != http.MethodPost || !strings.HasSuffix(req.URL, "/api/v1/beneficiaries/ben_123/delete") {
+		t.Errorf("previewed request = %+v", req)
+	}
+	if dec.More() {
+		t.Errorf("expected a single JSON document, got trailing output: %s", out.String())
+	}
+}
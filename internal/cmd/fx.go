@@ -1,6 +1,15 @@
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/outfmt"
+)
 
 func newFXCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -11,5 +20,48 @@ func newFXCmd() *cobra.Command {
 	cmd.AddCommand(newFXRatesCmd())
 	cmd.AddCommand(newFXQuotesCmd())
 	cmd.AddCommand(newFXConversionsCmd())
+	cmd.AddCommand(newFXConvertCmd())
+	return cmd
+}
+
+// newFXConvertCmd exposes "fx conversions create" as "fx convert", the
+// shorter name treasury users reach for.
+func newFXConvertCmd() *cobra.Command {
+	cmd := newFXConversionsCreateCmd()
+	cmd.Use = "convert"
+	cmd.Aliases = nil
+	cmd.Long = strings.ReplaceAll(cmd.Long, "airwallex fx conversions create", "airwallex fx convert")
 	return cmd
 }
+
+// maxRatePrecision caps --rate-precision; API rates carry fewer decimals.
+const maxRatePrecision = 12
+
+// ratePrecisionValue is a --rate-precision flag value restricted to
+// 0..maxRatePrecision so bad input fails at parse time.
+type ratePrecisionValue int
+
+func (p *ratePrecisionValue) String() string { return strconv.Itoa(int(*p)) }
+
+func (p *ratePrecisionValue) Set(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > maxRatePrecision {
+		return fmt.Errorf("must be an integer between 0 and %d", maxRatePrecision)
+	}
+	*p = ratePrecisionValue(n)
+	return nil
+}
+
+func (p *ratePrecisionValue) Type() string { return "int" }
+
+// format renders an exchange rate at the configured precision.
+func (p *ratePrecisionValue) format(n json.Number) string {
+	return outfmt.FormatRatePrecision(n, int(*p))
+}
+
+// addRatePrecisionFlag registers --rate-precision, which rounds exchange rates
+// in text output. JSON output always keeps the API's full precision.
+func addRatePrecisionFlag(cmd *cobra.Command, p *ratePrecisionValue) {
+	*p = ratePrecisionValue(outfmt.DefaultRatePrecision)
+	cmd.Flags().Var(p, "rate-precision", "Decimal places for exchange rates in text output (JSON keeps full precision)")
+}
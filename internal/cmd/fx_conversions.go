@@ -25,6 +25,7 @@ func newFXConversionsCmd() *cobra.Command {
 }
 
 func newFXConversionsListCmd() *cobra.Command {
+	var ratePrecision ratePrecisionValue
 	var status, fromDate, toDate string
 	cmd := NewListCommand(ListConfig[api.Conversion]{
 		Use:          "list",
@@ -37,7 +38,7 @@ func newFXConversionsListCmd() *cobra.Command {
 				c.ID,
 				outfmt.FormatMoney(c.SellAmount) + " " + c.SellCurrency,
 				outfmt.FormatMoney(c.BuyAmount) + " " + c.BuyCurrency,
-				outfmt.FormatRate(c.Rate),
+				ratePrecision.format(c.Rate),
 				c.Status,
 			}
 		},
@@ -64,11 +65,13 @@ func newFXConversionsListCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&fromDate, "from", "f", "", "From date (YYYY-MM-DD)")
 	cmd.Flags().StringVar(&toDate, "to", "", "To date (YYYY-MM-DD)")
 	flagAlias(cmd.Flags(), "from", "fr")
+	addRatePrecisionFlag(cmd, &ratePrecision)
 	return cmd
 }
 
 func newFXConversionsGetCmd() *cobra.Command {
-	return NewGetCommand(GetConfig[*api.Conversion]{
+	var ratePrecision ratePrecisionValue
+	cmd := NewGetCommand(GetConfig[*api.Conversion]{
 		Use:     "get <conversionId>",
 		Aliases: []string{"g"},
 		Short:   "Get conversion details",
@@ -82,22 +85,29 @@ func newFXConversionsGetCmd() *cobra.Command {
 				{Key: "buy_currency", Value: conv.BuyCurrency},
 				{Key: "sell_amount", Value: outfmt.FormatMoney(conv.SellAmount)},
 				{Key: "buy_amount", Value: outfmt.FormatMoney(conv.BuyAmount)},
-				{Key: "rate", Value: outfmt.FormatRate(conv.Rate)},
+				{Key: "rate", Value: ratePrecision.format(conv.Rate)},
 				{Key: "status", Value: conv.Status},
 				{Key: "created_at", Value: conv.CreatedAt},
 			}
+			if conv.ConversionDate != "" {
+				rows = append(rows, outfmt.KV{Key: "conversion_date", Value: conv.ConversionDate})
+			}
 			if conv.QuoteID != "" {
 				rows = append(rows, outfmt.KV{Key: "quote_id", Value: conv.QuoteID})
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}, getClient)
+	addRatePrecisionFlag(cmd, &ratePrecision)
+	return cmd
 }
 
 func newFXConversionsCreateCmd() *cobra.Command {
+	var ratePrecision ratePrecisionValue
 	var sellCurrency, buyCurrency string
 	var sellAmount, buyAmount float64
 	var quoteID string
+	var conversionDate string
 
 	cmd := &cobra.Command{
 		Use:     "create",
@@ -109,6 +119,9 @@ Examples:
   # Convert at market rate
   airwallex fx conversions create --sell-currency USD --buy-currency EUR --sell-amount 10000
 
+  # Convert at market rate, settling on a later date
+  airwallex fx conversions create --sell-currency USD --buy-currency EUR --sell-amount 10000 --conversion-date 2025-06-30
+
   # Convert using a locked quote
   airwallex fx conversions create --quote-id qt_xxx`,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -122,8 +135,15 @@ Examples:
 				"request_id": uuid.New().String(),
 			}
 
+			if err := validateDate(conversionDate); err != nil {
+				return fmt.Errorf("--conversion-date: %w", err)
+			}
+
 			if quoteID != "" {
 				// Using a quote - just need the quote ID
+				if conversionDate != "" {
+					return fmt.Errorf("--conversion-date cannot be used with --quote-id (the quote fixes the date)")
+				}
 				req["quote_id"] = quoteID
 			} else {
 				// Market rate conversion - validate currencies
@@ -163,6 +183,9 @@ Examples:
 				if buyAmount > 0 {
 					req["buy_amount"] = buyAmount
 				}
+				if conversionDate != "" {
+					req["conversion_date"] = conversionDate
+				}
 			}
 
 			conv, err := client.CreateConversion(cmd.Context(), req)
@@ -179,14 +202,18 @@ Examples:
 				{Key: "conversion_id", Value: conv.ID},
 				{Key: "sold", Value: outfmt.FormatMoney(conv.SellAmount) + " " + conv.SellCurrency},
 				{Key: "bought", Value: outfmt.FormatMoney(conv.BuyAmount) + " " + conv.BuyCurrency},
-				{Key: "rate", Value: outfmt.FormatRate(conv.Rate)},
+				{Key: "rate", Value: ratePrecision.format(conv.Rate)},
 				{Key: "status", Value: conv.Status},
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			if conv.ConversionDate != "" {
+				rows = append(rows, outfmt.KV{Key: "conversion_date", Value: conv.ConversionDate})
+			}
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}
 
 	cmd.Flags().StringVar(&quoteID, "quote-id", "", "Use a locked quote")
+	cmd.Flags().StringVar(&conversionDate, "conversion-date", "", "Settlement date for a market-rate conversion (YYYY-MM-DD; default: earliest available)")
 	cmd.Flags().StringVar(&sellCurrency, "sell-currency", "", "Currency to sell")
 	cmd.Flags().StringVar(&buyCurrency, "buy-currency", "", "Currency to buy")
 	cmd.Flags().Float64Var(&sellAmount, "sell-amount", 0, "Amount to sell")
@@ -196,5 +223,6 @@ Examples:
 	flagAlias(cmd.Flags(), "sell-amount", "sa")
 	flagAlias(cmd.Flags(), "buy-amount", "ba")
 	flagAlias(cmd.Flags(), "quote-id", "qid")
+	addRatePrecisionFlag(cmd, &ratePrecision)
 	return cmd
 }
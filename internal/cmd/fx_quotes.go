@@ -24,6 +24,7 @@ func newFXQuotesCmd() *cobra.Command {
 }
 
 func newFXQuotesCreateCmd() *cobra.Command {
+	var ratePrecision ratePrecisionValue
 	var sellCurrency, buyCurrency string
 	var sellAmount, buyAmount float64
 	var validity string
@@ -135,10 +136,10 @@ Validity periods: 1m, 5m, 15m, 30m, 1h, 2h, 4h, 12h, 24h`,
 				{Key: "buy_currency", Value: quote.BuyCurrency},
 				{Key: "sell_amount", Value: outfmt.FormatMoney(quote.SellAmount)},
 				{Key: "buy_amount", Value: outfmt.FormatMoney(quote.BuyAmount)},
-				{Key: "rate", Value: outfmt.FormatRate(quote.Rate)},
+				{Key: "rate", Value: ratePrecision.format(quote.Rate)},
 				{Key: "expires", Value: quote.RateExpiry},
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}
 
@@ -152,11 +153,13 @@ Validity periods: 1m, 5m, 15m, 30m, 1h, 2h, 4h, 12h, 24h`,
 	cmd.Flags().Float64Var(&sellAmount, "sell-amount", 0, "Amount to sell")
 	cmd.Flags().Float64Var(&buyAmount, "buy-amount", 0, "Amount to buy")
 	cmd.Flags().StringVar(&validity, "validity", "1h", "Quote validity period (1m, 5m, 1h, 24h)")
+	addRatePrecisionFlag(cmd, &ratePrecision)
 	return cmd
 }
 
 func newFXQuotesGetCmd() *cobra.Command {
-	return NewGetCommand(GetConfig[*api.Quote]{
+	var ratePrecision ratePrecisionValue
+	cmd := NewGetCommand(GetConfig[*api.Quote]{
 		Use:     "get <quoteId>",
 		Aliases: []string{"g"},
 		Short:   "Get quote details",
@@ -170,11 +173,13 @@ func newFXQuotesGetCmd() *cobra.Command {
 				{Key: "buy_currency", Value: quote.BuyCurrency},
 				{Key: "sell_amount", Value: outfmt.FormatMoney(quote.SellAmount)},
 				{Key: "buy_amount", Value: outfmt.FormatMoney(quote.BuyAmount)},
-				{Key: "rate", Value: outfmt.FormatRate(quote.Rate)},
+				{Key: "rate", Value: ratePrecision.format(quote.Rate)},
 				{Key: "status", Value: quote.Status},
 				{Key: "expires", Value: quote.RateExpiry},
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}, getClient)
+	addRatePrecisionFlag(cmd, &ratePrecision)
+	return cmd
 }
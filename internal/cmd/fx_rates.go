@@ -1,15 +1,20 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"math/big"
 
 	"github.com/spf13/cobra"
 
+	"github.com/salmonumbrella/airwallex-cli/internal/api"
 	"github.com/salmonumbrella/airwallex-cli/internal/outfmt"
 )
 
 func newFXRatesCmd() *cobra.Command {
+	var ratePrecision ratePrecisionValue
 	var sellCurrency, buyCurrency string
+	var amount amountValue
 
 	cmd := &cobra.Command{
 		Use:     "rates",
@@ -17,11 +22,15 @@ func newFXRatesCmd() *cobra.Command {
 		Short:   "Get current exchange rates",
 		Long: `Get current exchange rate between a currency pair.
 
-Both --sell and --buy currencies are required.
+Both --sell and --buy currencies are required. With --amount, the rate is
+quoted for selling that amount and the amount bought is shown too. When the
+API does not return it, the table shows an estimate from the rate as
+"~AMOUNT"; JSON output carries only what the API returned.
 
 Examples:
   airwallex fx rates --sell USD --buy EUR
-  airwallex fx rates --sell CAD --buy USD`,
+  airwallex fx rates --sell CAD --buy USD
+  airwallex fx rates --sell USD --buy JPY --amount 1000`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Both currencies are required
 			if sellCurrency == "" || buyCurrency == "" {
@@ -40,7 +49,7 @@ Examples:
 				return err
 			}
 
-			result, err := client.GetRates(cmd.Context(), sellCurrency, buyCurrency)
+			result, err := client.GetFXRate(cmd.Context(), sellCurrency, buyCurrency, json.Number(amount))
 			if err != nil {
 				return err
 			}
@@ -56,9 +65,19 @@ Examples:
 				return nil
 			}
 
+			if amount.IsSet() {
+				f.StartTable([]string{"SELL", "BUY", "RATE", "TYPE", "SELL_AMOUNT", "BUY_AMOUNT"})
+				colTypes := []outfmt.ColumnType{outfmt.ColumnCurrency, outfmt.ColumnCurrency, outfmt.ColumnPlain, outfmt.ColumnPlain, outfmt.ColumnAmount, outfmt.ColumnAmount}
+				for _, r := range result.Rates {
+					f.ColorRow(colTypes, r.SellCurrency, r.BuyCurrency, ratePrecision.format(r.Rate), r.RateType,
+						rateSellAmount(r, json.Number(amount)), rateBuyAmount(r, json.Number(amount)))
+				}
+				return f.EndTable()
+			}
+
 			f.StartTable([]string{"SELL", "BUY", "RATE", "TYPE"})
 			for _, r := range result.Rates {
-				f.Row(r.SellCurrency, r.BuyCurrency, outfmt.FormatRate(r.Rate), r.RateType)
+				f.Row(r.SellCurrency, r.BuyCurrency, ratePrecision.format(r.Rate), r.RateType)
 			}
 			return f.EndTable()
 		},
@@ -66,5 +85,37 @@ Examples:
 
 	cmd.Flags().StringVar(&sellCurrency, "sell", "", "Sell currency (e.g., USD)")
 	cmd.Flags().StringVar(&buyCurrency, "buy", "", "Buy currency (e.g., EUR)")
+	cmd.Flags().Var(&amount, "amount", "Sell amount to quote the rate for; also shows the amount bought")
+	addRatePrecisionFlag(cmd, &ratePrecision)
 	return cmd
 }
+
+// rateSellAmount formats the amount r was quoted for, falling back to the
+// requested amount when the API leaves it out.
+func rateSellAmount(r api.Rate, requested json.Number) string {
+	if r.SellAmount == "" {
+		return outfmt.FormatMoneyCurrency(requested, r.SellCurrency)
+	}
+	return outfmt.FormatMoneyCurrency(r.SellAmount, r.SellCurrency)
+}
+
+// rateBuyAmount formats the amount bought at r. When the API leaves it out,
+// it is estimated from the rate as an exact decimal and marked with "~".
+func rateBuyAmount(r api.Rate, requested json.Number) string {
+	if r.BuyAmount != "" {
+		return outfmt.FormatMoneyCurrency(r.BuyAmount, r.BuyCurrency)
+	}
+	sell := r.SellAmount
+	if sell == "" {
+		sell = requested
+	}
+	amount, ok := new(big.Rat).SetString(sell.String())
+	if !ok {
+		return ""
+	}
+	rate, ok := new(big.Rat).SetString(r.Rate.String())
+	if !ok {
+		return ""
+	}
+	return "~" + amount.Mul(amount, rate).FloatString(outfmt.CurrencyDecimals(r.BuyCurrency))
+}
@@ -1,9 +1,16 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"io"
+	"net/http"
 	"strings"
 	"testing"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/api"
+	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
 )
 
 // TestFXRatesCommand tests the FX rates command flag validation
@@ -549,3 +556,158 @@ func TestFXConversionsListCommand_PageSizeFlag(t *testing.T) {
 		t.Errorf("expected default page-size to be 20, got: %s", pageSizeFlag.DefValue)
 	}
 }
+
+func TestFXRates_RatePrecision(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.Handle("GET", "/api/v1/fx/rates/current", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"sell_currency":"USD","buy_currency":"EUR","rate":0.92345678,"rate_type":"CURRENT"}`))
+	})
+	defer testMockServer.HandleError("GET", "/api/v1/fx/rates/current", http.StatusNotFound, "endpoint not found")
+
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(&out)
+		root.SetErr(io.Discard)
+		root.SetArgs(append([]string{"fx", "rates", "--sell", "USD", "--buy", "EUR"}, args...))
+		err := root.ExecuteContext(ctx)
+		return out.String(), err
+	}
+
+	text, err := run("--rate-precision", "4", "--output", "text")
+	if err != nil {
+		t.Fatalf("text run failed: %v", err)
+	}
+	if !strings.Contains(text, "0.9235") || strings.Contains(text, "0.923457") {
+		t.Errorf("text output should show the rate rounded to 4 decimals:\n%s", text)
+	}
+
+	jsonOut, err := run("--rate-precision", "4", "--output", "json")
+	if err != nil {
+		t.Fatalf("json run failed: %v", err)
+	}
+	if !strings.Contains(jsonOut, "0.92345678") {
+		t.Errorf("JSON output should keep full precision:\n%s", jsonOut)
+	}
+
+	if _, err := run("--rate-precision", "-1"); err == nil || !strings.Contains(err.Error(), "between 0 and") {
+		t.Errorf("expected range error for negative precision, got %v", err)
+	}
+}
+
+func TestFXConvertCommand_ConversionDate(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	var posted map[string]interface{}
+	testMockServer.Handle("POST", "/api/v1/fx/conversions/create", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&posted)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"conv_1","sell_currency":"USD","buy_currency":"EUR","sell_amount":1000,"buy_amount":921.5,"rate":0.9215,"status":"SCHEDULED","conversion_date":"2025-06-30"}`))
+	})
+	defer testMockServer.HandleError("POST", "/api/v1/fx/conversions/create", http.StatusNotFound, "endpoint not found")
+
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(&out)
+		root.SetErr(io.Discard)
+		root.SetArgs(append([]string{"fx", "convert"}, args...))
+		err := root.ExecuteContext(ctx)
+		return out.String(), err
+	}
+
+	out, err := run("--sell-currency", "USD", "--buy-currency", "EUR", "--sell-amount", "1000", "--conversion-date", "2025-06-30")
+	if err != nil {
+		t.Fatalf("fx convert failed: %v", err)
+	}
+	if posted["conversion_date"] != "2025-06-30" {
+		t.Errorf("conversion_date sent = %v, want 2025-06-30", posted["conversion_date"])
+	}
+	for _, want := range []string{"conv_1", "0.921500", "conversion_date", "2025-06-30"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	if _, err := run("--sell-currency", "USD", "--buy-currency", "EUR", "--sell-amount", "1000", "--conversion-date", "30/06/2025"); err == nil || !strings.Contains(err.Error(), "--conversion-date") {
+		t.Errorf("expected a bad --conversion-date to fail, got %v", err)
+	}
+	if _, err := run("--quote-id", "quote_123", "--conversion-date", "2025-06-30"); err == nil || !strings.Contains(err.Error(), "cannot be used with --quote-id") {
+		t.Errorf("expected --conversion-date with --quote-id to fail, got %v", err)
+	}
+}
+
+func TestFXRates_Amount(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	var gotAmount string
+	testMockServer.Handle("GET", "/api/v1/fx/rates/current", func(w http.ResponseWriter, r *http.Request) {
+		gotAmount = r.URL.Query().Get("sell_amount")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"sell_currency":"USD","buy_currency":"JPY","rate":149.235,"rate_type":"CURRENT"}`))
+	})
+	defer testMockServer.HandleError("GET", "/api/v1/fx/rates/current", http.StatusNotFound, "endpoint not found")
+
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(&out)
+		root.SetErr(io.Discard)
+		root.SetArgs(append([]string{"fx", "rates", "--sell", "USD", "--buy", "JPY"}, args...))
+		err := root.ExecuteContext(ctx)
+		return out.String(), err
+	}
+
+	text, err := run("--amount", "1000", "--output", "text")
+	if err != nil {
+		t.Fatalf("text run failed: %v", err)
+	}
+	if gotAmount != "1000" {
+		t.Errorf("sell_amount sent = %q, want 1000", gotAmount)
+	}
+	for _, want := range []string{"BUY_AMOUNT", "149.235000", "1000.00", "~149235"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("text output missing %q:\n%s", want, text)
+		}
+	}
+
+	jsonOut, err := run("--amount", "1000", "--output", "json")
+	if err != nil {
+		t.Fatalf("json run failed: %v", err)
+	}
+	var result struct {
+		Rates []struct {
+			Rate      json.Number `json:"rate"`
+			BuyAmount json.Number `json:"buy_amount"`
+		} `json:"rates"`
+	}
+	if err := json.Unmarshal([]byte(jsonOut), &result); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, jsonOut)
+	}
+	if len(result.Rates) != 1 || result.Rates[0].Rate != "149.235" || result.Rates[0].BuyAmount != "" {
+		t.Errorf("json rates = %+v, want rate 149.235 and no invented buy_amount", result.Rates)
+	}
+
+	if _, err := run("--amount", "-5"); err == nil || !strings.Contains(err.Error(), "--amount") {
+		t.Errorf("expected a negative --amount to fail, got %v", err)
+	}
+}
+
+func TestRateBuyAmount(t *testing.T) {
+	quoted := api.Rate{SellCurrency: "USD", BuyCurrency: "JPY", Rate: "149.235", BuyAmount: "149200"}
+	if got := rateBuyAmount(quoted, "1000"); got != "149200" {
+		t.Errorf("quoted buy amount = %q, want 149200", got)
+	}
+	estimated := api.Rate{SellCurrency: "USD", BuyCurrency: "EUR", Rate: "0.921537"}
+	if got := rateBuyAmount(estimated, "12345678901234.56"); got != "~11376999897606.99" {
+		t.Errorf("estimated buy amount = %q, want ~11376999897606.99", got)
+	}
+}
@@ -4,10 +4,15 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
@@ -17,6 +22,8 @@ import (
 
 	"github.com/salmonumbrella/airwallex-cli/internal/api"
 	"github.com/salmonumbrella/airwallex-cli/internal/batch"
+	"github.com/salmonumbrella/airwallex-cli/internal/config"
+	"github.com/salmonumbrella/airwallex-cli/internal/dryrun"
 	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
 	"github.com/salmonumbrella/airwallex-cli/internal/outfmt"
 	"github.com/salmonumbrella/airwallex-cli/internal/secrets"
@@ -35,19 +42,62 @@ func mustMarkRequired(cmd *cobra.Command, name string) {
 
 // newClientForCreds is a variable that can be overridden in tests.
 var newClientForCreds = func(creds secrets.Credentials) (*api.Client, error) {
+	if creds.Env == envDemo {
+		if creds.AccountID != "" {
+			return api.NewClientWithBaseURLAndAccount(api.DemoBaseURL, creds.ClientID, creds.APIKey, creds.AccountID)
+		}
+		return api.NewClientWithBaseURL(api.DemoBaseURL, creds.ClientID, creds.APIKey)
+	}
 	if creds.AccountID != "" {
 		return api.NewClientWithAccount(creds.ClientID, creds.APIKey, creds.AccountID)
 	}
 	return api.NewClient(creds.ClientID, creds.APIKey)
 }
 
-// getClient creates an API client from the current account
+// tokenStoreFor returns the on-disk login token cache for an account. The
+// file name hashes the account name and credentials so switching accounts,
+// client IDs, or environments never reuses another login's token.
+func tokenStoreFor(account string, creds secrets.Credentials) (*api.FileTokenStore, error) {
+	dir, err := config.TokenDir()
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256([]byte(strings.Join([]string{account, creds.ClientID, creds.AccountID, creds.Env}, "\x00")))
+	return &api.FileTokenStore{Path: filepath.Join(dir, hex.EncodeToString(sum[:16])+".json")}, nil
+}
+
+// clearTokenStore removes account's cached login token, so it does not
+// outlive the account's stored credentials.
+func clearTokenStore(account string, creds secrets.Credentials) error {
+	store, err := tokenStoreFor(account, creds)
+	if err != nil {
+		return err
+	}
+	return store.Clear()
+}
+
+// getClient creates an API client from the current account. Under
+// WithSharedClients the account's client is built once and reused.
 func getClient(ctx context.Context) (*api.Client, error) {
 	account, err := requireAccount(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	shared := sharedClientsFromContext(ctx)
+	client := shared.get(account)
+	if client == nil {
+		if client, err = newAccountClient(ctx, account); err != nil {
+			return nil, err
+		}
+		client = shared.put(account, client)
+	}
+	return client, nil
+}
+
+// newAccountClient builds a client for account and applies the connection
+// settings from the root flags (see applyConnectionSettings).
+func newAccountClient(ctx context.Context, account string) (*api.Client, error) {
 	store, err := openSecretsStore()
 	if err != nil {
 		return nil, err
@@ -58,7 +108,137 @@ func getClient(ctx context.Context) (*api.Client, error) {
 		return nil, fmt.Errorf("account not found: %s", account)
 	}
 
-	return newClientForCreds(creds)
+	client, err := newConnectedClient(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+	if store, err := tokenStoreFor(account, creds); err == nil {
+		client.SetTokenStore(store)
+	}
+	return client, nil
+}
+
+// newConnectedClient builds a client for creds with the connection settings
+// applied. Used directly, e.g. to check credentials, it has no token store.
+func newConnectedClient(ctx context.Context, creds secrets.Credentials) (*api.Client, error) {
+	client, err := newClientForCreds(creds)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyConnectionSettings(ctx, client); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// applyConnectionSettings applies the root flags' connection settings to
+// client: retries, timeouts, rate limit, API version, TLS, and proxy.
+func applyConnectionSettings(ctx context.Context, client *api.Client) error {
+	f, ok := rootFlagsFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if f.RetryIdempotent5xx {
+		client.SetRetryIdempotent5xx(true)
+	}
+	if len(f.RetryStatus) > 0 {
+		for _, status := range f.RetryStatus {
+			if status < 400 || status > 599 {
+				return fmt.Errorf("invalid --retry-status %d: must be a 4xx or 5xx status", status)
+			}
+		}
+		client.SetRetryStatuses(f.RetryStatus)
+	}
+	if f.RequestTimeout > 0 {
+		client.SetRequestTimeout(f.RequestTimeout)
+	}
+	if f.maxRetriesSet {
+		client.SetMaxRetries(f.MaxRetries)
+	}
+	if f.RateLimit > 0 {
+		client.SetRateLimit(f.RateLimit)
+	}
+	for _, path := range f.CACerts {
+		//nolint:gosec // G304: path comes from --ca-cert, intentional
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("--ca-cert: %w", err)
+		}
+		if err := client.AddRootCAs(pem); err != nil {
+			return fmt.Errorf("--ca-cert %s: %w", path, err)
+		}
+	}
+	if f.APIDateVersion != "" {
+		version := strings.ToLower(strings.TrimSpace(f.APIDateVersion))
+		if _, err := time.Parse("2006-01-02", version); err != nil && version != api.APIVersionLatest {
+			return fmt.Errorf("invalid --api-date-version %q: must be a date (YYYY-MM-DD) or %q", f.APIDateVersion, api.APIVersionLatest)
+		}
+		client.SetAPIVersion(version)
+	}
+	if f.Proxy != "" {
+		proxyURL, err := parseProxyURL(f.Proxy)
+		if err != nil {
+			return err
+		}
+		client.SetProxy(proxyURL)
+	}
+	return nil
+}
+
+// invocationHooks returns this invocation's per-command outputs. They travel
+// in the command context rather than on the client, so a shared client never
+// carries --show-url, --dry-run, or --stats between commands, even
+// concurrent ones.
+func invocationHooks(ctx context.Context) api.Hooks {
+	f, _ := rootFlagsFromContext(ctx)
+	if f == nil {
+		f = &rootFlags{}
+	}
+	var urlWriter, idemKeyWriter io.Writer
+	if f.ShowURL {
+		urlWriter = iocontext.GetIO(ctx).ErrOut
+	}
+	if f.ShowIdempotencyKey {
+		idemKeyWriter = iocontext.GetIO(ctx).ErrOut
+	}
+	var dryRun func(api.DryRunRequest) error
+	if f.DryRun {
+		dryRun = func(r api.DryRunRequest) error {
+			return writeDryRunRequest(ctx, r)
+		}
+	}
+	return api.Hooks{
+		ShowURL:            urlWriter,
+		ShowIdempotencyKey: idemKeyWriter,
+		DryRun:             dryRun,
+		Stats:              f.stats,
+	}
+}
+
+// caCertsFromEnv returns the AWX_CA_CERT paths, the default for --ca-cert.
+func caCertsFromEnv() []string {
+	var paths []string
+	for _, p := range filepath.SplitList(os.Getenv("AWX_CA_CERT")) {
+		if p = strings.TrimSpace(p); p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// parseProxyURL validates a --proxy value. http, https, and socks5 proxies
+// are supported.
+func parseProxyURL(s string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid --proxy %q: must be a URL such as http://proxy:3128", s)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+		return u, nil
+	default:
+		return nil, fmt.Errorf("invalid --proxy %q: scheme must be http, https, or socks5", s)
+	}
 }
 
 // convertDateToRFC3339 converts a date string in YYYY-MM-DD format to RFC3339 format
@@ -103,6 +283,29 @@ func validateAmount(amount float64) error {
 	return nil
 }
 
+// decimalPattern matches a plain non-negative decimal such as 1234.56.
+var decimalPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+// amountValue is a monetary flag value kept as the exact digits given, so an
+// amount like 1234567.89 reaches the API without a float64 round trip.
+type amountValue json.Number
+
+func (a *amountValue) String() string { return string(*a) }
+
+func (a *amountValue) Set(s string) error {
+	s = strings.TrimSpace(s)
+	if !decimalPattern.MatchString(s) || strings.Trim(s, "0.") == "" {
+		return fmt.Errorf("must be a positive decimal amount, e.g. 1234.56")
+	}
+	*a = amountValue(s)
+	return nil
+}
+
+func (a *amountValue) Type() string { return "decimal" }
+
+// IsSet reports whether the flag was given.
+func (a *amountValue) IsSet() bool { return *a != "" }
+
 // validateCurrency validates that a currency code is 3 uppercase letters
 func validateCurrency(currency string) error {
 	if currency == "" {
@@ -172,8 +375,8 @@ var isTerminal = func() bool {
 // Returns true if confirmed, false if declined.
 // Returns an error if stdin is not a TTY and confirmation is needed.
 func ConfirmOrYes(ctx context.Context, prompt string) (bool, error) {
-	// If --yes or --force flag is set, skip confirmation
-	if outfmt.GetYes(ctx) {
+	// If --yes or --force flag is set, skip confirmation; --dry-run sends nothing
+	if outfmt.GetYes(ctx) || dryrun.IsEnabled(ctx) {
 		return true, nil
 	}
 	if outfmt.GetNoInput(ctx) {
@@ -252,6 +455,19 @@ func readOptionalJSONPayload(data, fromFile string) (map[string]interface{}, err
 	return readJSONPayload(data, fromFile)
 }
 
+// saveRequestBody writes body to path exactly as the API client will encode
+// it (json.Marshal), for --save-request audit trails.
+func saveRequestBody(path string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save request: %w", err)
+	}
+	return nil
+}
+
 func readTextInput(path string) (string, error) {
 	var reader io.Reader
 	switch path {
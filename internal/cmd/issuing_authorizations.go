@@ -121,7 +121,7 @@ func newAuthorizationsGetCmd() *cobra.Command {
 			if auth.Merchant.Name != "" {
 				rows = append(rows, outfmt.KV{Key: "merchant", Value: auth.Merchant.Name})
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}, getClient)
 }
@@ -68,7 +68,7 @@ func newCardholdersGetCmd() *cobra.Command {
 				{Key: "status", Value: ch.Status},
 				{Key: "created_at", Value: ch.CreatedAt},
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}, getClient)
 }
@@ -101,6 +101,9 @@ func newCardholdersCreateCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if ch.CardholderID == "" {
+				return writeNoDetailsResult(cmd, "Created cardholder (the API returned no details; find it with 'airwallex issuing cardholders list')")
+			}
 
 			if outfmt.IsJSON(cmd.Context()) {
 				return writeJSONOutput(cmd, ch)
@@ -84,7 +84,7 @@ func newCardsGetCmd() *cobra.Command {
 				{Key: "cardholder_id", Value: card.CardholderID},
 				{Key: "created_at", Value: card.CreatedAt},
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}, getClient)
 }
@@ -215,6 +215,10 @@ Program types: PREPAID, DEBIT, CREDIT, DEFERRED_DEBIT`,
 			if err != nil {
 				return err
 			}
+			if card.CardID == "" {
+				// Without an ID there are no company card details to fetch.
+				return writeNoDetailsResult(cmd, "Created card (the API returned no details; find it with 'airwallex issuing cards list')")
+			}
 
 			io := iocontext.GetIO(cmd.Context())
 			if outfmt.IsJSON(cmd.Context()) {
@@ -402,7 +406,7 @@ func newCardsDetailsCmd() *cobra.Command {
 				{Key: "cvv", Value: details.Cvv},
 				{Key: "expiry", Value: fmt.Sprintf("%02d/%d", details.ExpiryMonth, details.ExpiryYear)},
 			}
-			return outfmt.WriteKV(io.Out, rows)
+			return outfmt.WriteKVForContext(cmd.Context(), io.Out, rows)
 		},
 	}
 
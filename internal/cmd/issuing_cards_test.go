@@ -1,13 +1,19 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"io"
+	"net/http"
 	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
 
 	"github.com/salmonumbrella/airwallex-cli/internal/api"
+	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
 	"github.com/salmonumbrella/airwallex-cli/internal/secrets"
 )
 
@@ -117,6 +123,44 @@ func TestCardsCreateValidation(t *testing.T) {
 	}
 }
 
+func TestCardsCreate_EmptyResponseReportsNoDetails(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.Handle("POST", "/api/v1/issuing/cards/create", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	defer testMockServer.HandleError("POST", "/api/v1/issuing/cards/create", http.StatusNotFound, "endpoint not found")
+	testMockServer.Handle("POST", "/api/v1/issuing/cardholders/create", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	defer testMockServer.HandleError("POST", "/api/v1/issuing/cardholders/create", http.StatusNotFound, "endpoint not found")
+
+	tests := map[string][]string{
+		"company card": {"issuing", "cards", "create", "MyCard", "--cardholder-id", "chld_123", "--limit", "100", "--company"},
+		"cardholder":   {"issuing", "cardholders", "create", "--email", "a@example.com", "--first-name", "Ada", "--last-name", "Lovelace"},
+	}
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+			root := NewRootCmd()
+			root.SetArgs(append(args, "--output", "json"))
+			if err := root.ExecuteContext(ctx); err != nil {
+				t.Fatalf("create failed: %v", err)
+			}
+
+			var got noDetailsResult
+			if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+				t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+			}
+			if !got.Success || !strings.Contains(got.Message, "the API returned no details") {
+				t.Errorf("result = %+v, want success with a no-details message", got)
+			}
+		})
+	}
+}
+
 func isExpectedAPIError(err error) bool {
 	var contextual *api.ContextualError
 	if errors.As(err, &contextual) {
@@ -58,6 +58,14 @@ func newDisputesListCmd() *cobra.Command {
 			}
 			return []string{disputeID(d), d.TransactionID, d.Status, amount, d.Currency}
 		},
+		CSVHeaders: []string{"dispute_id", "transaction_id", "status", "reason", "amount", "currency", "created_at"},
+		CSVRowFunc: func(d api.TransactionDispute) []string {
+			amount := ""
+			if d.Amount != "" {
+				amount = outfmt.FormatMoneyCurrency(d.Amount, d.Currency)
+			}
+			return []string{disputeID(d), d.TransactionID, d.Status, d.Reason, amount, d.Currency, d.CreatedAt}
+		},
 		IDFunc: func(d api.TransactionDispute) string {
 			return disputeID(d)
 		},
@@ -127,7 +135,7 @@ func newDisputesGetCmd() *cobra.Command {
 			if outfmt.MoneyFloat64(dispute.Amount) != 0 || dispute.Currency != "" {
 				rows = append(rows, outfmt.KV{Key: "amount", Value: outfmt.FormatMoney(dispute.Amount) + " " + dispute.Currency})
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}, getClient)
 }
@@ -148,6 +156,10 @@ Examples:
 		SuccessMessage: func(dispute *api.TransactionDispute) string {
 			return fmt.Sprintf("Created dispute: %s", disputeID(*dispute))
 		},
+		ID: func(dispute *api.TransactionDispute) string {
+			return disputeID(*dispute)
+		},
+		NoDetails: "Created dispute (the API returned no details; find it with 'airwallex issuing disputes list')",
 	}, getClient)
 }
 
@@ -172,7 +184,9 @@ Examples:
 }
 
 func newDisputesSubmitCmd() *cobra.Command {
-	return &cobra.Command{
+	var showTransition bool
+
+	cmd := &cobra.Command{
 		Use:     "submit <disputeId>",
 		Aliases: []string{"sub"},
 		Short:   "Submit a dispute",
@@ -185,23 +199,41 @@ func newDisputesSubmitCmd() *cobra.Command {
 			}
 
 			disputeIDArg := NormalizeIDArg(args[0])
+			var before *api.TransactionDispute
+			if showTransition {
+				if before, err = client.GetTransactionDispute(cmd.Context(), disputeIDArg); err != nil {
+					return err
+				}
+			}
+
 			dispute, err := client.SubmitTransactionDispute(cmd.Context(), disputeIDArg)
 			if err != nil {
 				return err
 			}
 
 			if outfmt.IsJSON(cmd.Context()) {
+				if showTransition {
+					return writeJSONOutput(cmd, transition[*api.TransactionDispute]{Before: before, After: dispute})
+				}
 				return writeJSONOutput(cmd, dispute)
 			}
 
 			u.Success(fmt.Sprintf("Submitted dispute: %s", disputeID(*dispute)))
+			if showTransition {
+				u.Info(fmt.Sprintf("Status: %s -> %s", before.Status, dispute.Status))
+			}
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&showTransition, "show-transition", false, "Fetch the dispute first and output {before, after} in JSON mode")
+	return cmd
 }
 
 func newDisputesCancelCmd() *cobra.Command {
-	return &cobra.Command{
+	var showTransition bool
+
+	cmd := &cobra.Command{
 		Use:     "cancel <disputeId>",
 		Aliases: []string{"x"},
 		Short:   "Cancel a dispute",
@@ -214,17 +246,33 @@ func newDisputesCancelCmd() *cobra.Command {
 			}
 
 			disputeIDArg := NormalizeIDArg(args[0])
+			var before *api.TransactionDispute
+			if showTransition {
+				if before, err = client.GetTransactionDispute(cmd.Context(), disputeIDArg); err != nil {
+					return err
+				}
+			}
+
 			dispute, err := client.CancelTransactionDispute(cmd.Context(), disputeIDArg)
 			if err != nil {
 				return err
 			}
 
 			if outfmt.IsJSON(cmd.Context()) {
+				if showTransition {
+					return writeJSONOutput(cmd, transition[*api.TransactionDispute]{Before: before, After: dispute})
+				}
 				return writeJSONOutput(cmd, dispute)
 			}
 
 			u.Success(fmt.Sprintf("Cancelled dispute: %s", disputeID(*dispute)))
+			if showTransition {
+				u.Info(fmt.Sprintf("Status: %s -> %s", before.Status, dispute.Status))
+			}
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&showTransition, "show-transition", false, "Fetch the dispute first and output {before, after} in JSON mode")
+	return cmd
 }
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
+)
+
+func TestDisputesList_CSV(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	run := func(t *testing.T, body string) [][]string {
+		t.Helper()
+		testMockServer.HandleJSON("GET", "/api/v1/issuing/transaction_disputes", http.StatusOK, json.RawMessage(body))
+		defer testMockServer.HandleError("GET", "/api/v1/issuing/transaction_disputes", http.StatusNotFound, "endpoint not found")
+
+		var out, errOut bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: &errOut, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(&out)
+		root.SetErr(io.Discard)
+		root.SetArgs([]string{"issuing", "disputes", "list", "--output", "csv"})
+		if err := root.ExecuteContext(ctx); err != nil {
+			t.Fatalf("disputes list --output csv failed: %v", err)
+		}
+		records, err := csv.NewReader(&out).ReadAll()
+		if err != nil {
+			t.Fatalf("output is not CSV: %v\n%s", err, out.String())
+		}
+		return records
+	}
+
+	t.Run("rows", func(t *testing.T) {
+		records := run(t, `{"items":[
+			{"id":"dsp_1","transaction_id":"txn_1","status":"SUBMITTED","reason":"FRAUD","amount":125.5,"currency":"USD","created_at":"2024-03-01T10:00:00Z"},
+			{"dispute_id":"dsp_2","transaction_id":"txn_2","status":"DRAFT","reason":"DUPLICATE, CHARGED TWICE","amount":1500,"currency":"JPY","created_at":"2024-03-02T10:00:00Z"},
+			{"id":"dsp_3","transaction_id":"txn_3","status":"WON","reason":"OTHER","amount":12.3456,"currency":"KWD","created_at":"2024-03-03T10:00:00Z"}
+		],"has_more":false}`)
+
+		want := [][]string{
+			{"dispute_id", "transaction_id", "status", "reason", "amount", "currency", "created_at"},
+			{"dsp_1", "txn_1", "SUBMITTED", "FRAUD", "125.50", "USD", "2024-03-01T10:00:00Z"},
+			{"dsp_2", "txn_2", "DRAFT", "DUPLICATE, CHARGED TWICE", "1500", "JPY", "2024-03-02T10:00:00Z"},
+			{"dsp_3", "txn_3", "WON", "OTHER", "12.346", "KWD", "2024-03-03T10:00:00Z"},
+		}
+		if !reflect.DeepEqual(records, want) {
+			t.Errorf("CSV = %q\nwant %q", records, want)
+		}
+	})
+
+	t.Run("empty writes header", func(t *testing.T) {
+		records := run(t, `{"items":[],"has_more":false}`)
+		want := [][]string{{"dispute_id", "transaction_id", "status", "reason", "amount", "currency", "created_at"}}
+		if !reflect.DeepEqual(records, want) {
+			t.Errorf("CSV = %q, want header only", records)
+		}
+	})
+}
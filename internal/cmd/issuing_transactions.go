@@ -22,6 +22,8 @@ func newTransactionsCmd() *cobra.Command {
 
 func newTransactionsListCmd() *cobra.Command {
 	var cardID string
+	var billingCurrency string
+	var txnType string
 	var from string
 	var to string
 	cmd := NewListCommand(ListConfig[api.Transaction]{
@@ -36,6 +38,9 @@ Examples:
   # List recent transactions
   airwallex issuing transactions list --page-size 20
 
+  # Card spend for a month (for reconciliation)
+  airwallex issuing transactions list --card-id card_xxx --from 2024-06-01 --to 2024-06-30 --all
+
   # Filter by merchant name (case-insensitive)
   airwallex issuing transactions list --output json --query \
     '[.[] | select(.merchant.name | test("COACH"; "i"))]'
@@ -82,7 +87,15 @@ Examples:
 				return ListResult[api.Transaction]{}, err
 			}
 
-			result, err := client.ListTransactions(ctx, cardID, fromRFC3339, toRFC3339, opts.Page, normalizePageSize(opts.Limit))
+			result, err := client.ListIssuingTransactions(ctx, api.IssuingTransactionListParams{
+				CardID:          cardID,
+				BillingCurrency: billingCurrency,
+				TransactionType: txnType,
+				FromCreatedAt:   fromRFC3339,
+				ToCreatedAt:     toRFC3339,
+				PageNum:         opts.Page,
+				PageSize:        normalizePageSize(opts.Limit),
+			})
 			if err != nil {
 				return ListResult[api.Transaction]{}, err
 			}
@@ -95,6 +108,8 @@ Examples:
 	}, getClient)
 
 	cmd.Flags().StringVar(&cardID, "card-id", "", "Filter by card ID")
+	cmd.Flags().StringVar(&billingCurrency, "billing-currency", "", "Filter by billing currency")
+	cmd.Flags().StringVar(&txnType, "type", "", "Filter by transaction type (e.g. AUTHORIZATION, CLEARING, REFUND)")
 	cmd.Flags().StringVarP(&from, "from", "f", "", "From date (YYYY-MM-DD)")
 	cmd.Flags().StringVar(&to, "to", "", "To date (YYYY-MM-DD)")
 	flagAlias(cmd.Flags(), "card-id", "cid")
@@ -121,8 +136,9 @@ func newTransactionsGetCmd() *cobra.Command {
 				{Key: "merchant", Value: txn.Merchant.Name},
 				{Key: "status", Value: txn.Status},
 				{Key: "date", Value: txn.TransactionDate},
+				{Key: "posted_at", Value: txn.PostedAt},
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}, getClient)
 }
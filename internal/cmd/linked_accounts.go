@@ -72,7 +72,7 @@ func newLinkedAccountsGetCmd() *cobra.Command {
 			if la.AccountNumber != "" {
 				rows = append(rows, outfmt.KV{Key: "account_number", Value: "****" + la.AccountNumber})
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}, getClient)
 }
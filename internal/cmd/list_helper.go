@@ -2,14 +2,17 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
 	"github.com/salmonumbrella/airwallex-cli/internal/api"
+	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
 	"github.com/salmonumbrella/airwallex-cli/internal/outfmt"
 	"github.com/salmonumbrella/airwallex-cli/internal/pagination"
 )
@@ -37,6 +40,14 @@ const (
 	PaginationCursor PaginationMode = "cursor"
 )
 
+// ListColumn is a named table column that --columns can select.
+type ListColumn[T any] struct {
+	Name   string // --columns key, e.g. "bank_country"
+	Header string // table header; defaults to the upper-cased Name
+	Type   outfmt.ColumnType
+	Value  func(T) string
+}
+
 // ListConfig defines how a list command behaves
 type ListConfig[T any] struct {
 	// Command metadata
@@ -60,6 +71,18 @@ type ListConfig[T any] struct {
 	ColumnTypes  []outfmt.ColumnType // Optional: column types for colorization
 	EmptyMessage string
 
+	// Columns, when set, replaces Headers/RowFunc/ColumnTypes and registers
+	// --columns. DefaultColumns names the columns shown without the flag;
+	// --columns picks and orders any of Columns, or "all" for every one.
+	Columns        []ListColumn[T]
+	DefaultColumns []string
+
+	// CSVHeaders and CSVRowFunc optionally define the --output csv columns
+	// when they should differ from the text table. Defaults to Headers and
+	// RowFunc.
+	CSVHeaders []string
+	CSVRowFunc func(T) []string
+
 	// IDFunc extracts ID from item for cursor-based pagination
 	// If nil, next cursor hint won't be shown
 	IDFunc func(T) string
@@ -74,17 +97,31 @@ type ListConfig[T any] struct {
 	// Pagination configures which pagination model the endpoint uses.
 	// Defaults to PaginationPage.
 	Pagination PaginationMode
+
+	// AfterID registers --after-id on page-paginated commands whose endpoint
+	// also accepts ID-based continuation. The ID is passed as opts.Cursor and
+	// --all advances using IDFunc instead of page numbers. Page-only endpoints
+	// leave this unset; offset pagination may skip or repeat items if data
+	// changes between pages.
+	AfterID bool
 }
 
 // NewListCommand creates a cobra command from ListConfig
 func NewListCommand[T any](cfg ListConfig[T], getClient func(context.Context) (*api.Client, error)) *cobra.Command {
 	var limit int
 	var after string
+	var afterID string
 	var page int
 	var pageSize int
 	var itemsOnlyFlag bool
 	var fetchAll bool
+	var partialOK bool
+	var maxPages int
 	var lightFlag bool
+	var chunkSize int
+	var chunkFile string
+	var maxItems int
+	var columns []string
 
 	cmd := &cobra.Command{
 		Use:     cfg.Use,
@@ -99,12 +136,14 @@ func NewListCommand[T any](cfg ListConfig[T], getClient func(context.Context) (*
 			}
 
 			// Enforce limits and pagination defaults
+			errOut := iocontext.GetIO(cmd.Context()).ErrOut
 			switch mode {
 			case PaginationCursor:
 				if limit <= 0 {
 					limit = 20
 				}
 				if limit > 100 {
+					warnf(cmd.Context(), errOut, "--limit %d exceeds the maximum of 100; using 100", limit)
 					limit = 100
 				}
 			case PaginationPage:
@@ -112,6 +151,7 @@ func NewListCommand[T any](cfg ListConfig[T], getClient func(context.Context) (*
 					pageSize = 20
 				}
 				if pageSize > 100 {
+					warnf(cmd.Context(), errOut, "--page-size %d exceeds the maximum of 100; using 100", pageSize)
 					pageSize = 100
 				}
 				if page <= 0 {
@@ -120,6 +160,49 @@ func NewListCommand[T any](cfg ListConfig[T], getClient func(context.Context) (*
 			default:
 				return fmt.Errorf("unknown pagination mode %q", mode)
 			}
+			if afterID != "" && cmd.Flags().Changed("page") {
+				return fmt.Errorf("--after-id cannot be combined with --page")
+			}
+			if partialOK && !fetchAll {
+				return fmt.Errorf("--partial-ok requires --all")
+			}
+			if maxPages < 0 {
+				return fmt.Errorf("--max-pages must be positive")
+			}
+			if maxPages > 0 && !fetchAll {
+				return fmt.Errorf("--max-pages requires --all")
+			}
+			if maxItems < 0 {
+				return fmt.Errorf("--max-items must be positive")
+			}
+			if maxItems > 0 && !fetchAll {
+				return fmt.Errorf("--max-items requires --all")
+			}
+			if chunkSize < 0 {
+				return fmt.Errorf("--chunk-size must be positive")
+			}
+			if (chunkSize > 0) != (chunkFile != "") {
+				return fmt.Errorf("--chunk-size and --chunk-file must be used together")
+			}
+
+			headers, columnTypes, rowFunc := cfg.Headers, cfg.ColumnTypes, cfg.RowFunc
+			if len(cfg.Columns) > 0 {
+				if len(columns) > 0 && outfmt.IsJSON(cmd.Context()) {
+					return fmt.Errorf("--columns applies to table and CSV output; use --only-fields with JSON")
+				}
+				selection := cfg.DefaultColumns
+				if len(columns) > 0 {
+					selection = columns
+				}
+				selected, err := selectListColumns(cfg.Columns, selection)
+				if err != nil {
+					return err
+				}
+				headers, columnTypes, rowFunc = listColumnsTable(selected)
+			}
+			if outfmt.IsCSV(cmd.Context()) && cfg.CSVRowFunc != nil && len(columns) == 0 {
+				headers, columnTypes, rowFunc = cfg.CSVHeaders, nil, cfg.CSVRowFunc
+			}
 
 			client, err := getClient(cmd.Context())
 			if err != nil {
@@ -135,6 +218,9 @@ func NewListCommand[T any](cfg ListConfig[T], getClient func(context.Context) (*
 			}
 			if mode == PaginationPage {
 				opts.Limit = pageSize
+				if afterID != "" {
+					opts.Cursor = afterID
+				}
 			}
 
 			// When --all is used, fetch all pages using max page size.
@@ -158,15 +244,30 @@ func NewListCommand[T any](cfg ListConfig[T], getClient func(context.Context) (*
 				return err
 			}
 
-			// Auto-paginate when --all is set
+			// Auto-paginate when --all is set. With --partial-ok, a failed page
+			// keeps the items gathered so far and the error is returned after output.
+			// --max-pages and --max-items stop early and mark the result as truncated.
+			var partialErr error
+			truncated := false
+			truncatedBy := ""
 			if fetchAll && result.HasMore {
 				allItems := make([]T, 0, len(result.Items)*2)
 				allItems = append(allItems, result.Items...)
+				pages := 1
 				for result.HasMore {
-					switch mode {
-					case PaginationPage:
+					if maxPages > 0 && pages >= maxPages {
+						truncated, truncatedBy = true, "max-pages"
+						break
+					}
+					if maxItems > 0 && len(allItems) >= maxItems {
+						truncated, truncatedBy = true, "max-items"
+						break
+					}
+					switch {
+					case mode == PaginationPage && opts.Cursor == "":
 						opts.Page++
-					case PaginationCursor:
+					default:
+						// Cursor mode, or page mode resumed with --after-id.
 						if cfg.IDFunc != nil && len(result.Items) > 0 {
 							opts.Cursor = cfg.IDFunc(result.Items[len(result.Items)-1])
 						} else {
@@ -181,32 +282,132 @@ func NewListCommand[T any](cfg ListConfig[T], getClient func(context.Context) (*
 						result, err = cfg.Fetch(cmd.Context(), client, opts)
 					}
 					if err != nil {
-						return err
+						if !partialOK {
+							return err
+						}
+						partialErr = fmt.Errorf("partial results: stopped after %d items: %w", len(allItems), err)
+						warnf(cmd.Context(), iocontext.GetIO(cmd.Context()).ErrOut, "%v", partialErr)
+						break
 					}
 					allItems = append(allItems, result.Items...)
+					pages++
 				}
 				result.Items = allItems
-				result.HasMore = false
+				// A partial or truncated fetch still has more to read, but there is
+				// no reliable next page to point at, so no next links or hints are
+				// emitted.
+				result.HasMore = partialErr != nil || truncated
+			}
+			if fetchAll && maxItems > 0 && len(result.Items) > maxItems {
+				result.Items = result.Items[:maxItems]
+				truncated, truncatedBy = true, "max-items"
+				result.HasMore = true
+			}
+			// Outside the JSON envelope, a truncated result is only visible as a
+			// warning on stderr.
+			if truncated && (!outfmt.IsJSON(cmd.Context()) || itemsOnlyFlag || outfmt.GetItemsOnly(cmd.Context()) || chunkSize > 0) {
+				if truncatedBy == "max-items" {
+					warnf(cmd.Context(), errOut, "results truncated at %d item%s (--max-items); more items are available", maxItems, pluralSuffix(maxItems))
+				} else {
+					warnf(cmd.Context(), errOut, "results truncated after %d page%s (--max-pages); more items are available", maxPages, pluralSuffix(maxPages))
+				}
 			}
 
 			f := outfmt.FromContext(cmd.Context())
 			itemsOnly := itemsOnlyFlag || outfmt.GetItemsOnly(cmd.Context())
 
+			if chunkSize > 0 {
+				items := make([]any, 0, len(result.Items))
+				for _, it := range result.Items {
+					if lightFlag && cfg.LightFunc != nil {
+						items = append(items, cfg.LightFunc(it))
+					} else {
+						items = append(items, it)
+					}
+				}
+				files, err := writeJSONChunks(chunkFile, items, chunkSize)
+				if err != nil {
+					return err
+				}
+				if outfmt.IsJSON(cmd.Context()) {
+					summary := map[string]interface{}{
+						"files":    files,
+						"items":    len(items),
+						"has_more": result.HasMore,
+					}
+					if partialErr != nil {
+						summary["partial"] = true
+					}
+					if fetchAll {
+						summary["truncated"] = truncated
+					}
+					if err := f.Output(summary); err != nil {
+						return err
+					}
+				} else {
+					_, _ = fmt.Fprintf(iocontext.GetIO(cmd.Context()).ErrOut, "Wrote %d items to %d files\n", len(items), len(files))
+				}
+				return partialErr
+			}
+
+			// Templates see the same {items, has_more} shape as JSON output,
+			// minus the _links annotations.
+			if outfmt.GetTemplate(cmd.Context()) != "" {
+				items := result.Items
+				if items == nil {
+					items = make([]T, 0)
+				}
+				if itemsOnly {
+					if err := f.Output(items); err != nil {
+						return err
+					}
+					return partialErr
+				}
+				output := map[string]interface{}{
+					"items":    items,
+					"has_more": result.HasMore,
+				}
+				if fetchAll {
+					output["truncated"] = truncated
+				}
+				if err := f.Output(output); err != nil {
+					return err
+				}
+				return partialErr
+			}
+
 			// Handle empty results
 			if len(result.Items) == 0 {
 				if outfmt.IsJSON(cmd.Context()) {
 					// Ensure empty slice serializes as [] not null
 					empty := make([]T, 0)
 					if itemsOnly {
-						return f.Output(empty)
+						if err := f.Output(empty); err != nil {
+							return err
+						}
+						return partialErr
 					}
-					return f.Output(map[string]interface{}{
+					output := map[string]interface{}{
 						"items":    empty,
 						"has_more": result.HasMore,
-					})
+					}
+					if fetchAll {
+						output["truncated"] = truncated
+					}
+					if err := f.Output(output); err != nil {
+						return err
+					}
+					return partialErr
+				}
+				if outfmt.IsCSV(cmd.Context()) {
+					// Header row only, so spreadsheet imports still see the columns.
+					if err := f.OutputList(result.Items, headers, nil); err != nil {
+						return err
+					}
+					return partialErr
 				}
 				f.Empty(cfg.EmptyMessage)
-				return nil
+				return partialErr
 			}
 
 			// For JSON output, include pagination metadata
@@ -235,12 +436,21 @@ func NewListCommand[T any](cfg ListConfig[T], getClient func(context.Context) (*
 				}
 
 				if itemsOnly {
-					return f.Output(itemsOut)
+					if err := f.Output(itemsOut); err != nil {
+						return err
+					}
+					return partialErr
 				}
 				output := map[string]interface{}{
 					"items":    itemsOut,
 					"has_more": result.HasMore,
 				}
+				if partialErr != nil {
+					output["partial"] = true
+				}
+				if fetchAll {
+					output["truncated"] = truncated
+				}
 				selfOverride := ""
 				switch mode {
 				case PaginationCursor:
@@ -248,12 +458,18 @@ func NewListCommand[T any](cfg ListConfig[T], getClient func(context.Context) (*
 						selfOverride = "after"
 					}
 				case PaginationPage:
-					if page != 1 || cmd.Flags().Changed("page") {
+					if afterID != "" {
+						selfOverride = "after-id"
+					} else if page != 1 || cmd.Flags().Changed("page") {
 						selfOverride = "page"
 					}
 				}
-				links := map[string]string{"self": buildCommandLink(cmd, mode, page, pageSize, after, limit, selfOverride)}
-				if result.HasMore && len(result.Items) > 0 {
+				selfAfter := after
+				if afterID != "" {
+					selfAfter = afterID
+				}
+				links := map[string]string{"self": buildCommandLink(cmd, mode, page, pageSize, selfAfter, limit, selfOverride)}
+				if result.HasMore && len(result.Items) > 0 && partialErr == nil && !truncated {
 					switch mode {
 					case PaginationCursor:
 						if cfg.IDFunc != nil {
@@ -262,6 +478,12 @@ func NewListCommand[T any](cfg ListConfig[T], getClient func(context.Context) (*
 							links["next"] = buildCommandLink(cmd, mode, page, pageSize, lastID, limit, "after")
 						}
 					case PaginationPage:
+						if afterID != "" && cfg.IDFunc != nil {
+							lastID := cfg.IDFunc(result.Items[len(result.Items)-1])
+							output["next_cursor"] = lastID
+							links["next"] = buildCommandLink(cmd, mode, page, pageSize, lastID, limit, "after-id")
+							break
+						}
 						output["next_page"] = page + 1
 						links["next"] = buildCommandLink(cmd, mode, page+1, pageSize, after, limit, "page")
 					}
@@ -272,9 +494,15 @@ func NewListCommand[T any](cfg ListConfig[T], getClient func(context.Context) (*
 				// Only emit links that are actionable (avoid empty self if this command
 				// isn't rooted under "airwallex" in tests/embedding).
 				if len(links) > 0 && cmd.Root() != nil && cmd.Root().Use != "" {
-					return f.OutputAnnotated(output, links)
+					if err := f.OutputAnnotated(output, links); err != nil {
+						return err
+					}
+					return partialErr
 				}
-				return f.Output(output)
+				if err := f.Output(output); err != nil {
+					return err
+				}
+				return partialErr
 			}
 
 			// Use OutputListWithColors for consistent sort/limit handling
@@ -284,15 +512,18 @@ func NewListCommand[T any](cfg ListConfig[T], getClient func(context.Context) (*
 				if !ok {
 					return []string{fmt.Sprintf("<%T>", item)}
 				}
-				return cfg.RowFunc(t)
+				return rowFunc(t)
 			}
 
-			if err := f.OutputListWithColors(result.Items, cfg.Headers, cfg.ColumnTypes, rowFn); err != nil {
+			if err := f.OutputListWithColors(result.Items, headers, columnTypes, rowFn); err != nil {
 				return err
 			}
 
 			// Show pagination hint for text output
-			if result.HasMore {
+			if partialErr != nil {
+				return partialErr
+			}
+			if result.HasMore && !truncated {
 				if cfg.MoreHint != "" {
 					fmt.Fprintln(os.Stderr, cfg.MoreHint)
 				} else {
@@ -303,6 +534,11 @@ func NewListCommand[T any](cfg ListConfig[T], getClient func(context.Context) (*
 							fmt.Fprintf(os.Stderr, "# More results available. Next page: --after %s\n", lastID)
 						}
 					case PaginationPage:
+						if afterID != "" && cfg.IDFunc != nil {
+							lastID := cfg.IDFunc(result.Items[len(result.Items)-1])
+							fmt.Fprintf(os.Stderr, "# More results available. Next page: --after-id %s\n", lastID)
+							break
+						}
 						fmt.Fprintf(os.Stderr, "# More results available. Next page: --page %d\n", page+1)
 					}
 				}
@@ -327,23 +563,116 @@ func NewListCommand[T any](cfg ListConfig[T], getClient func(context.Context) (*
 		cmd.Flags().IntVarP(&page, "page", "p", 1, "Page number (1+)")
 		cmd.Flags().IntVarP(&pageSize, "page-size", "n", 20, "Page size (1-100)")
 		flagAlias(cmd.Flags(), "page-size", "ps")
+		if cfg.AfterID {
+			cmd.Flags().StringVar(&afterID, "after-id", "", "Resume listing after this item ID (stable alternative to --page)")
+		}
 	default:
 		panic(fmt.Sprintf("unsupported pagination mode %q", mode))
 	}
 	cmd.Flags().BoolVarP(&fetchAll, "all", "a", false, "Fetch all pages (auto-paginate)")
+	cmd.Flags().IntVar(&maxPages, "max-pages", 0, "With --all, stop after N pages and mark the result truncated (0 = no cap)")
+	cmd.Flags().IntVar(&maxItems, "max-items", 0, "With --all, stop after N items and mark the result truncated (0 = no cap)")
+	cmd.Flags().BoolVar(&partialOK, "partial-ok", false, "With --all, output pages fetched before a mid-pagination error (still exits non-zero)")
 	cmd.Flags().BoolVarP(&itemsOnlyFlag, "items-only", "i", false, "Output only the items/results array when present (JSON output)")
 	cmd.Flags().BoolVar(&itemsOnlyFlag, "results-only", false, "Alias for --items-only")
 	flagAlias(cmd.Flags(), "items-only", "io")
 	flagAlias(cmd.Flags(), "results-only", "ro")
 
+	cmd.Flags().IntVar(&chunkSize, "chunk-size", 0, "Write items to JSON files of at most N items each (with --chunk-file)")
+	cmd.Flags().StringVar(&chunkFile, "chunk-file", "", "Base path for --chunk-size files (out.json writes out.0.json, out.1.json, ...)")
+
 	if cfg.LightFunc != nil {
 		cmd.Flags().BoolVar(&lightFlag, "light", false, "Minimal JSON payload (saves tokens)")
 		flagAlias(cmd.Flags(), "light", "li")
 	}
 
+	if len(cfg.Columns) > 0 {
+		cmd.Flags().StringSliceVar(&columns, "columns", nil, "Table columns to show, in order, or \"all\" (available: "+strings.Join(listColumnNames(cfg.Columns), ", ")+")")
+	}
+
 	return cmd
 }
 
+// selectListColumns resolves --columns names (case-insensitive, "-" and "_"
+// alike) to columns in the order given; "all" selects every column.
+func selectListColumns[T any](available []ListColumn[T], names []string) ([]ListColumn[T], error) {
+	if len(names) == 1 && strings.EqualFold(strings.TrimSpace(names[0]), "all") {
+		return available, nil
+	}
+	selected := make([]ListColumn[T], 0, len(names))
+	for _, name := range names {
+		key := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", "_")
+		found := false
+		for _, c := range available {
+			if c.Name == key {
+				selected = append(selected, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown column %q (valid: %s, or all)", name, strings.Join(listColumnNames(available), ", "))
+		}
+	}
+	return selected, nil
+}
+
+func listColumnNames[T any](columns []ListColumn[T]) []string {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// listColumnsTable turns selected columns into the headers, column types,
+// and row function the table writer expects.
+func listColumnsTable[T any](columns []ListColumn[T]) ([]string, []outfmt.ColumnType, func(T) []string) {
+	headers := make([]string, len(columns))
+	types := make([]outfmt.ColumnType, len(columns))
+	for i, c := range columns {
+		headers[i] = c.Header
+		if headers[i] == "" {
+			headers[i] = strings.ToUpper(c.Name)
+		}
+		types[i] = c.Type
+	}
+	rowFunc := func(item T) []string {
+		row := make([]string, len(columns))
+		for i, c := range columns {
+			row[i] = c.Value(item)
+		}
+		return row
+	}
+	return headers, types, rowFunc
+}
+
+// writeJSONChunks writes items as JSON arrays of at most size items each.
+// The chunk index goes before base's extension: out.json becomes out.0.json,
+// out.1.json, and so on. It returns the paths written, in order.
+func writeJSONChunks(base string, items []any, size int) ([]string, error) {
+	ext := filepath.Ext(base)
+	if ext == "" {
+		ext = ".json"
+	}
+	stem := strings.TrimSuffix(base, filepath.Ext(base))
+
+	files := make([]string, 0, (len(items)+size-1)/size)
+	for start := 0; start < len(items); start += size {
+		end := min(start+size, len(items))
+		path := fmt.Sprintf("%s.%d%s", stem, len(files), ext)
+		data, err := json.MarshalIndent(items[start:end], "", "  ")
+		if err != nil {
+			return files, err
+		}
+		if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+			return files, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		files = append(files, path)
+	}
+	return files, nil
+}
+
 func buildCommandLink(cmd *cobra.Command, mode PaginationMode, page, pageSize int, after string, limit int, override string) string {
 	omit := map[string]bool{
 		"help":         true,
@@ -353,6 +682,7 @@ func buildCommandLink(cmd *cobra.Command, mode PaginationMode, page, pageSize in
 		"page":      true,
 		"page-size": true,
 		"after":     true,
+		"after-id":  true,
 		"limit":     true,
 	}
 
@@ -371,8 +701,11 @@ func buildCommandLink(cmd *cobra.Command, mode PaginationMode, page, pageSize in
 			overrides["limit"] = fmt.Sprintf("%d", limit)
 		}
 	case PaginationPage:
-		if override == "page" {
+		switch override {
+		case "page":
 			overrides["page"] = fmt.Sprintf("%d", page)
+		case "after-id":
+			overrides["after-id"] = after
 		}
 		if cmd.Flags().Changed("page-size") {
 			overrides["page-size"] = fmt.Sprintf("%d", pageSize)
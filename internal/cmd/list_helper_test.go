@@ -5,6 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -847,3 +852,358 @@ func TestNewListCommand_CustomFlagsCapture(t *testing.T) {
 		t.Errorf("expected captured status 'SETTLED', got '%s'", capturedStatus)
 	}
 }
+
+func TestNewListCommand_AllPartialOK(t *testing.T) {
+	newCmd := func() *cobra.Command {
+		cfg := ListConfig[testItem]{
+			Use:          "test",
+			Short:        "Test list command",
+			Headers:      []string{"ID", "NAME"},
+			EmptyMessage: "No items",
+			RowFunc: func(item testItem) []string {
+				return []string{item.ID, item.Name}
+			},
+			Fetch: func(ctx context.Context, client *api.Client, opts ListOptions) (ListResult[testItem], error) {
+				if opts.Page == 2 {
+					return ListResult[testItem]{}, errors.New("temporary upstream error")
+				}
+				return ListResult[testItem]{
+					Items:   []testItem{{ID: "1", Name: "Item 1"}, {ID: "2", Name: "Item 2"}},
+					HasMore: true,
+				}, nil
+			},
+		}
+		return NewListCommand(cfg, func(ctx context.Context) (*api.Client, error) {
+			return &api.Client{}, nil
+		})
+	}
+
+	t.Run("without --partial-ok discards pages", func(t *testing.T) {
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: &bytes.Buffer{}})
+		cmd := newCmd()
+		cmd.SetContext(outfmt.WithFormat(ctx, "json"))
+		cmd.SetArgs([]string{"--all"})
+		if err := cmd.Execute(); err == nil {
+			t.Fatal("expected error")
+		}
+		if out.Len() != 0 {
+			t.Errorf("expected no output, got %q", out.String())
+		}
+	})
+
+	t.Run("with --partial-ok emits page 1 and warns", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: &errOut})
+		cmd := newCmd()
+		cmd.SetContext(outfmt.WithFormat(ctx, "json"))
+		cmd.SetArgs([]string{"--all", "--partial-ok"})
+
+		err := cmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), "temporary upstream error") {
+			t.Fatalf("expected non-nil error wrapping the page failure, got %v", err)
+		}
+		if !strings.Contains(errOut.String(), "warning: partial results: stopped after 2 items") {
+			t.Errorf("expected warning on stderr, got %q", errOut.String())
+		}
+
+		var got struct {
+			Items    []testItem `json:"items"`
+			HasMore  bool       `json:"has_more"`
+			Partial  bool       `json:"partial"`
+			NextPage *int       `json:"next_page"`
+		}
+		if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+			t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+		}
+		if len(got.Items) != 2 || got.Items[0].ID != "1" {
+			t.Errorf("expected page 1 items, got %+v", got.Items)
+		}
+		if !got.Partial || !got.HasMore {
+			t.Errorf("expected partial=true has_more=true, got partial=%v has_more=%v", got.Partial, got.HasMore)
+		}
+		if got.NextPage != nil {
+			t.Errorf("expected no next_page for partial results, got %d", *got.NextPage)
+		}
+	})
+
+	t.Run("--partial-ok requires --all", func(t *testing.T) {
+		cmd := newCmd()
+		cmd.SetContext(outfmt.WithFormat(context.Background(), "json"))
+		cmd.SetArgs([]string{"--partial-ok"})
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "requires --all") {
+			t.Errorf("expected --all requirement error, got %v", err)
+		}
+	})
+}
+
+func TestNewListCommand_AllMaxPagesTruncated(t *testing.T) {
+	// Three pages of two items each.
+	newCmd := func() *cobra.Command {
+		cfg := ListConfig[testItem]{
+			Use:          "test",
+			Short:        "Test list command",
+			Headers:      []string{"ID", "NAME"},
+			EmptyMessage: "No items",
+			RowFunc: func(item testItem) []string {
+				return []string{item.ID, item.Name}
+			},
+			Fetch: func(ctx context.Context, client *api.Client, opts ListOptions) (ListResult[testItem], error) {
+				id := strconv.Itoa(opts.Page)
+				return ListResult[testItem]{
+					Items:   []testItem{{ID: id + "a"}, {ID: id + "b"}},
+					HasMore: opts.Page < 3,
+				}, nil
+			},
+		}
+		return NewListCommand(cfg, func(ctx context.Context) (*api.Client, error) {
+			return &api.Client{}, nil
+		})
+	}
+	run := func(format string, args ...string) (out, errOut string) {
+		t.Helper()
+		var o, e bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &o, ErrOut: &e})
+		cmd := newCmd()
+		cmd.SetContext(outfmt.WithFormat(ctx, format))
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+		return o.String(), e.String()
+	}
+	type envelope struct {
+		Items     []testItem `json:"items"`
+		HasMore   bool       `json:"has_more"`
+		Truncated *bool      `json:"truncated"`
+		NextPage  *int       `json:"next_page"`
+	}
+
+	out, _ := run("json", "--all", "--max-pages", "2")
+	var capped envelope
+	if err := json.Unmarshal([]byte(out), &capped); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if len(capped.Items) != 4 || capped.Truncated == nil || !*capped.Truncated || !capped.HasMore {
+		t.Errorf("capped fetch = %d items truncated=%v has_more=%v, want 4 items truncated and has_more", len(capped.Items), capped.Truncated, capped.HasMore)
+	}
+	if capped.NextPage != nil {
+		t.Errorf("expected no next_page for truncated results, got %d", *capped.NextPage)
+	}
+
+	out, _ = run("json", "--all")
+	var full envelope
+	if err := json.Unmarshal([]byte(out), &full); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if len(full.Items) != 6 || full.Truncated == nil || *full.Truncated || full.HasMore {
+		t.Errorf("full fetch = %d items truncated=%v has_more=%v, want 6 items, not truncated", len(full.Items), full.Truncated, full.HasMore)
+	}
+
+	// Without the envelope the cap is reported on stderr.
+	if _, errOut := run("text", "--all", "--max-pages", "1"); !strings.Contains(errOut, "warning: results truncated after 1 page ") {
+		t.Errorf("expected truncation warning, got %q", errOut)
+	}
+
+	cmd := newCmd()
+	cmd.SetContext(outfmt.WithFormat(context.Background(), "json"))
+	cmd.SetArgs([]string{"--max-pages", "2"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "requires --all") {
+		t.Errorf("expected --all requirement error, got %v", err)
+	}
+}
+
+func TestNewListCommand_AllMaxItems(t *testing.T) {
+	// Three pages of two items each.
+	var fetches int
+	newCmd := func() *cobra.Command {
+		fetches = 0
+		cfg := ListConfig[testItem]{
+			Use:          "test",
+			Short:        "Test list command",
+			Headers:      []string{"ID", "NAME"},
+			EmptyMessage: "No items",
+			RowFunc: func(item testItem) []string {
+				return []string{item.ID, item.Name}
+			},
+			Fetch: func(ctx context.Context, client *api.Client, opts ListOptions) (ListResult[testItem], error) {
+				fetches++
+				id := strconv.Itoa(opts.Page)
+				return ListResult[testItem]{
+					Items:   []testItem{{ID: id + "a"}, {ID: id + "b"}},
+					HasMore: opts.Page < 3,
+				}, nil
+			},
+		}
+		return NewListCommand(cfg, func(ctx context.Context) (*api.Client, error) {
+			return &api.Client{}, nil
+		})
+	}
+	run := func(args ...string) (out, errOut string, err error) {
+		t.Helper()
+		var o, e bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &o, ErrOut: &e})
+		cmd := newCmd()
+		cmd.SetContext(outfmt.WithFormat(ctx, "json"))
+		cmd.SetArgs(args)
+		err = cmd.Execute()
+		return o.String(), e.String(), err
+	}
+
+	out, errOut, err := run("--all", "--max-items", "3", "--items-only")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	var items []testItem
+	if err := json.Unmarshal([]byte(out), &items); err != nil {
+		t.Fatalf("--items-only output is not a JSON array: %v\n%s", err, out)
+	}
+	if got := len(items); got != 3 || items[2].ID != "2a" {
+		t.Errorf("got %d items %v, want the first 3", got, items)
+	}
+	if fetches != 2 {
+		t.Errorf("fetched %d pages, want 2", fetches)
+	}
+	if !strings.Contains(errOut, "warning: results truncated at 3 items (--max-items)") {
+		t.Errorf("expected truncation warning, got %q", errOut)
+	}
+
+	out, errOut, err = run("--all", "--max-items", "10", "--items-only")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	items = nil
+	if err := json.Unmarshal([]byte(out), &items); err != nil {
+		t.Fatalf("--items-only output is not a JSON array: %v\n%s", err, out)
+	}
+	if len(items) != 6 || errOut != "" {
+		t.Errorf("uncapped fetch = %d items, stderr %q; want 6 items and no warning", len(items), errOut)
+	}
+
+	if _, _, err := run("--max-items", "3"); err == nil || !strings.Contains(err.Error(), "requires --all") {
+		t.Errorf("expected --all requirement error, got %v", err)
+	}
+}
+
+func TestNewListCommand_ChunkSize(t *testing.T) {
+	cfg := ListConfig[testItem]{
+		Use:          "test",
+		Short:        "Test list command",
+		Headers:      []string{"ID", "NAME"},
+		EmptyMessage: "No items",
+		RowFunc: func(item testItem) []string {
+			return []string{item.ID, item.Name}
+		},
+		Fetch: func(ctx context.Context, client *api.Client, opts ListOptions) (ListResult[testItem], error) {
+			items := make([]testItem, 5)
+			for i := range items {
+				items[i] = testItem{ID: strconv.Itoa(i + 1), Name: "Item " + strconv.Itoa(i+1)}
+			}
+			return ListResult[testItem]{Items: items}, nil
+		},
+	}
+	cmd := NewListCommand(cfg, func(ctx context.Context) (*api.Client, error) {
+		return &api.Client{}, nil
+	})
+
+	dir := t.TempDir()
+	var out bytes.Buffer
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: &bytes.Buffer{}})
+	cmd.SetContext(outfmt.WithFormat(ctx, "json"))
+	cmd.SetArgs([]string{"--chunk-size", "2", "--chunk-file", filepath.Join(dir, "out.json")})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantSizes := []int{2, 2, 1}
+	var ids []string
+	for i, want := range wantSizes {
+		data, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("out.%d.json", i)))
+		if err != nil {
+			t.Fatalf("chunk %d not written: %v", i, err)
+		}
+		var chunk []testItem
+		if err := json.Unmarshal(data, &chunk); err != nil {
+			t.Fatalf("chunk %d is not a JSON array: %v", i, err)
+		}
+		if len(chunk) != want {
+			t.Errorf("chunk %d has %d items, want %d", i, len(chunk), want)
+		}
+		for _, it := range chunk {
+			ids = append(ids, it.ID)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "out.3.json")); !os.IsNotExist(err) {
+		t.Errorf("unexpected fourth chunk file (err=%v)", err)
+	}
+	if strings.Join(ids, ",") != "1,2,3,4,5" {
+		t.Errorf("chunked IDs = %v, want 1..5 in order", ids)
+	}
+
+	var summary struct {
+		Files []string `json:"files"`
+		Items int      `json:"items"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &summary); err != nil {
+		t.Fatalf("summary is not JSON: %v\n%s", err, out.String())
+	}
+	if len(summary.Files) != 3 || summary.Items != 5 {
+		t.Errorf("summary = %+v, want 3 files and 5 items", summary)
+	}
+}
+
+func TestNewListCommand_Columns(t *testing.T) {
+	cfg := ListConfig[testItem]{
+		Use:   "test",
+		Short: "Test list command",
+		Columns: []ListColumn[testItem]{
+			{Name: "id", Header: "ITEM_ID", Value: func(item testItem) string { return item.ID }},
+			{Name: "name", Value: func(item testItem) string { return item.Name }},
+			{Name: "name_length", Value: func(item testItem) string { return strconv.Itoa(len(item.Name)) }},
+		},
+		DefaultColumns: []string{"id", "name"},
+		Fetch: func(ctx context.Context, client *api.Client, opts ListOptions) (ListResult[testItem], error) {
+			return ListResult[testItem]{Items: []testItem{{ID: "1", Name: "One"}}}, nil
+		},
+	}
+
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		cmd := NewListCommand(cfg, func(ctx context.Context) (*api.Client, error) {
+			return &api.Client{}, nil
+		})
+		ctx := outfmt.WithFormat(context.Background(), "csv")
+		ctx = iocontext.WithIO(ctx, &iocontext.IO{Out: &out, ErrOut: &bytes.Buffer{}, In: strings.NewReader("")})
+		cmd.SetContext(ctx)
+		cmd.SetOut(&out)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, "ITEM_ID,NAME\n1,One\n"},
+		{[]string{"--columns", "name_length,ID"}, "NAME_LENGTH,ITEM_ID\n3,1\n"},
+		{[]string{"--columns", "name-length"}, "NAME_LENGTH\n3\n"},
+		{[]string{"--columns", "all"}, "ITEM_ID,NAME,NAME_LENGTH\n1,One,3\n"},
+	}
+	for _, tt := range tests {
+		got, err := run(tt.args...)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tt.args, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%v: output = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+
+	_, err := run("--columns", "id,bogus")
+	if err == nil || !strings.Contains(err.Error(), `unknown column "bogus" (valid: id, name, name_length, or all)`) {
+		t.Errorf("unknown column error = %v", err)
+	}
+}
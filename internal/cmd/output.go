@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"os"
 
@@ -9,6 +10,7 @@ import (
 
 	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
 	"github.com/salmonumbrella/airwallex-cli/internal/outfmt"
+	"github.com/salmonumbrella/airwallex-cli/internal/ui"
 )
 
 func writeJSONOutput(cmd *cobra.Command, value interface{}) error {
@@ -26,3 +28,37 @@ func commandOutputWriter(cmd *cobra.Command) io.Writer {
 	}
 	return out
 }
+
+// transition is the --show-transition JSON envelope: a resource as fetched
+// just before a state-changing call, and as that call returned it.
+type transition[T any] struct {
+	Before T `json:"before"`
+	After  T `json:"after"`
+}
+
+// warnf writes a "warning: " line to w and records the message for
+// --warnings-in-json.
+func warnf(ctx context.Context, w io.Writer, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	if warnings := outfmt.GetWarnings(ctx); warnings != nil {
+		warnings.Add(msg)
+	}
+	_, _ = fmt.Fprintf(w, "warning: %s\n", msg)
+}
+
+// noDetailsResult is the JSON output of a create that succeeded with an
+// empty response body, leaving no resource to print.
+type noDetailsResult struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// writeNoDetailsResult reports a create that succeeded without returning the
+// new resource, as msg in text mode or a noDetailsResult in JSON.
+func writeNoDetailsResult(cmd *cobra.Command, msg string) error {
+	if outfmt.IsJSON(cmd.Context()) {
+		return writeJSONOutput(cmd, noDetailsResult{Success: true, Message: msg})
+	}
+	ui.FromContext(cmd.Context()).Success(msg)
+	return nil
+}
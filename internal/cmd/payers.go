@@ -98,7 +98,7 @@ func newPayersGetCmd() *cobra.Command {
 				{Key: "status", Value: payer.Status},
 				{Key: "created_at", Value: payer.CreatedAt},
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}, getClient)
 }
@@ -119,6 +119,10 @@ Examples:
 		SuccessMessage: func(payer *api.Payer) string {
 			return fmt.Sprintf("Created payer: %s", payerID(*payer))
 		},
+		ID: func(payer *api.Payer) string {
+			return payerID(*payer)
+		},
+		NoDetails: "Created payer (the API returned no details; find it with 'airwallex payers list')",
 	}, getClient)
 }
 
@@ -24,6 +24,12 @@ type PayloadCommandConfig[T any] struct {
 	ReadPayload    func(data, fromFile string) (map[string]interface{}, error)
 	Run            func(ctx context.Context, client *api.Client, args []string, payload map[string]interface{}) (T, error)
 	SuccessMessage func(T) string
+
+	// ID, set on creates, returns the new resource's ID. A blank ID means the
+	// API succeeded with an empty body, and NoDetails is reported instead of
+	// the empty result.
+	ID        func(T) string
+	NoDetails string
 }
 
 // NewPayloadCommand builds a command that reads a JSON payload and executes a request.
@@ -62,6 +68,9 @@ func NewPayloadCommand[T any](cfg PayloadCommandConfig[T], getClient func(contex
 			if err != nil {
 				return err
 			}
+			if cfg.ID != nil && cfg.ID(result) == "" {
+				return writeNoDetailsResult(cmd, cfg.NoDetails)
+			}
 
 			if outfmt.IsJSON(cmd.Context()) {
 				return writeJSONOutput(cmd, result)
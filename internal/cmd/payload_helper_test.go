@@ -47,6 +47,43 @@ func TestNewPayloadCommand_JSONUsesContextIO(t *testing.T) {
 	}
 }
 
+func TestNewPayloadCommand_BlankIDReportsNoDetails(t *testing.T) {
+	for _, id := range []string{"", "thing_1"} {
+		var outBuf bytes.Buffer
+		ctx := iocontext.WithIO(outfmt.WithFormat(context.Background(), "json"), &iocontext.IO{Out: &outBuf, ErrOut: &bytes.Buffer{}, In: strings.NewReader("")})
+
+		cmd := NewPayloadCommand(PayloadCommandConfig[map[string]any]{
+			Use:   "test",
+			Short: "Test",
+			Run: func(ctx context.Context, client *api.Client, args []string, payload map[string]interface{}) (map[string]any, error) {
+				if id == "" {
+					return map[string]any{}, nil
+				}
+				return map[string]any{"id": id}, nil
+			},
+			ID: func(result map[string]any) string {
+				s, _ := result["id"].(string)
+				return s
+			},
+			NoDetails: "Created thing (the API returned no details)",
+		}, func(context.Context) (*api.Client, error) {
+			return &api.Client{}, nil
+		})
+
+		cmd.SetContext(ctx)
+		cmd.SetArgs([]string{"--data", "{}"})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("command failed: %v", err)
+		}
+
+		output := outBuf.String()
+		noDetails := strings.Contains(output, `"message": "Created thing (the API returned no details)"`)
+		if noDetails != (id == "") {
+			t.Errorf("id %q: output = %q", id, output)
+		}
+	}
+}
+
 func TestNewPayloadCommand_RunError(t *testing.T) {
 	expectedErr := errors.New("run failed")
 
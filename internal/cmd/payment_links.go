@@ -3,7 +3,6 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
 
 	"github.com/spf13/cobra"
 
@@ -77,7 +76,7 @@ func newPaymentLinksGetCmd() *cobra.Command {
 			if pl.ExpiresAt != "" {
 				rows = append(rows, outfmt.KV{Key: "expires_at", Value: pl.ExpiresAt})
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}, getClient)
 }
@@ -144,7 +143,7 @@ Examples:
 			}
 
 			u.Success(fmt.Sprintf("Created payment link: %s", pl.ID))
-			_, _ = fmt.Fprintf(os.Stdout, "URL: %s\n", pl.URL)
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "URL: %s\n", pl.URL)
 			return nil
 		},
 	}
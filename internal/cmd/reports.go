@@ -99,7 +99,7 @@ func newReportsGetCmd() *cobra.Command {
 			if r.ErrorMessage != "" {
 				rows = append(rows, outfmt.KV{Key: "error_message", Value: r.ErrorMessage})
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}, getClient)
 }
@@ -3,7 +3,10 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -71,7 +74,7 @@ func TestNewGetCommand_TextOutputWithTextOutput(t *testing.T) {
 		},
 		TextOutput: func(cmd *cobra.Command, item *testResource) error {
 			textOutputCalled = true
-			return outfmt.WriteKV(cmd.OutOrStdout(), []outfmt.KV{
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), []outfmt.KV{
 				{Key: "id", Value: item.ID},
 				{Key: "name", Value: item.Name},
 			})
@@ -269,3 +272,47 @@ func TestNewGetCommand_TextOutputError(t *testing.T) {
 		t.Errorf("expected error %v, got %v", expectedErr, err)
 	}
 }
+
+func TestGetCommands_EmitBareResourceJSON(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	// One endpoint returns the bare resource, the other wraps it in an envelope.
+	testMockServer.Handle("GET", "/api/v1/transfers/tfr_bare", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"tfr_bare","status":"PAID"}`))
+	})
+	testMockServer.Handle("GET", "/api/v1/issuing/cards/card_wrapped", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"card":{"card_id":"card_wrapped","card_status":"ACTIVE"}}`))
+	})
+
+	tests := []struct {
+		args    []string
+		idKey   string
+		idValue string
+	}{
+		{args: []string{"transfers", "get", "tfr_bare"}, idKey: "id", idValue: "tfr_bare"},
+		{args: []string{"issuing", "cards", "get", "card_wrapped"}, idKey: "card_id", idValue: "card_wrapped"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			var out bytes.Buffer
+			ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+			root := NewRootCmd()
+			root.SetArgs(append(tt.args, "--output", "json"))
+			if err := root.ExecuteContext(ctx); err != nil {
+				t.Fatalf("command failed: %v", err)
+			}
+
+			var got map[string]interface{}
+			if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+				t.Fatalf("output is not a JSON object: %v\n%s", err, out.String())
+			}
+			if got[tt.idKey] != tt.idValue {
+				t.Errorf("expected bare resource with %s=%q at top level, got %v", tt.idKey, tt.idValue, got)
+			}
+		})
+	}
+}
@@ -6,17 +6,23 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/salmonumbrella/airwallex-cli/internal/api"
+	"github.com/salmonumbrella/airwallex-cli/internal/config"
 	"github.com/salmonumbrella/airwallex-cli/internal/debug"
+	"github.com/salmonumbrella/airwallex-cli/internal/dryrun"
 	"github.com/salmonumbrella/airwallex-cli/internal/exitcode"
 	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
 	"github.com/salmonumbrella/airwallex-cli/internal/outfmt"
+	"github.com/salmonumbrella/airwallex-cli/internal/redact"
 	"github.com/salmonumbrella/airwallex-cli/internal/ui"
 )
 
@@ -41,6 +47,89 @@ type rootFlags struct {
 	OutputLimit int    // limit number of results in output (0 = no limit)
 	SortBy      string // field name to sort by
 	Desc        bool   // sort descending (only valid with --sort-by)
+	GroupBy     string // table column to group text output by
+	NoHeaders   bool   // omit the header row from table and CSV output
+	// TrimZeroDecimals shows table amounts like 1000.00 as 1000 in text output.
+	TrimZeroDecimals bool
+	// YAMLDocuments writes YAML lists as one "---" document per item.
+	YAMLDocuments bool
+	// OnlyFields/OmitFields keep or drop dot-path fields in structured output.
+	OnlyFields []string
+	OmitFields []string
+	// MoneyObjects groups <x>_amount/<x>_currency pairs into {amount, currency} objects in JSON output.
+	MoneyObjects bool
+	// Retry behaviour
+	RetryIdempotent5xx bool  // retry POST once on 5xx when an idempotency key is set
+	RetryStatus        []int // override retryable HTTP statuses (default 429 and 5xx)
+	// MaxRetries overrides the 429 and 5xx retry counts when maxRetriesSet
+	// (from --max-retries or AWX_MAX_RETRIES).
+	MaxRetries    int
+	maxRetriesSet bool
+	// RateLimit caps outbound requests per second (0 = unlimited).
+	RateLimit float64
+	// RequestTimeout bounds each HTTP attempt; timed-out idempotent attempts are retried.
+	RequestTimeout time.Duration
+	// CommandTimeout bounds the whole command, retries included.
+	CommandTimeout time.Duration
+	cancelTimeout  context.CancelFunc
+	// APIDateVersion overrides the pinned x-api-version ("latest" omits it).
+	APIDateVersion string
+	// Proxy overrides HTTP_PROXY/HTTPS_PROXY/NO_PROXY for API requests.
+	Proxy string
+	// CACerts are PEM files trusted in addition to the system roots.
+	CACerts []string
+	// Debugging
+	ShowURL bool // print each resolved request URL to stderr
+	// ShowIdempotencyKey prints the x-idempotency-key of financial creates to stderr.
+	ShowIdempotencyKey bool
+	// DryRun prints mutating requests instead of sending them.
+	DryRun bool
+	Stats  bool // print request latency and retry counts to stderr
+	// NoTrailingNewline drops the final newline from stdout output.
+	NoTrailingNewline bool
+	// MaskIDs hashes resource IDs in text output, debug logs, and errors (JSON stays unmasked).
+	MaskIDs bool
+	// OutputFile receives the primary result output instead of stdout.
+	OutputFile string
+	// Pluck prints only the value at this dot-path of each result record.
+	Pluck string
+	// WarningsInJSON adds a "warnings" array to structured output objects.
+	WarningsInJSON bool
+
+	stats   *api.RequestStats            // collector shared by every client built for this run
+	outTrim *iocontext.TrimNewlineWriter // stdout wrapper for --no-trailing-newline
+	outFile *os.File                     // destination opened for --output-file
+}
+
+// finish releases what PersistentPreRunE set up for --command-timeout,
+// --no-trailing-newline, and --output-file, then writes the --stats report
+// to errOut. It runs once the command returns, whether or not it failed,
+// and does nothing when called again.
+func (f *rootFlags) finish(errOut io.Writer) error {
+	var errs []error
+	if f.cancelTimeout != nil {
+		f.cancelTimeout()
+		f.cancelTimeout = nil
+	}
+	if f.outTrim != nil {
+		if err := f.outTrim.Close(); err != nil {
+			errs = append(errs, err)
+		}
+		f.outTrim = nil
+	}
+	if f.outFile != nil {
+		if err := f.outFile.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("--output-file: %w", err))
+		}
+		f.outFile = nil
+	}
+	if f.stats != nil {
+		if err := f.stats.Write(errOut); err != nil {
+			errs = append(errs, err)
+		}
+		f.stats = nil
+	}
+	return errors.Join(errs...)
 }
 
 type rootFlagsKey struct{}
@@ -54,6 +143,33 @@ func rootFlagsFromContext(ctx context.Context) (*rootFlags, bool) {
 	return f, ok
 }
 
+// commandTimeoutError is the cause of a --command-timeout deadline. It matches
+// context.DeadlineExceeded so callers checking for a deadline still see one.
+type commandTimeoutError struct {
+	timeout time.Duration
+}
+
+func (e *commandTimeoutError) Error() string {
+	return fmt.Sprintf("timed out after %s (--command-timeout)", e.timeout)
+}
+
+func (e *commandTimeoutError) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
+// explainTimeout names the --command-timeout that cut err short, unless err already
+// carries it (net/http reports the context cause itself).
+func explainTimeout(ctx context.Context, err error) error {
+	var timeoutErr *commandTimeoutError
+	if err == nil || !errors.Is(err, context.DeadlineExceeded) || errors.As(err, &timeoutErr) {
+		return err
+	}
+	if errors.As(context.Cause(ctx), &timeoutErr) {
+		return fmt.Errorf("%w: %w", timeoutErr, err)
+	}
+	return err
+}
+
 func binaryName() string {
 	if len(os.Args) > 0 {
 		return filepath.Base(os.Args[0])
@@ -69,7 +185,13 @@ func NewRootCmd() *cobra.Command {
 		Long:         "A command-line interface for the Airwallex API.",
 		Version:      Version,
 		SilenceUsage: true,
-		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer func() {
+				if err != nil {
+					_ = flags.finish(iocontext.GetIO(cmd.Context()).ErrOut)
+				}
+			}()
+
 			// Agent mode defaults: stable JSON output, no colors, and no interactive prompts.
 			// Respect explicit user choices.
 			if flags.Agent {
@@ -109,10 +231,71 @@ func NewRootCmd() *cobra.Command {
 				flags.Output = "json"
 			}
 
+			// --pluck reads from the JSON representation; in text mode the
+			// value is printed raw instead of JSON-encoded.
+			pluckRaw := false
+			if flags.Pluck != "" {
+				if flags.Query != "" || flags.QueryFile != "" || flags.Template != "" {
+					return fmt.Errorf("--pluck cannot be combined with --query, --query-file, or --template")
+				}
+				switch flags.Output {
+				case "text":
+					flags.Output, pluckRaw = "json", true
+				case "json", "jsonl":
+				default:
+					return fmt.Errorf("--pluck requires --output text, json, or jsonl")
+				}
+			}
+
 			// Validate flag combinations
+			flags.Color = strings.ToLower(strings.TrimSpace(flags.Color))
+			switch flags.Color {
+			case "auto", "always", "never":
+			default:
+				return fmt.Errorf("invalid --color %q (must be auto, always, or never)", flags.Color)
+			}
 			if flags.Desc && flags.SortBy == "" {
 				return fmt.Errorf("--desc requires --sort-by to be specified")
 			}
+			if flags.RequestTimeout < 0 {
+				return fmt.Errorf("--request-timeout must be positive")
+			}
+			if flags.CommandTimeout < 0 {
+				return fmt.Errorf("--command-timeout must be positive")
+			}
+			if flags.RateLimit < 0 {
+				return fmt.Errorf("--rate-limit must not be negative")
+			}
+			if cmd.Flags().Changed("max-retries") {
+				flags.maxRetriesSet = true
+			} else if v := os.Getenv("AWX_MAX_RETRIES"); v != "" {
+				n, err := strconv.Atoi(strings.TrimSpace(v))
+				if err != nil {
+					return fmt.Errorf("invalid AWX_MAX_RETRIES %q: must be an integer", v)
+				}
+				flags.MaxRetries = n
+				flags.maxRetriesSet = true
+			}
+			if flags.maxRetriesSet && (flags.MaxRetries < 0 || flags.MaxRetries > api.MaxRetriesLimit) {
+				return fmt.Errorf("--max-retries must be between 0 and %d", api.MaxRetriesLimit)
+			}
+			if flags.GroupBy != "" && flags.Output == "csv" {
+				return fmt.Errorf("--group-by is not supported with --output csv")
+			}
+			if flags.Template != "" {
+				if _, err := outfmt.ParseTemplate(flags.Template); err != nil {
+					return err
+				}
+			}
+			if flags.YAMLDocuments && flags.Output != "yaml" {
+				return fmt.Errorf("--yaml-documents requires --output yaml")
+			}
+			if (len(flags.OnlyFields) > 0 || len(flags.OmitFields) > 0) && !outfmt.IsJSON(outfmt.WithFormat(cmd.Context(), flags.Output)) {
+				return fmt.Errorf("--only-fields and --omit-fields require --output json, jsonl, or yaml")
+			}
+			if flags.WarningsInJSON && !outfmt.IsJSON(outfmt.WithFormat(cmd.Context(), flags.Output)) {
+				return fmt.Errorf("--warnings-in-json requires --output json, jsonl, or yaml")
+			}
 
 			// Setup debug mode
 			debug.SetupLogger(flags.Debug)
@@ -122,9 +305,44 @@ func NewRootCmd() *cobra.Command {
 			if !iocontext.HasIO(ctx) {
 				ctx = iocontext.WithIO(ctx, iocontext.DefaultIO())
 			}
+			if flags.OutputFile != "" {
+				f, err := os.OpenFile(flags.OutputFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+				if err != nil {
+					return fmt.Errorf("--output-file: %w", err)
+				}
+				flags.outFile = f
+				streams := *iocontext.GetIO(ctx)
+				streams.Out = f
+				ctx = iocontext.WithIO(ctx, &streams)
+				cmd.Root().SetOut(f)
+			}
+			if flags.MaskIDs {
+				streams := *iocontext.GetIO(ctx)
+				streams.ErrOut = redact.NewWriter(streams.ErrOut)
+				if !outfmt.IsJSON(outfmt.WithFormat(ctx, flags.Output)) {
+					streams.Out = redact.NewWriter(streams.Out)
+					cmd.Root().SetOut(redact.NewWriter(cmd.Root().OutOrStdout()))
+				}
+				ctx = iocontext.WithIO(ctx, &streams)
+				// Cobra prints command errors (which may carry request URLs) itself.
+				cmd.Root().SetErr(redact.NewWriter(cmd.Root().ErrOrStderr()))
+				debug.SetupLoggerTo(streams.ErrOut, flags.Debug)
+			}
+			if flags.NoTrailingNewline {
+				streams := *iocontext.GetIO(ctx)
+				flags.outTrim = iocontext.NewTrimNewlineWriter(streams.Out)
+				streams.Out = flags.outTrim
+				ctx = iocontext.WithIO(ctx, &streams)
+				// Commands that write through cmd.OutOrStdout() must share the
+				// one trimming writer so only the very last newline is held back.
+				cmd.Root().SetOut(flags.outTrim)
+			}
 
 			// Inject UI context
 			u := ui.New(flags.Color)
+			if flags.MaskIDs {
+				u = u.WithWriters(redact.NewWriter(os.Stdout), redact.NewWriter(os.Stderr))
+			}
 			ctx = ui.WithUI(ctx, u)
 
 			// Inject output format context
@@ -144,19 +362,58 @@ func NewRootCmd() *cobra.Command {
 			// Inject agent-friendly flags
 			ctx = outfmt.WithYes(ctx, flags.Yes)
 			ctx = outfmt.WithNoInput(ctx, flags.NoInput)
+			ctx = dryrun.WithDryRun(ctx, flags.DryRun)
 			ctx = outfmt.WithItemsOnly(ctx, flags.ItemsOnly)
 			ctx = outfmt.WithLimit(ctx, flags.OutputLimit)
 			ctx = outfmt.WithSortBy(ctx, flags.SortBy)
+			ctx = outfmt.WithGroupBy(ctx, flags.GroupBy)
+			ctx = outfmt.WithNoHeaders(ctx, flags.NoHeaders)
+			ctx = outfmt.WithTrimZeroDecimals(ctx, flags.TrimZeroDecimals)
+			ctx = outfmt.WithYAMLDocuments(ctx, flags.YAMLDocuments)
 			ctx = outfmt.WithDesc(ctx, flags.Desc)
+			ctx = outfmt.WithMoneyObjects(ctx, flags.MoneyObjects)
+			ctx = outfmt.WithFieldMask(ctx, outfmt.FieldMask{Only: flags.OnlyFields, Omit: flags.OmitFields})
+			if flags.WarningsInJSON {
+				ctx = outfmt.WithWarnings(ctx, &outfmt.Warnings{})
+			}
+			if flags.Pluck != "" {
+				ctx = outfmt.WithPluck(ctx, flags.Pluck, pluckRaw)
+			}
+
+			if flags.Stats {
+				flags.stats = &api.RequestStats{}
+			}
+
+			if flags.CommandTimeout > 0 {
+				ctx, flags.cancelTimeout = context.WithTimeoutCause(ctx, flags.CommandTimeout, &commandTimeoutError{timeout: flags.CommandTimeout})
+				if run := cmd.RunE; run != nil {
+					cmd.RunE = func(cmd *cobra.Command, args []string) error {
+						return explainTimeout(cmd.Context(), run(cmd, args))
+					}
+				}
+			}
+
+			// Cobra skips post-run hooks when RunE fails, so the cleanup and
+			// --stats report run from a RunE wrapper instead.
+			if run := cmd.RunE; run != nil {
+				cmd.RunE = func(cmd *cobra.Command, args []string) error {
+					err := run(cmd, args)
+					if finishErr := flags.finish(iocontext.GetIO(cmd.Context()).ErrOut); err == nil {
+						err = finishErr
+					}
+					return err
+				}
+			}
 
 			ctx = withRootFlags(ctx, flags)
+			ctx = api.WithHooks(ctx, invocationHooks(ctx))
 			cmd.SetContext(ctx)
 			return nil
 		},
 	}
 
 	cmd.PersistentFlags().StringVar(&flags.Account, "account", os.Getenv("AWX_ACCOUNT"), "Account name (or AWX_ACCOUNT env)")
-	cmd.PersistentFlags().StringVarP(&flags.Output, "output", "o", getEnvOrDefault("AWX_OUTPUT", "text"), "Output format: text|json|jsonl|ndjson (env AWX_OUTPUT)")
+	cmd.PersistentFlags().StringVarP(&flags.Output, "output", "o", getEnvOrDefault("AWX_OUTPUT", "text"), "Output format: text|json|jsonl|ndjson|yaml|csv (env AWX_OUTPUT)")
 	cmd.PersistentFlags().BoolVarP(&flags.JSON, "json", "j", false, "Shorthand for --output json")
 	cmd.PersistentFlags().StringVar(&flags.Color, "color", getEnvOrDefault("AWX_COLOR", "auto"), "Color output: auto|always|never")
 	cmd.PersistentFlags().BoolVar(&flags.NoColor, "no-color", false, "Shorthand for --color never")
@@ -180,6 +437,31 @@ func NewRootCmd() *cobra.Command {
 	cmd.PersistentFlags().IntVar(&flags.OutputLimit, "output-limit", 0, "Limit number of results in output (0 = no limit)")
 	cmd.PersistentFlags().StringVar(&flags.SortBy, "sort-by", "", "Sort results by field")
 	cmd.PersistentFlags().BoolVar(&flags.Desc, "desc", false, "Sort in descending order")
+	cmd.PersistentFlags().BoolVar(&flags.NoHeaders, "no-headers", false, "Omit the header row from table and CSV output")
+	cmd.PersistentFlags().BoolVar(&flags.TrimZeroDecimals, "trim-zero-decimals", false, "Show table amounts without an all-zero fraction (1000.00 as 1000) in text output")
+	cmd.PersistentFlags().BoolVar(&flags.YAMLDocuments, "yaml-documents", false, "With --output yaml, write each list item as a separate YAML document (---)")
+	cmd.PersistentFlags().StringVar(&flags.GroupBy, "group-by", "", "Group table rows by column (e.g. STATUS), with per-group counts and amount subtotals")
+	cmd.PersistentFlags().StringSliceVar(&flags.OnlyFields, "only-fields", nil, "Keep only these comma-separated dot-path fields in each JSON/YAML record (e.g. id,beneficiary.bank_details)")
+	cmd.PersistentFlags().StringSliceVar(&flags.OmitFields, "omit-fields", nil, "Remove these comma-separated dot-path fields from each JSON/YAML record (e.g. beneficiary.first_name)")
+	cmd.PersistentFlags().StringVar(&flags.Pluck, "pluck", "", "Print only the scalar at this dot-path (e.g. status), one line per list item")
+	cmd.PersistentFlags().BoolVar(&flags.WarningsInJSON, "warnings-in-json", false, "Also include warnings as a \"warnings\" array in JSON/YAML output objects")
+	cmd.PersistentFlags().BoolVar(&flags.MoneyObjects, "money-objects", false, "Group <x>_amount/<x>_currency pairs into {amount, currency} objects (JSON output)")
+	cmd.PersistentFlags().BoolVar(&flags.RetryIdempotent5xx, "retry-idempotent-5xx", false, "Retry POST requests once on 5xx when they carry an idempotency key")
+	cmd.PersistentFlags().DurationVar(&flags.RequestTimeout, "request-timeout", 0, "Timeout for each HTTP attempt, e.g. 10s; timed-out GETs are retried (0 = only the 30s client limit)")
+	cmd.PersistentFlags().DurationVar(&flags.CommandTimeout, "command-timeout", 0, "Deadline for the whole command including retries, e.g. 30s (0 = none)")
+	cmd.PersistentFlags().IntVar(&flags.MaxRetries, "max-retries", 0, "Retries for 429, 5xx, timed-out, and unreachable-host requests, 0 to fail fast (default 3 for 429, 1 for 5xx, 2 otherwise; env AWX_MAX_RETRIES)")
+	cmd.PersistentFlags().Float64Var(&flags.RateLimit, "rate-limit", 0, "Maximum API requests per second, including retries (0 = unlimited)")
+	cmd.PersistentFlags().IntSliceVar(&flags.RetryStatus, "retry-status", nil, "HTTP statuses to retry, e.g. 408,425,429,503 (default 429 and 5xx)")
+	cmd.PersistentFlags().StringVar(&flags.APIDateVersion, "api-date-version", "", "Dated Airwallex API version to send as x-api-version, e.g. 2024-09-27, or \"latest\" to omit it (default "+api.APIVersion+")")
+	cmd.PersistentFlags().StringVar(&flags.Proxy, "proxy", "", "Proxy URL for API requests, e.g. http://proxy:3128 (default from HTTPS_PROXY/HTTP_PROXY/NO_PROXY)")
+	cmd.PersistentFlags().StringArrayVar(&flags.CACerts, "ca-cert", caCertsFromEnv(), "PEM CA bundle to trust in addition to the system roots (repeatable; env AWX_CA_CERT, "+string(os.PathListSeparator)+"-separated)")
+	cmd.PersistentFlags().BoolVar(&flags.ShowURL, "show-url", false, "Print the resolved request URL (with query params) to stderr before sending")
+	cmd.PersistentFlags().BoolVar(&flags.DryRun, "dry-run", false, "Print the request a create/update/delete/cancel would send (method, URL, body) without sending it; login and GET requests still go through")
+	cmd.PersistentFlags().BoolVar(&flags.ShowIdempotencyKey, "show-idempotency-key", false, "Print the idempotency key sent with financial creates to stderr (transfers create also adds it to JSON output)")
+	cmd.PersistentFlags().BoolVar(&flags.MaskIDs, "mask-ids", false, "Mask account/beneficiary/transfer IDs in text output, debug logs, and errors (JSON is unmasked)")
+	cmd.PersistentFlags().StringVar(&flags.OutputFile, "output-file", "", "Write results to this file instead of stdout (warnings and progress stay on stderr)")
+	cmd.PersistentFlags().BoolVar(&flags.NoTrailingNewline, "no-trailing-newline", false, "Omit the final newline from output (for tools that expect exact bytes)")
+	cmd.PersistentFlags().BoolVar(&flags.Stats, "stats", false, "Print request count, latency (min/avg/max), and retry attempts to stderr when done")
 
 	// Multi-letter hidden flag aliases.
 	flagAlias(cmd.PersistentFlags(), "output", "out")
@@ -194,9 +476,13 @@ func NewRootCmd() *cobra.Command {
 	flagAlias(cmd.PersistentFlags(), "query", "jq")
 	flagAlias(cmd.PersistentFlags(), "items-only", "io")
 	flagAlias(cmd.PersistentFlags(), "results-only", "ro")
+	flagAlias(cmd.PersistentFlags(), "dry-run", "dr")
 
 	cmd.AddCommand(newAPICmd())
 	cmd.AddCommand(newAuthCmd())
+	cmd.AddCommand(newConfigCmd())
+	cmd.AddCommand(newDoctorCmd())
+	cmd.AddCommand(newStatusCmd())
 	cmd.AddCommand(newBalancesCmd())
 	cmd.AddCommand(newIssuingCmd())
 	// Desire paths: top-level shortcuts to commonly used issuing commands.
@@ -227,6 +513,7 @@ func NewRootCmd() *cobra.Command {
 	cmd.AddCommand(newCreateRouterCmd())
 	cmd.AddCommand(newCancelRouterCmd())
 	addCanonicalVerbAliases(cmd)
+	addDryRunSupport(cmd)
 
 	defaultHelp := cmd.HelpFunc()
 	cmd.SetHelpFunc(func(c *cobra.Command, args []string) {
@@ -258,6 +545,11 @@ func requireAccount(ctx context.Context) (string, error) {
 		return f.Account, nil
 	}
 
+	// Then a saved default (config accounts default <name>)
+	if settings, err := config.LoadSettings(); err == nil && settings.DefaultAccount != "" {
+		return settings.DefaultAccount, nil
+	}
+
 	// Try to auto-select if only one account is configured
 	store, err := openSecretsStore()
 	if err != nil {
@@ -281,7 +573,7 @@ func requireAccount(ctx context.Context) (string, error) {
 		for i, a := range accounts {
 			names[i] = a.Name
 		}
-		return "", fmt.Errorf("multiple accounts configured: %s\nSpecify with --account <name> or set AWX_ACCOUNT", strings.Join(names, ", "))
+		return "", fmt.Errorf("multiple accounts configured: %s\nSpecify with --account <name>, set AWX_ACCOUNT, or run: airwallex config accounts default <name>", strings.Join(names, ", "))
 	}
 }
 
@@ -330,6 +622,7 @@ func writeAgentError(ctx context.Context, err error) {
 		ExitCode   int    `json:"exit_code"`
 		HTTPStatus int    `json:"http_status,omitempty"`
 		Request    string `json:"request,omitempty"`
+		RequestID  string `json:"request_id,omitempty"`
 		APIError   string `json:"api_error,omitempty"`
 		APISource  string `json:"api_source,omitempty"`
 	}
@@ -348,6 +641,7 @@ func writeAgentError(ctx context.Context, err error) {
 	if errors.As(err, &ctxErr) && ctxErr != nil {
 		out.Error.HTTPStatus = ctxErr.StatusCode
 		out.Error.Request = fmt.Sprintf("%s %s", ctxErr.Method, ctxErr.URL)
+		out.Error.RequestID = ctxErr.RequestID
 	}
 
 	var apiErr *api.APIError
@@ -1,15 +1,24 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
 	"github.com/salmonumbrella/airwallex-cli/internal/outfmt"
+	"github.com/salmonumbrella/airwallex-cli/internal/redact"
 	"github.com/salmonumbrella/airwallex-cli/internal/ui"
 )
 
@@ -504,3 +513,416 @@ func TestRootCmd_QueryFileConflict(t *testing.T) {
 		t.Fatalf("error = %q, want to contain %q", err.Error(), "use only one of --query or --query-file")
 	}
 }
+
+func TestRootCmd_ShowURLPrintsResolvedURL(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.HandleJSON("GET", "/api/v1/transfers", http.StatusOK, map[string]any{"items": []any{}, "has_more": false})
+	defer testMockServer.HandleError("GET", "/api/v1/transfers", http.StatusNotFound, "endpoint not found")
+
+	var errOut bytes.Buffer
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: io.Discard, ErrOut: &errOut, In: strings.NewReader("")})
+	root := NewRootCmd()
+	root.SetArgs([]string{"transfers", "list", "--status", "IN REVIEW", "--page-size", "5", "--show-url", "--output", "json"})
+	if err := root.ExecuteContext(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "GET " + testMockServer.URL() + "/api/v1/transfers?page_num=1&page_size=5&status=IN+REVIEW"
+	if !strings.Contains(errOut.String(), want) {
+		t.Errorf("stderr = %q, want it to contain %q", errOut.String(), want)
+	}
+
+	errOut.Reset()
+	root = NewRootCmd()
+	root.SetArgs([]string{"transfers", "list", "--output", "json"})
+	if err := root.ExecuteContext(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(errOut.String(), "/api/v1/transfers") {
+		t.Errorf("expected no URL without --show-url, got %q", errOut.String())
+	}
+}
+
+func TestRootCmd_StatsReportedWhenCommandFails(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.HandleError("GET", "/api/v1/transfers/tfr_stats", http.StatusBadRequest, "bad request")
+	defer testMockServer.HandleError("GET", "/api/v1/transfers/tfr_stats", http.StatusNotFound, "endpoint not found")
+
+	var errOut bytes.Buffer
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: io.Discard, ErrOut: &errOut, In: strings.NewReader("")})
+	root := NewRootCmd()
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"transfers", "get", "tfr_stats", "--stats"})
+	if err := root.ExecuteContext(ctx); err == nil {
+		t.Fatal("expected the 400 response to fail the command")
+	}
+	if !strings.Contains(errOut.String(), "stats: 1 requests, 1 http calls") {
+		t.Errorf("stderr = %q, want the --stats report after a failure", errOut.String())
+	}
+}
+
+func TestRootCmd_MaxRetries(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	calls := 0
+	testMockServer.Handle("GET", "/api/v1/transfers", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"code":"too_many_requests","message":"slow down"}`))
+	})
+	defer testMockServer.HandleError("GET", "/api/v1/transfers", http.StatusNotFound, "endpoint not found")
+
+	run := func(args ...string) error {
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: io.Discard, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetArgs(append([]string{"transfers", "list", "--output", "json"}, args...))
+		return root.ExecuteContext(ctx)
+	}
+
+	t.Setenv("AWX_MAX_RETRIES", "0")
+	if err := run(); err == nil {
+		t.Fatal("expected 429 error")
+	}
+	if calls != 1 {
+		t.Errorf("AWX_MAX_RETRIES=0: calls = %d, want 1", calls)
+	}
+
+	calls = 0
+	t.Setenv("AWX_MAX_RETRIES", "5")
+	if err := run("--max-retries", "0"); err == nil {
+		t.Fatal("expected 429 error")
+	}
+	if calls != 1 {
+		t.Errorf("--max-retries 0 should override the env: calls = %d, want 1", calls)
+	}
+
+	t.Setenv("AWX_MAX_RETRIES", "lots")
+	if err := run(); err == nil || !strings.Contains(err.Error(), "AWX_MAX_RETRIES") {
+		t.Errorf("expected invalid env error, got %v", err)
+	}
+	if err := run("--max-retries", "99"); err == nil || !strings.Contains(err.Error(), "between 0 and") {
+		t.Errorf("expected range error, got %v", err)
+	}
+}
+
+func TestRootCmd_NoTrailingNewline(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.HandleJSON("GET", "/api/v1/transfers", http.StatusOK, map[string]any{"items": []any{}, "has_more": false})
+	defer testMockServer.HandleError("GET", "/api/v1/transfers", http.StatusNotFound, "endpoint not found")
+	testMockServer.HandleJSON("GET", "/api/v1/deposits/dep_123", http.StatusOK, map[string]any{"id": "dep_123", "amount": 10, "currency": "USD", "status": "SETTLED"})
+	defer testMockServer.HandleError("GET", "/api/v1/deposits/dep_123", http.StatusNotFound, "endpoint not found")
+
+	run := func(args ...string) []byte {
+		t.Helper()
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(&out)
+		root.SetArgs(args)
+		if err := root.ExecuteContext(ctx); err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+		return out.Bytes()
+	}
+
+	tests := map[string][]string{
+		"json": {"transfers", "list", "--output", "json"},
+		"text": {"config", "accounts", "default", "--output", "text"},
+		"kv":   {"deposits", "get", "dep_123", "--output", "text"},
+	}
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			with := run(args...)
+			without := run(append(args, "--no-trailing-newline")...)
+
+			if !bytes.HasSuffix(with, []byte("\n")) {
+				t.Fatalf("default output should end with a newline: %q", with)
+			}
+			if !bytes.Equal(without, with[:len(with)-1]) {
+				t.Errorf("--no-trailing-newline output = %q, want %q", without, with[:len(with)-1])
+			}
+		})
+	}
+}
+
+func TestRootCmd_MaskIDs(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	const transferID = "7f687fe6-dcf4-4462-92fa-80335301d9d2"
+	const beneficiaryID = "be1e6c4a-2b1f-4f0e-9a57-0d4b4b9a6c11"
+	path := "/api/v1/transfers/" + transferID
+	testMockServer.HandleJSON("GET", path, http.StatusOK, map[string]any{
+		"id":                transferID,
+		"beneficiary_id":    beneficiaryID,
+		"status":            "PAID",
+		"transfer_amount":   100,
+		"transfer_currency": "USD",
+	})
+	defer testMockServer.HandleError("GET", path, http.StatusNotFound, "endpoint not found")
+
+	run := func(args ...string) (string, string) {
+		t.Helper()
+		var out, errOut bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: &errOut, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(&out)
+		root.SetArgs(args)
+		if err := root.ExecuteContext(ctx); err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+		return out.String(), errOut.String()
+	}
+
+	out, errOut := run("transfers", "get", transferID, "--mask-ids", "--debug", "--output", "text")
+	for _, id := range []string{transferID, beneficiaryID} {
+		if strings.Contains(out, id) {
+			t.Errorf("text output contains unmasked ID %s:\n%s", id, out)
+		}
+		if strings.Contains(errOut, id) {
+			t.Errorf("debug output contains unmasked ID %s:\n%s", id, errOut)
+		}
+	}
+	if !strings.Contains(out, redact.IDs(transferID)) {
+		t.Errorf("text output missing masked transfer ID %q:\n%s", redact.IDs(transferID), out)
+	}
+	if !strings.Contains(errOut, "api request") || !strings.Contains(errOut, redact.IDs(transferID)) {
+		t.Errorf("debug output missing masked request URL:\n%s", errOut)
+	}
+
+	out, _ = run("transfers", "get", transferID, "--mask-ids", "--output", "json")
+	if !strings.Contains(out, transferID) || !strings.Contains(out, beneficiaryID) {
+		t.Errorf("JSON output should keep real IDs:\n%s", out)
+	}
+}
+
+func TestRootCmd_ColorModes(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	t.Setenv("NO_COLOR", "")
+	os.Unsetenv("NO_COLOR")
+
+	testMockServer.HandleJSON("GET", "/api/v1/transfers", http.StatusOK, map[string]any{
+		"items": []map[string]any{
+			{"id": "tfr_1", "status": "PAID", "transfer_amount": 100, "transfer_currency": "USD"},
+		},
+		"has_more": false,
+	})
+	defer testMockServer.HandleError("GET", "/api/v1/transfers", http.StatusNotFound, "endpoint not found")
+
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(&out)
+		root.SetErr(io.Discard)
+		root.SetArgs(append([]string{"transfers", "list", "--output", "text"}, args...))
+		err := root.ExecuteContext(ctx)
+		return out.String(), err
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantEscape bool
+	}{
+		// Test stdout is not a terminal, so auto behaves like piped output.
+		{name: "auto", args: []string{"--color", "auto"}, wantEscape: false},
+		{name: "always", args: []string{"--color", "always"}, wantEscape: true},
+		{name: "never", args: []string{"--color", "never"}, wantEscape: false},
+		{name: "no-color alias", args: []string{"--no-color"}, wantEscape: false},
+		{name: "mixed case", args: []string{"--color", "ALWAYS"}, wantEscape: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := run(tt.args...)
+			if err != nil {
+				t.Fatalf("list failed: %v", err)
+			}
+			if !strings.Contains(out, "tfr_1") {
+				t.Fatalf("output missing transfer:\n%s", out)
+			}
+			if got := strings.Contains(out, "\x1b["); got != tt.wantEscape {
+				t.Errorf("escape codes present = %v, want %v:\n%q", got, tt.wantEscape, out)
+			}
+		})
+	}
+
+	if _, err := run("--color", "sometimes"); err == nil || !strings.Contains(err.Error(), "invalid --color") {
+		t.Errorf("expected invalid --color error, got %v", err)
+	}
+}
+
+func TestRootCmd_OutputFile(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.HandleJSON("GET", "/api/v1/transfers", http.StatusOK, map[string]any{
+		"items":    []map[string]any{{"id": "tfr_1", "status": "PAID"}},
+		"has_more": true,
+	})
+	defer testMockServer.HandleError("GET", "/api/v1/transfers", http.StatusNotFound, "endpoint not found")
+
+	path := filepath.Join(t.TempDir(), "out.json")
+	var out, errOut bytes.Buffer
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: &errOut, In: strings.NewReader("")})
+	root := NewRootCmd()
+	root.SetOut(&out)
+	root.SetErr(&errOut)
+	root.SetArgs([]string{"transfers", "list", "--all", "--max-pages", "1", "--items-only", "--output", "json", "--output-file", path})
+	if err := root.ExecuteContext(ctx); err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read output file: %v", err)
+	}
+	if !strings.Contains(string(data), `"tfr_1"`) {
+		t.Errorf("output file missing results:\n%s", data)
+	}
+	if out.Len() != 0 {
+		t.Errorf("stdout should be empty, got:\n%s", out.String())
+	}
+	if !strings.Contains(errOut.String(), "warning: results truncated") {
+		t.Errorf("stderr missing truncation warning:\n%s", errOut.String())
+	}
+	if strings.Contains(string(data), "warning") {
+		t.Errorf("output file should not contain warnings:\n%s", data)
+	}
+}
+
+func TestRootCmd_WarningsInJSON(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	var pageSize string
+	testMockServer.Handle("GET", "/api/v1/issuing/transaction_disputes", func(w http.ResponseWriter, r *http.Request) {
+		pageSize = r.URL.Query().Get("page_size")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[{"id":"dsp_1","status":"DRAFT"}],"has_more":false}`))
+	})
+	defer testMockServer.HandleError("GET", "/api/v1/issuing/transaction_disputes", http.StatusNotFound, "endpoint not found")
+
+	run := func(t *testing.T, args ...string) (map[string]interface{}, string) {
+		t.Helper()
+		var out, errOut bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: &errOut, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetArgs(append([]string{"issuing", "disputes", "list", "--output", "json"}, args...))
+		if err := root.ExecuteContext(ctx); err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+		var got map[string]interface{}
+		if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+			t.Fatalf("output is not a JSON object: %v\n%s", err, out.String())
+		}
+		return got, errOut.String()
+	}
+
+	got, errOut := run(t, "--page-size", "500", "--warnings-in-json")
+	if pageSize != "100" {
+		t.Errorf("page_size = %q, want clamped to 100", pageSize)
+	}
+	const want = "--page-size 500 exceeds the maximum of 100; using 100"
+	warnings, _ := got["warnings"].([]interface{})
+	if len(warnings) != 1 || warnings[0] != want {
+		t.Errorf("warnings = %v, want [%q]", got["warnings"], want)
+	}
+	if items, _ := got["items"].([]interface{}); len(items) != 1 {
+		t.Errorf("items = %v, want the listed dispute", got["items"])
+	}
+	if !strings.Contains(errOut, "warning: "+want) {
+		t.Errorf("stderr = %q, want the warning there too", errOut)
+	}
+
+	got, _ = run(t, "--warnings-in-json")
+	if warnings, ok := got["warnings"].([]interface{}); !ok || len(warnings) != 0 {
+		t.Errorf("warnings = %#v, want an empty array", got["warnings"])
+	}
+
+	got, _ = run(t, "--page-size", "500")
+	if _, ok := got["warnings"]; ok {
+		t.Errorf("warnings present without --warnings-in-json: %v", got)
+	}
+}
+
+func TestRootCmd_CommandTimeout(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.Handle("GET", "/api/v1/transfers", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	})
+	defer testMockServer.HandleError("GET", "/api/v1/transfers", http.StatusNotFound, "endpoint not found")
+
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: io.Discard, ErrOut: io.Discard, In: strings.NewReader("")})
+	root := NewRootCmd()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"transfers", "list", "--command-timeout", "100ms"})
+
+	start := time.Now()
+	err := root.ExecuteContext(ctx)
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("command took %s, want it cut off near the 100ms timeout", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "timed out after 100ms (--command-timeout)") {
+		t.Errorf("error = %q, want it to name --command-timeout", err.Error())
+	}
+
+	root = NewRootCmd()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"transfers", "list", "--command-timeout", "-1s"})
+	if err := root.ExecuteContext(ctx); err == nil || !strings.Contains(err.Error(), "--command-timeout must be positive") {
+		t.Errorf("expected negative --command-timeout to be rejected, got %v", err)
+	}
+
+	// Wait commands keep their own --timeout in seconds alongside it.
+	create, _, err := NewRootCmd().Find([]string{"transfers", "create"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := create.ParseFlags([]string{"--wait", "--timeout", "300", "--command-timeout", "10m"}); err != nil {
+		t.Fatalf("parsing both timeouts: %v", err)
+	}
+	if got := create.Flags().Lookup("timeout").Value.String(); got != "300" {
+		t.Errorf("--timeout = %s, want 300", got)
+	}
+	if got := create.Flags().Lookup("command-timeout").Value.String(); got != "10m0s" {
+		t.Errorf("--command-timeout = %s, want 10m0s", got)
+	}
+}
+
+func TestExplainTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeoutCause(context.Background(), time.Nanosecond, &commandTimeoutError{timeout: 2 * time.Second})
+	defer cancel()
+	<-ctx.Done()
+
+	err := explainTimeout(ctx, fmt.Errorf("polling: %w", context.DeadlineExceeded))
+	if err.Error() != "timed out after 2s (--command-timeout): polling: context deadline exceeded" {
+		t.Errorf("error = %q", err.Error())
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("explained error should still match context.DeadlineExceeded")
+	}
+
+	other := errors.New("boom")
+	if got := explainTimeout(ctx, other); got != other {
+		t.Errorf("unrelated error changed: %v", got)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/salmonumbrella/airwallex-cli/internal/flagmap"
 	"github.com/salmonumbrella/airwallex-cli/internal/outfmt"
+	"github.com/salmonumbrella/airwallex-cli/internal/schemavalidator"
 )
 
 // schemaKeyToFlag finds the CLI flag that maps to a given schema field key or path
@@ -34,7 +35,7 @@ func newSchemasCmd() *cobra.Command {
 }
 
 func newSchemasBeneficiaryCmd() *cobra.Command {
-	var bankCountry, entityType, paymentMethod string
+	var bankCountry, entityType, paymentMethod, as string
 
 	cmd := &cobra.Command{
 		Use:     "beneficiary",
@@ -44,8 +45,17 @@ func newSchemasBeneficiaryCmd() *cobra.Command {
 
 Examples:
   airwallex schemas beneficiary --bank-country US --entity-type COMPANY
-  airwallex schemas beneficiary --bank-country CA --entity-type PERSONAL --payment-method LOCAL`,
+  airwallex schemas beneficiary --bank-country CA --entity-type PERSONAL --payment-method LOCAL
+
+  # Emit a standard JSON Schema document for use with other validators
+  airwallex schemas beneficiary --bank-country US --entity-type COMPANY --as json-schema`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			switch as {
+			case "airwallex", "json-schema":
+			default:
+				return fmt.Errorf("invalid --as %q: must be airwallex or json-schema", as)
+			}
+
 			client, err := getClient(cmd.Context())
 			if err != nil {
 				return err
@@ -56,6 +66,10 @@ Examples:
 				return err
 			}
 
+			if as == "json-schema" {
+				return writeJSONOutput(cmd, schemavalidator.ToJSONSchema(schema))
+			}
+
 			if outfmt.IsJSON(cmd.Context()) {
 				return writeJSONOutput(cmd, schema)
 			}
@@ -87,6 +101,7 @@ Examples:
 	cmd.Flags().StringVar(&bankCountry, "bank-country", "", "Bank country code (required)")
 	cmd.Flags().StringVar(&entityType, "entity-type", "", "Entity type: COMPANY or PERSONAL (required)")
 	cmd.Flags().StringVar(&paymentMethod, "payment-method", "", "Payment method: LOCAL or SWIFT")
+	cmd.Flags().StringVar(&as, "as", "airwallex", "Schema format: airwallex or json-schema")
 	mustMarkRequired(cmd, "bank-country")
 	mustMarkRequired(cmd, "entity-type")
 	flagAlias(cmd.Flags(), "bank-country", "bk")
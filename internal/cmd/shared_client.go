@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"sync"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/api"
+)
+
+// sharedClients holds one API client per account for every command executed
+// under the same WithSharedClients context.
+type sharedClients struct {
+	mu      sync.Mutex
+	clients map[string]*api.Client
+}
+
+type sharedClientsKey struct{}
+
+// WithSharedClients returns a context under which commands run in this
+// process reuse one API client per account instead of building their own.
+// The rate limiter, circuit breaker, and login token then apply across all
+// of them, e.g. when a program runs many commands through ExecuteContext.
+//
+// The first command to need an account's client fixes its connection
+// settings (--rate-limit, --max-retries, --proxy, ...); per-command outputs
+// such as --show-url and --dry-run follow each invocation's own flags.
+func WithSharedClients(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sharedClientsKey{}, &sharedClients{clients: map[string]*api.Client{}})
+}
+
+func sharedClientsFromContext(ctx context.Context) *sharedClients {
+	s, _ := ctx.Value(sharedClientsKey{}).(*sharedClients)
+	return s
+}
+
+// get returns the client already built for account, or nil. A nil
+// sharedClients never has one.
+func (s *sharedClients) get(account string) *api.Client {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.clients[account]
+}
+
+// put shares client for account and returns the client to use: the one
+// already shared if another command got there first.
+func (s *sharedClients) put(account string, client *api.Client) *api.Client {
+	if s == nil {
+		return client
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.clients[account]; ok {
+		return existing
+	}
+	s.clients[account] = client
+	return client
+}
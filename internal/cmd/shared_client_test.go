@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/api"
+	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
+	"github.com/salmonumbrella/airwallex-cli/internal/secrets"
+)
+
+func TestWithSharedClients_ReusesClientAcrossCommands(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.HandleError("GET", "/api/v1/transfers/tfr_shared", http.StatusInternalServerError, "upstream unavailable")
+
+	var built []*api.Client
+	original := newClientForCreds
+	newClientForCreds = func(creds secrets.Credentials) (*api.Client, error) {
+		c, err := original(creds)
+		if err == nil {
+			built = append(built, c)
+		}
+		return c, err
+	}
+	defer func() { newClientForCreds = original }()
+
+	run := func(ctx context.Context, extra ...string) string {
+		t.Helper()
+		var out, errOut bytes.Buffer
+		ctx = iocontext.WithIO(ctx, &iocontext.IO{Out: &out, ErrOut: &errOut, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(&out)
+		root.SetErr(&errOut)
+		root.SetArgs(append([]string{"transfers", "get", "tfr_shared", "--max-retries", "0"}, extra...))
+		if err := root.ExecuteContext(ctx); err == nil {
+			t.Fatal("expected the 500 response to fail the command")
+		}
+		return errOut.String()
+	}
+
+	ctx := WithSharedClients(context.Background())
+	first := run(ctx, "--show-url")
+	second := run(ctx)
+
+	if len(built) != 1 {
+		t.Fatalf("built %d clients for two shared commands, want 1", len(built))
+	}
+	if got := built[0].CircuitState().Failures; got != 2 {
+		t.Errorf("shared circuit breaker failures = %d, want 2 (one per command)", got)
+	}
+	if !strings.Contains(first, "/api/v1/transfers/tfr_shared") {
+		t.Errorf("first command should show the URL, stderr = %q", first)
+	}
+	if strings.Contains(second, "GET http") {
+		t.Errorf("--show-url leaked into the second command, stderr = %q", second)
+	}
+
+	run(context.Background())
+	if len(built) != 2 {
+		t.Fatalf("built %d clients, want a fresh one without WithSharedClients", len(built))
+	}
+	if got := built[1].CircuitState().Failures; got != 1 {
+		t.Errorf("unshared circuit breaker failures = %d, want 1", got)
+	}
+}
+
+func TestWithSharedClients_ConcurrentDryRunDoesNotLeak(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	var deletes int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/authentication/login" {
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"token":      "tok",
+				"expires_at": time.Now().Add(time.Hour).Format(time.RFC3339),
+			})
+			return
+		}
+		atomic.AddInt32(&deletes, 1)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	original := newClientForCreds
+	newClientForCreds = func(creds secrets.Credentials) (*api.Client, error) {
+		return api.NewClientWithBaseURL(srv.URL, creds.ClientID, creds.APIKey)
+	}
+	defer func() { newClientForCreds = original }()
+
+	ctx := WithSharedClients(context.Background())
+	const n = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*n)
+	for i := 0; i < 2*n; i++ {
+		args := []string{"beneficiaries", "delete", "ben_123", "--yes"}
+		if i%2 == 0 {
+			args = append(args, "--dry-run")
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := iocontext.WithIO(ctx, &iocontext.IO{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}, In: strings.NewReader("")})
+			root := NewRootCmd()
+			root.SetArgs(args)
+			errs <- root.ExecuteContext(ctx)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("command failed: %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&deletes); got != n {
+		t.Errorf("sent %d deletes, want %d (one per command without --dry-run)", got, n)
+	}
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/api"
+	"github.com/salmonumbrella/airwallex-cli/internal/outfmt"
+)
+
+// statusReport is the status output. Circuit fields describe the breaker
+// after the probe request, which alone never opens it.
+type statusReport struct {
+	API                 string     `json:"api"`     // "ok" or the probe error
+	Circuit             string     `json:"circuit"` // "closed", "open", or "half-open"
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	ResetInSeconds      int64      `json:"reset_in_seconds"`
+	TokenCached         bool       `json:"token_cached"`
+	TokenExpiresAt      *time.Time `json:"token_expires_at,omitempty"`
+}
+
+func newStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show API reachability, circuit breaker state, and cached login token",
+		Long: `Report whether the API is reachable, the circuit breaker state, and the
+cached login token for the selected account.
+
+The circuit breaker only lives for one invocation, so status sends a single
+probe request (GET /api/v1/balances/current) and reports the breaker as that
+request left it. One probe (plus its retry) cannot reach the failure
+threshold, so the breaker is always reported closed here; consecutive_failures
+shows whether the probe failed. To see whether the breaker tripped during a
+real command, rerun that command with --stats, which includes the breaker
+state in its summary. The token is read from the on-disk cache before probing.
+
+The command exits non-zero when the probe fails, after printing the report.
+
+Examples:
+  airwallex status
+  airwallex status --output json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			account, err := requireAccount(ctx)
+			if err != nil {
+				return err
+			}
+			store, err := openSecretsStore()
+			if err != nil {
+				return err
+			}
+			creds, err := store.Get(account)
+			if err != nil {
+				return fmt.Errorf("account not found: %s", account)
+			}
+
+			var report statusReport
+			if tokens, err := tokenStoreFor(account, creds); err == nil {
+				if token, err := tokens.Load(); err == nil && token != nil {
+					report.TokenCached = true
+					expires := token.ExpiresAt
+					report.TokenExpiresAt = &expires
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			probeErr := probeAPI(ctx, client)
+			report.API = "ok"
+			if probeErr != nil {
+				report.API = probeErr.Error()
+			}
+			report.setCircuit(client.CircuitState())
+
+			if err := writeStatusReport(ctx, cmd.OutOrStdout(), report); err != nil {
+				return err
+			}
+			return probeErr
+		},
+	}
+}
+
+func probeAPI(ctx context.Context, client *api.Client) error {
+	resp, err := client.Get(ctx, "/api/v1/balances/current")
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("GET /api/v1/balances/current returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (r *statusReport) setCircuit(st api.CircuitState) {
+	r.Circuit = st.String()
+	r.ConsecutiveFailures = st.Failures
+	r.ResetInSeconds = int64(st.ResetIn.Round(time.Second) / time.Second)
+}
+
+func writeStatusReport(ctx context.Context, w io.Writer, r statusReport) error {
+	if outfmt.IsJSON(ctx) {
+		return writeJSONOutputTo(ctx, w, r)
+	}
+	resetIn := "-"
+	if r.Circuit == "open" {
+		resetIn = (time.Duration(r.ResetInSeconds) * time.Second).String()
+	}
+	token := "none"
+	if r.TokenCached && r.TokenExpiresAt != nil {
+		if until := time.Until(*r.TokenExpiresAt); until > 0 {
+			token = fmt.Sprintf("expires %s (in %s)", r.TokenExpiresAt.Format(time.RFC3339), until.Round(time.Second))
+		} else {
+			token = fmt.Sprintf("expired %s", r.TokenExpiresAt.Format(time.RFC3339))
+		}
+	}
+	return outfmt.WriteKVForContext(ctx, w, []outfmt.KV{
+		{Key: "api", Value: r.API},
+		{Key: "circuit", Value: r.Circuit},
+		{Key: "consecutive_failures", Value: strconv.Itoa(r.ConsecutiveFailures)},
+		{Key: "reset_in", Value: resetIn},
+		{Key: "token", Value: token},
+	})
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/api"
+	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
+	"github.com/salmonumbrella/airwallex-cli/internal/outfmt"
+)
+
+func TestWriteStatusReport(t *testing.T) {
+	expires := time.Now().Add(20 * time.Minute).UTC().Truncate(time.Second)
+
+	closed := statusReport{API: "ok", TokenCached: true, TokenExpiresAt: &expires}
+	closed.setCircuit(api.CircuitState{Failures: 1})
+
+	open := statusReport{API: "circuit breaker open: API experiencing issues, retry later"}
+	open.setCircuit(api.CircuitState{Open: true, Failures: 5, ResetIn: 17*time.Second + 400*time.Millisecond})
+
+	halfOpen := statusReport{API: "ok"}
+	halfOpen.setCircuit(api.CircuitState{HalfOpen: true, Failures: 5})
+
+	tests := []struct {
+		name   string
+		report statusReport
+		want   []string
+	}{
+		{"closed", closed, []string{"circuit", "closed", "consecutive_failures", "1", "reset_in", "-", "expires " + expires.Format(time.RFC3339)}},
+		{"open", open, []string{"circuit", "open", "consecutive_failures", "5", "17s", "token", "none", "circuit breaker open"}},
+		{"half-open", halfOpen, []string{"circuit", "half-open", "consecutive_failures", "5", "reset_in", "-"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := writeStatusReport(context.Background(), &out, tt.report); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, out.String())
+				}
+			}
+
+			out.Reset()
+			ctx := outfmt.WithFormat(context.Background(), "json")
+			if err := writeStatusReport(ctx, &out, tt.report); err != nil {
+				t.Fatalf("write json: %v", err)
+			}
+			var got statusReport
+			if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+				t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+			}
+			if got.Circuit != tt.report.Circuit || got.ConsecutiveFailures != tt.report.ConsecutiveFailures || got.ResetInSeconds != tt.report.ResetInSeconds {
+				t.Errorf("json = %+v, want %+v", got, tt.report)
+			}
+		})
+	}
+	if open.ResetInSeconds != 17 {
+		t.Errorf("ResetInSeconds = %d, want 17", open.ResetInSeconds)
+	}
+}
+
+func TestStatus_Probe(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	testMockServer.HandleJSON("GET", "/api/v1/balances/current", http.StatusOK, []any{})
+
+	var out bytes.Buffer
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+	root := NewRootCmd()
+	root.SetOut(&out)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"status", "--output", "json"})
+	if err := root.ExecuteContext(ctx); err != nil {
+		t.Fatalf("status failed: %v", err)
+	}
+	var report statusReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+	if report.API != "ok" || report.Circuit != "closed" || report.ConsecutiveFailures != 0 {
+		t.Errorf("report = %+v, want api ok and circuit closed", report)
+	}
+}
@@ -89,6 +89,8 @@ func TestMain(m *testing.M) {
 func setupTestEnvironment(t *testing.T) func() {
 	t.Helper()
 	t.Setenv("AWX_ACCOUNT", "test-account")
+	// Keep persisted login tokens out of the real config directory.
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
 	original := openSecretsStore
 	openSecretsStore = func() (secrets.Store, error) {
 		return &mockStore{}, nil
@@ -2,9 +2,14 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,7 +18,7 @@ import (
 
 	"github.com/salmonumbrella/airwallex-cli/internal/api"
 	"github.com/salmonumbrella/airwallex-cli/internal/batch"
-	"github.com/salmonumbrella/airwallex-cli/internal/dryrun"
+	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
 	"github.com/salmonumbrella/airwallex-cli/internal/outfmt"
 	"github.com/salmonumbrella/airwallex-cli/internal/suggest"
 	"github.com/salmonumbrella/airwallex-cli/internal/ui"
@@ -31,6 +36,9 @@ func newTransfersCmd() *cobra.Command {
 	cmd.AddCommand(newTransfersBatchCreateCmd())
 	cmd.AddCommand(newTransfersCancelCmd())
 	cmd.AddCommand(newTransfersConfirmationCmd())
+	cmd.AddCommand(newTransfersEstimateArrivalCmd())
+	cmd.AddCommand(newTransfersWaitCmd())
+	cmd.AddCommand(newTransfersEventsCmd())
 	return cmd
 }
 
@@ -61,6 +69,7 @@ func suggestBeneficiaries(ctx context.Context, client *api.Client, query string)
 
 func newTransfersListCmd() *cobra.Command {
 	var status string
+	var includeFX bool
 
 	cmd := NewListCommand(ListConfig[api.Transfer]{
 		Use:     "list",
@@ -103,7 +112,11 @@ Examples:
 
   # Compact view with selected fields
   airwallex transfers list --output json --query \
-    '.items[] | {ref: .reference, amount: .transfer_amount, currency: .transfer_currency, status: .status}'`,
+    '.items[] | {ref: .reference, amount: .transfer_amount, currency: .transfer_currency, status: .status}'
+
+  # Inline FX rate alongside the transfer fee for cross-currency transfers
+  airwallex transfers list --include-fx --output json --query \
+    '.items[] | select(.fx) | {id, rate: .fx.rate, fee: .fee_amount}'`,
 		Headers:      []string{"TRANSFER_ID", "AMOUNT", "CURRENCY", "STATUS", "REFERENCE"},
 		EmptyMessage: "No transfers found",
 		ColumnTypes: []outfmt.ColumnType{
@@ -134,6 +147,11 @@ Examples:
 			if err != nil {
 				return ListResult[api.Transfer]{}, err
 			}
+			if includeFX {
+				if err := attachTransferFX(ctx, client, result.Items); err != nil {
+					return ListResult[api.Transfer]{}, err
+				}
+			}
 			return ListResult[api.Transfer]{
 				Items:   result.Items,
 				HasMore: result.HasMore,
@@ -142,16 +160,27 @@ Examples:
 	}, getClient)
 
 	cmd.Flags().StringVarP(&status, "status", "s", "", "Filter by status")
+	cmd.Flags().BoolVar(&includeFX, "include-fx", false, "Fetch and attach FX conversion details (rate, sell and buy amounts) to cross-currency transfers")
 	return cmd
 }
 
 func newTransfersGetCmd() *cobra.Command {
-	return NewGetCommand(GetConfig[*api.Transfer]{
+	var includeFX bool
+
+	cmd := NewGetCommand(GetConfig[*api.Transfer]{
 		Use:     "get <transferId>",
 		Aliases: []string{"g"},
 		Short:   "Get transfer details",
 		Fetch: func(ctx context.Context, client *api.Client, id string) (*api.Transfer, error) {
-			return client.GetTransfer(ctx, id)
+			t, err := client.GetTransfer(ctx, id)
+			if err != nil || !includeFX {
+				return t, err
+			}
+			items := []api.Transfer{*t}
+			if err := attachTransferFX(ctx, client, items); err != nil {
+				return nil, err
+			}
+			return &items[0], nil
 		},
 		TextOutput: func(cmd *cobra.Command, t *api.Transfer) error {
 			rows := []outfmt.KV{
@@ -166,16 +195,30 @@ func newTransfersGetCmd() *cobra.Command {
 				{Key: "reason", Value: t.Reason},
 				{Key: "created_at", Value: t.CreatedAt},
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			if t.FeeAmount != "" {
+				rows = append(rows, outfmt.KV{Key: "fee", Value: outfmt.FormatMoney(t.FeeAmount) + " " + t.FeeCurrency})
+			}
+			if t.FX != nil {
+				rows = append(rows,
+					outfmt.KV{Key: "fx_conversion_id", Value: t.FX.ConversionID},
+					outfmt.KV{Key: "fx_rate", Value: t.FX.Rate.String()},
+					outfmt.KV{Key: "fx_sell", Value: outfmt.FormatMoney(t.FX.SellAmount) + " " + t.FX.SellCurrency},
+					outfmt.KV{Key: "fx_buy", Value: outfmt.FormatMoney(t.FX.BuyAmount) + " " + t.FX.BuyCurrency},
+				)
+			}
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}, getClient)
+
+	cmd.Flags().BoolVar(&includeFX, "include-fx", false, "Fetch and attach FX conversion details (rate, sell and buy amounts) for a cross-currency transfer")
+	return cmd
 }
 
 func newTransfersCreateCmd() *cobra.Command {
 	var beneficiaryID string
-	var transferAmount float64
+	var transferAmount amountValue
 	var transferCurrency string
-	var sourceAmount float64
+	var sourceAmount amountValue
 	var sourceCurrency string
 	var transferMethod string
 	var localClearingSystem string
@@ -183,7 +226,11 @@ func newTransfersCreateCmd() *cobra.Command {
 	var reason string
 	var securityQuestion string
 	var securityAnswer string
-	var dryRun bool
+	var metadataFlags []string
+	var swift swiftTransferOptions
+	var sourceOfFunds string
+	var validateOnly bool
+	var idempotencyKey string
 	var wait bool
 	var waitTimeout int
 
@@ -215,6 +262,23 @@ Examples:
     --transfer-currency USD --source-currency USD --method LOCAL \
     --clearing-system ACH --reference "Invoice 123" --reason "payment_to_supplier"
 
+  # Tag with internal references (stored in the transfer's metadata)
+  airwallex transfers create --beneficiary-id xxx --transfer-amount 100 \
+    --transfer-currency USD --source-currency USD --reference "Invoice 123" \
+    --reason "payment_to_supplier" --metadata invoice=INV-123 --metadata cost_center=ops
+
+  # SWIFT with all fees paid by the sender (OUR), charged to a separate account
+  airwallex transfers create --beneficiary-id xxx --transfer-amount 5000 \
+    --transfer-currency EUR --source-currency USD --method SWIFT \
+    --reference "Invoice 123" --reason "payment_to_supplier" \
+    --swift-charge-option PAYER --charge-account-id yyy \
+    --remittance-info "INV-123 consulting services"
+
+  # Check a CNY payout locally, including the source-of-funds declaration
+  airwallex transfers create --beneficiary-id xxx --transfer-amount 20000 \
+    --transfer-currency CNY --source-currency USD --reference "Invoice 123" \
+    --reason "payment_to_supplier" --source-of-funds business_income --validate
+
 Clearing systems by country:
   Canada: EFT (default), REGULAR_EFT, INTERAC, BILL_PAYMENT
   USA:    ACH, NEXT_DAY_ACH, FEDNOW, FEDWIRE
@@ -227,8 +291,8 @@ Interac e-Transfer notes:
   - Answer: 3-25 alphanumeric characters (no special chars like @, &, *)`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Validate amount fields: exactly one of transfer_amount or source_amount
-			hasTransferAmount := transferAmount > 0
-			hasSourceAmount := sourceAmount > 0
+			hasTransferAmount := transferAmount.IsSet()
+			hasSourceAmount := sourceAmount.IsSet()
 			if hasTransferAmount == hasSourceAmount {
 				if !hasTransferAmount {
 					return fmt.Errorf("must provide exactly one of --transfer-amount or --source-amount")
@@ -260,6 +324,18 @@ Interac e-Transfer notes:
 				}
 			}
 
+			metadata, err := parseTransferMetadata(metadataFlags)
+			if err != nil {
+				return err
+			}
+
+			idempotencyKey = strings.TrimSpace(idempotencyKey)
+			if cmd.Flags().Changed("idempotency-key") {
+				if err := api.ValidateIdempotencyKey(idempotencyKey); err != nil {
+					return fmt.Errorf("invalid --idempotency-key: %w", err)
+				}
+			}
+
 			// Normalize --method: if set to a clearing system name, convert to LOCAL + clearing system
 			clearingSystems := map[string]bool{
 				"INTERAC": true, "EFT": true, "REGULAR_EFT": true, "BILL_PAYMENT": true,
@@ -273,12 +349,18 @@ Interac e-Transfer notes:
 				transferMethod = "LOCAL"
 			}
 
-			u := ui.FromContext(cmd.Context())
-			client, err := getClient(cmd.Context())
-			if err != nil {
+			swift.Method = transferMethod
+			if err := validateSwiftTransfer(&swift); err != nil {
 				return err
 			}
 
+			sourceOfFunds = strings.TrimSpace(sourceOfFunds)
+			if validateOnly && sourceOfFunds == "" && sourceOfFundsRequired(transferCurrency) {
+				return fmt.Errorf("--source-of-funds is required for %s payouts", strings.ToUpper(transferCurrency))
+			}
+
+			u := ui.FromContext(cmd.Context())
+
 			req := map[string]interface{}{
 				"request_id":        uuid.New().String(),
 				"beneficiary_id":    beneficiaryID,
@@ -289,11 +371,11 @@ Interac e-Transfer notes:
 				"reason":            reason,
 			}
 
-			if transferAmount > 0 {
-				req["transfer_amount"] = transferAmount
+			if hasTransferAmount {
+				req["transfer_amount"] = json.Number(transferAmount)
 			}
-			if sourceAmount > 0 {
-				req["source_amount"] = sourceAmount
+			if hasSourceAmount {
+				req["source_amount"] = json.Number(sourceAmount)
 			}
 			if localClearingSystem != "" {
 				req["local_clearing_system"] = localClearingSystem
@@ -304,52 +386,37 @@ Interac e-Transfer notes:
 			if securityAnswer != "" {
 				req["security_answer"] = securityAnswer
 			}
+			if len(metadata) > 0 {
+				req["metadata"] = metadata
+			}
+			if swift.ChargeOption != "" {
+				req["swift_charge_option"] = swift.ChargeOption
+			}
+			if swift.ChargeAccountID != "" {
+				req["charge_account_id"] = swift.ChargeAccountID
+			}
+			if swift.RemittanceInfo != "" {
+				req["remittance_information"] = swift.RemittanceInfo
+			}
+			if sourceOfFunds != "" {
+				req["source_of_funds"] = sourceOfFunds
+			}
 
-			if dryRun {
-				// Fetch beneficiary details for preview
-				beneficiary, err := client.GetBeneficiary(cmd.Context(), beneficiaryID)
-				if err != nil {
-					return fmt.Errorf("failed to fetch beneficiary for preview: %w", err)
-				}
-
-				beneficiaryName := beneficiary.Beneficiary.CompanyName
-				if beneficiaryName == "" {
-					beneficiaryName = strings.TrimSpace(beneficiary.Beneficiary.FirstName + " " + beneficiary.Beneficiary.LastName)
-				}
-				if beneficiaryName == "" {
-					beneficiaryName = beneficiary.Beneficiary.BankDetails.AccountName
-				}
-				if beneficiaryName == "" {
-					beneficiaryName = beneficiary.Nickname
-				}
-
-				// Determine which amount to show in preview
-				previewAmount := transferAmount
-				previewCurrency := transferCurrency
-				if transferAmount == 0 && sourceAmount > 0 {
-					previewAmount = sourceAmount
-					previewCurrency = sourceCurrency
-				}
-
-				preview := &dryrun.Preview{
-					Operation:   "create",
-					Resource:    "transfer",
-					Description: fmt.Sprintf("Send %s to %s", dryrun.FormatAmount(previewAmount, previewCurrency), beneficiaryName),
-					Details: map[string]interface{}{
-						"Beneficiary":     beneficiaryName,
-						"Beneficiary ID":  beneficiaryID,
-						"Amount":          dryrun.FormatAmount(previewAmount, previewCurrency),
-						"Source Currency": sourceCurrency,
-						"Transfer Method": transferMethod,
-						"Reference":       reference,
-					},
+			if validateOnly {
+				u.Success("Validation passed")
+				if outfmt.IsJSON(cmd.Context()) {
+					return writeJSONOutput(cmd, req)
 				}
-
-				preview.Write(os.Stderr) //nolint:errcheck // preview output to stderr is best-effort
 				return nil
 			}
 
-			t, err := client.CreateTransfer(cmd.Context(), req)
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			idem := &api.IdempotencyKey{Key: idempotencyKey}
+			t, err := client.CreateTransfer(api.WithIdempotencyKey(cmd.Context(), idem), req)
 			if err != nil {
 				if api.IsNotFoundError(err) && strings.Contains(err.Error(), "beneficiary") {
 					suggestions := suggestBeneficiaries(cmd.Context(), client, beneficiaryID)
@@ -360,7 +427,19 @@ Interac e-Transfer notes:
 				return err
 			}
 
+			if t.TransferID == "" {
+				// A create can succeed with an empty body; there is no ID to
+				// report or wait on, but the transfer was still created.
+				if wait {
+					warnf(cmd.Context(), iocontext.GetIO(cmd.Context()).ErrOut, "not waiting: the API did not return the new transfer's ID")
+				}
+				return writeNoDetailsResult(cmd, fmt.Sprintf("Created transfer (the API returned no details; find it with 'airwallex transfers list', request_id %s)", req["request_id"]))
+			}
+
 			if outfmt.IsJSON(cmd.Context()) {
+				if f, ok := rootFlagsFromContext(cmd.Context()); ok && f.ShowIdempotencyKey {
+					return writeJSONOutput(cmd, createdTransfer{Transfer: t, IdempotencyKey: idem.Key})
+				}
 				return writeJSONOutput(cmd, t)
 			}
 
@@ -383,9 +462,9 @@ Interac e-Transfer notes:
 	}
 
 	cmd.Flags().StringVarP(&beneficiaryID, "beneficiary-id", "b", "", "Beneficiary ID (required)")
-	cmd.Flags().Float64Var(&transferAmount, "transfer-amount", 0, "Amount beneficiary receives")
+	cmd.Flags().Var(&transferAmount, "transfer-amount", "Amount beneficiary receives")
 	cmd.Flags().StringVar(&transferCurrency, "transfer-currency", "", "Currency of transfer amount (required)")
-	cmd.Flags().Float64Var(&sourceAmount, "source-amount", 0, "Amount to send from wallet")
+	cmd.Flags().Var(&sourceAmount, "source-amount", "Amount to send from wallet")
 	cmd.Flags().StringVar(&sourceCurrency, "source-currency", "", "Source currency (required)")
 	cmd.Flags().StringVarP(&transferMethod, "method", "m", "LOCAL", "LOCAL, SWIFT, or a clearing system (INTERAC, ACH, FEDWIRE, etc.)")
 	cmd.Flags().StringVar(&localClearingSystem, "clearing-system", "", "Clearing system (CA: EFT/INTERAC, US: ACH/FEDWIRE)")
@@ -393,7 +472,13 @@ Interac e-Transfer notes:
 	cmd.Flags().StringVar(&reason, "reason", "", "Transfer reason (required)")
 	cmd.Flags().StringVar(&securityQuestion, "security-question", "", "Interac security question (1-40 chars)")
 	cmd.Flags().StringVar(&securityAnswer, "security-answer", "", "Interac security answer (3-25 alphanumeric)")
-	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview the transfer without executing")
+	cmd.Flags().StringVar(&swift.ChargeOption, "swift-charge-option", "", "SWIFT fee option: SHARED (SHA) or PAYER (OUR)")
+	cmd.Flags().StringVar(&swift.ChargeAccountID, "charge-account-id", "", "Account charged SWIFT fees (required with --swift-charge-option PAYER)")
+	cmd.Flags().StringVar(&swift.RemittanceInfo, "remittance-info", "", "SWIFT remittance information for the beneficiary (max 140 chars)")
+	cmd.Flags().StringArrayVar(&metadataFlags, "metadata", nil, "Metadata entry (key=value, repeatable)")
+	cmd.Flags().StringVar(&sourceOfFunds, "source-of-funds", "", "Declared source of funds, e.g. business_income (required for CNY, INR, and BRL payouts)")
+	cmd.Flags().BoolVar(&validateOnly, "validate", false, "Check the transfer locally, including corridor requirements, without creating it")
+	cmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "Idempotency key to send, up to 128 printable characters (default: generated); reuse it to retry a create safely")
 	cmd.Flags().BoolVarP(&wait, "wait", "w", false, "Wait for transfer to complete")
 	cmd.Flags().IntVar(&waitTimeout, "timeout", 300, "Timeout in seconds when waiting")
 	mustMarkRequired(cmd, "beneficiary-id")
@@ -409,22 +494,138 @@ Interac e-Transfer notes:
 	flagAlias(cmd.Flags(), "clearing-system", "cs")
 	flagAlias(cmd.Flags(), "security-question", "sq")
 	flagAlias(cmd.Flags(), "security-answer", "ans")
-	flagAlias(cmd.Flags(), "dry-run", "dr")
 	flagAlias(cmd.Flags(), "reason", "rsn")
 	flagAlias(cmd.Flags(), "method", "mt")
 	flagAlias(cmd.Flags(), "timeout", "tmo")
 	return cmd
 }
 
+// sourceOfFundsCurrencies are payout currencies whose corridors require a
+// declared source of funds. The API does not publish an enum of accepted
+// values, so only presence is checked.
+var sourceOfFundsCurrencies = map[string]bool{
+	"BRL": true,
+	"CNY": true,
+	"INR": true,
+}
+
+// createdTransfer is the JSON output of transfers create with
+// --show-idempotency-key: the transfer plus the key it was created with.
+type createdTransfer struct {
+	*api.Transfer
+	IdempotencyKey string `json:"idempotency_key"`
+}
+
+// sourceOfFundsRequired reports whether a payout in transferCurrency must
+// declare --source-of-funds.
+func sourceOfFundsRequired(transferCurrency string) bool {
+	return sourceOfFundsCurrencies[strings.ToUpper(strings.TrimSpace(transferCurrency))]
+}
+
+// swiftTransferOptions holds the SWIFT-only fields of transfers create.
+type swiftTransferOptions struct {
+	Method          string
+	ChargeOption    string
+	ChargeAccountID string
+	RemittanceInfo  string
+}
+
+// maxSwiftRemittanceInfo is the MT103 field 70 limit (4 lines of 35 chars).
+const maxSwiftRemittanceInfo = 140
+
+// reSwiftCharset matches the SWIFT "x" character set allowed in remittance info.
+var reSwiftCharset = regexp.MustCompile(`^[A-Za-z0-9/\-?:().,'+ ]*$`)
+
+// validateSwiftTransfer checks the interdependent SWIFT fields locally and
+// reports every problem at once. ChargeOption is normalized in place
+// (SHA -> SHARED, OUR -> PAYER).
+func validateSwiftTransfer(o *swiftTransferOptions) error {
+	var issues []string
+
+	o.ChargeOption = strings.ToUpper(strings.TrimSpace(o.ChargeOption))
+	switch o.ChargeOption {
+	case "SHA":
+		o.ChargeOption = "SHARED"
+	case "OUR":
+		o.ChargeOption = "PAYER"
+	}
+
+	if !strings.EqualFold(o.Method, "SWIFT") {
+		var used []string
+		if o.ChargeOption != "" {
+			used = append(used, "--swift-charge-option")
+		}
+		if o.ChargeAccountID != "" {
+			used = append(used, "--charge-account-id")
+		}
+		if o.RemittanceInfo != "" {
+			used = append(used, "--remittance-info")
+		}
+		if len(used) == 0 {
+			return nil
+		}
+		issues = append(issues, fmt.Sprintf("%s only apply to SWIFT transfers (use --method SWIFT)", strings.Join(used, ", ")))
+	}
+
+	switch o.ChargeOption {
+	case "", "SHARED":
+		if o.ChargeAccountID != "" {
+			issues = append(issues, "--charge-account-id only applies when --swift-charge-option is PAYER (OUR)")
+		}
+	case "PAYER":
+		if o.ChargeAccountID == "" {
+			issues = append(issues, "--charge-account-id is required when --swift-charge-option is PAYER (OUR)")
+		}
+	default:
+		issues = append(issues, fmt.Sprintf("invalid --swift-charge-option %q: must be SHARED (SHA) or PAYER (OUR)", o.ChargeOption))
+	}
+
+	if n := len(o.RemittanceInfo); n > maxSwiftRemittanceInfo {
+		issues = append(issues, fmt.Sprintf("--remittance-info must be at most %d characters (got %d)", maxSwiftRemittanceInfo, n))
+	}
+	if !reSwiftCharset.MatchString(o.RemittanceInfo) {
+		issues = append(issues, "--remittance-info may only contain letters, digits, spaces, and / - ? : ( ) . , ' +")
+	}
+
+	if len(issues) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid SWIFT transfer:\n  - %s", strings.Join(issues, "\n  - "))
+}
+
+// parseTransferMetadata turns repeated --metadata key=value flags into the
+// transfer's metadata object, rejecting empty and duplicate keys.
+func parseTransferMetadata(entries []string) (map[string]string, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	metadata := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		key, value, ok := strings.Cut(entry, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --metadata value %q: expected key=value", entry)
+		}
+		if _, exists := metadata[key]; exists {
+			return nil, fmt.Errorf("duplicate --metadata key %q", key)
+		}
+		metadata[key] = value
+	}
+	return metadata, nil
+}
+
 func newTransfersBatchCreateCmd() *cobra.Command {
 	var fromFile string
 	var continueOnError bool
+	var onlyErrors bool
+	var stream bool
+	var deriveRequestIDs bool
 
 	cmd := &cobra.Command{
 		Use:     "batch-create",
 		Aliases: []string{"bc"},
 		Short:   "Create multiple transfers from file or stdin",
-		Long: `Create multiple transfers from a JSON file or stdin.
+		Long: `Create multiple transfers from a JSON or CSV file, or JSON on stdin.
 
 Input format (JSON array or newline-delimited JSON):
 [
@@ -439,18 +640,58 @@ Input format (JSON array or newline-delimited JSON):
   }
 ]
 
+A file ending in .csv is read as CSV with a header row. The columns are
+the JSON field names above; "amount" and "currency" are accepted for
+transfer_amount and transfer_currency. source_currency defaults to the
+transfer currency and transfer_method to LOCAL:
+
+  beneficiary_id,amount,currency,reference,reason
+  ben_xxx,100.00,USD,INV-001,payment_to_supplier
+
+With --derive-request-ids (the default for .csv files), items without a
+request_id get one derived from their position and content, so re-running
+the same file does not create duplicate transfers. Change the reference (or
+set request_id) to pay an identical row again. JSON and NDJSON items without
+a request_id get a fresh one unless --derive-request-ids is set.
+
 Examples:
   airwallex transfers batch-create --from-file transfers.json
+  airwallex transfers batch-create --file payments.csv --continue-on-error
   cat transfers.json | airwallex transfers batch-create
-  airwallex transfers batch-create --from-file transfers.json --continue-on-error`,
+  airwallex transfers batch-create --from-file transfers.json --continue-on-error
+
+  # Emit only failed entries (with their input index) for a retry run
+  airwallex transfers batch-create --from-file transfers.json --continue-on-error --only-errors --output json
+
+  # Create transfers as NDJSON lines arrive, printing one result per line
+  generate-payroll | airwallex transfers batch-create --from-file - --stream --output json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			u := ui.FromContext(cmd.Context())
 			client, err := getClient(cmd.Context())
 			if err != nil {
 				return err
 			}
+			if !cmd.Flags().Changed("derive-request-ids") {
+				deriveRequestIDs = !stream && strings.EqualFold(filepath.Ext(fromFile), ".csv")
+			}
 
-			items, err := batch.ReadItems(fromFile)
+			if stream {
+				var reader io.Reader
+				if fromFile == "" || fromFile == "-" {
+					reader = iocontext.GetIO(cmd.Context()).In
+				} else {
+					//nolint:gosec // G304: filename comes from user input, intentional
+					f, err := os.Open(fromFile)
+					if err != nil {
+						return fmt.Errorf("failed to open file: %w", err)
+					}
+					defer func() { _ = f.Close() }()
+					reader = f
+				}
+				return streamTransfersBatch(cmd, client, batch.NewDecoder(reader), continueOnError, onlyErrors, deriveRequestIDs)
+			}
+
+			items, err := readTransferBatch(fromFile)
 			if err != nil {
 				return err
 			}
@@ -461,12 +702,24 @@ Examples:
 			var summary batch.Summary
 			summary.Total = len(items)
 
+			// On SIGINT/SIGTERM the context is cancelled: stop sending, but still
+			// write the results gathered so far so the output stays valid.
+			var interrupted error
 			for i, item := range items {
+				if err := cmd.Context().Err(); err != nil {
+					interrupted = err
+					break
+				}
 				if _, ok := item["request_id"]; !ok {
-					item["request_id"] = uuid.New().String()
+					item["request_id"] = batchItemRequestID(i, item, deriveRequestIDs)
 				}
 
 				t, err := client.CreateTransfer(cmd.Context(), item)
+				if errors.Is(err, api.ErrDryRun) {
+					results = append(results, batch.Result{Index: i, Success: true, DryRun: true})
+					summary.Previewed++
+					continue
+				}
 				if err != nil {
 					results = append(results, batch.Result{
 						Index:   i,
@@ -476,6 +729,10 @@ Examples:
 					})
 					summary.Failed++
 
+					if ctxErr := cmd.Context().Err(); ctxErr != nil {
+						interrupted = ctxErr
+						break
+					}
 					if !continueOnError {
 						break
 					}
@@ -490,22 +747,46 @@ Examples:
 				summary.Success++
 			}
 
+			if onlyErrors {
+				results = batch.Failures(results)
+			}
+
 			if outfmt.IsJSON(cmd.Context()) {
-				return writeJSONOutput(cmd, map[string]interface{}{
+				output := map[string]interface{}{
 					"results": results,
 					"summary": summary,
-				})
+				}
+				if interrupted != nil {
+					output["interrupted"] = true
+				}
+				if err := writeJSONOutput(cmd, output); err != nil {
+					return err
+				}
+				if interrupted != nil {
+					return fmt.Errorf("interrupted after %d of %d transfers: %w", len(results), summary.Total, interrupted)
+				}
+				return nil
 			}
 
-			u.Info(fmt.Sprintf("Completed: %d success, %d failed", summary.Success, summary.Failed))
+			f := outfmt.FromContext(cmd.Context())
+			f.StartTable([]string{"INDEX", "RESULT", "TRANSFER_ID", "ERROR"})
 			for _, r := range results {
-				if r.Success {
-					u.Success(fmt.Sprintf("[%d] Created: %s", r.Index, r.ID))
-				} else {
-					u.Error(fmt.Sprintf("[%d] Failed: %s", r.Index, r.Error))
+				result := "created"
+				if r.DryRun {
+					result = "dry-run"
+				} else if !r.Success {
+					result = "failed"
 				}
+				f.Row(strconv.Itoa(r.Index), result, r.ID, r.Error)
+			}
+			if err := f.EndTable(); err != nil {
+				return err
 			}
+			u.Info(batchCompletedMessage(summary))
 
+			if interrupted != nil {
+				return fmt.Errorf("interrupted after %d of %d transfers: %w", len(results), summary.Total, interrupted)
+			}
 			if summary.Failed > 0 {
 				return fmt.Errorf("%d transfers failed", summary.Failed)
 			}
@@ -513,15 +794,211 @@ Examples:
 		},
 	}
 
-	cmd.Flags().StringVarP(&fromFile, "from-file", "F", "", "JSON file with transfers (- for stdin)")
+	cmd.Flags().StringVarP(&fromFile, "from-file", "F", "", "JSON or .csv file with transfers (- for JSON on stdin)")
 	cmd.Flags().BoolVar(&continueOnError, "continue-on-error", false, "Continue processing on errors")
 	flagAlias(cmd.Flags(), "from-file", "ff")
+	flagAlias(cmd.Flags(), "from-file", "file")
+	cmd.Flags().BoolVar(&onlyErrors, "only-errors", false, "Only emit failed entries (summary still counts all)")
+	cmd.Flags().BoolVar(&stream, "stream", false, "Read NDJSON incrementally and create each transfer as its line arrives, emitting one result per line")
+	cmd.Flags().BoolVar(&deriveRequestIDs, "derive-request-ids", false, "Derive missing request_ids from each item's position and content so re-runs don't duplicate transfers (default true for .csv files)")
 	flagAlias(cmd.Flags(), "continue-on-error", "ce")
 
 	return cmd
 }
 
+// streamTransfersBatch creates a transfer for each NDJSON item as the decoder
+// yields it and reports each result immediately: in JSON mode as one compact
+// JSON line per result followed by a {"summary": ...} line, otherwise as a
+// success/error message. Lines that fail to parse count as failed entries.
+func streamTransfersBatch(cmd *cobra.Command, client *api.Client, dec *batch.Decoder, continueOnError, onlyErrors, deriveRequestIDs bool) error {
+	u := ui.FromContext(cmd.Context())
+	// Results stream as JSON lines; YAML has no line-per-record form, so it
+	// gets the text messages.
+	format := outfmt.Format(cmd.Context())
+	jsonMode := format == "json" || format == "jsonl"
+	enc := json.NewEncoder(commandOutputWriter(cmd))
+
+	emit := func(r batch.Result) error {
+		if onlyErrors && r.Success {
+			return nil
+		}
+		if jsonMode {
+			return enc.Encode(r)
+		}
+		if r.DryRun {
+			u.Info(fmt.Sprintf("[%d] Dry run: not sent", r.Index))
+		} else if r.Success {
+			u.Success(fmt.Sprintf("[%d] Created: %s", r.Index, r.ID))
+		} else {
+			u.Error(fmt.Sprintf("[%d] Failed: %s", r.Index, r.Error))
+		}
+		return nil
+	}
+
+	var summary batch.Summary
+	var interrupted error
+	for {
+		if err := cmd.Context().Err(); err != nil {
+			interrupted = err
+			break
+		}
+		item, err := dec.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var lineErr *batch.LineError
+		if err != nil && !errors.As(err, &lineErr) {
+			return err
+		}
+
+		index := summary.Total
+		summary.Total++
+
+		var result batch.Result
+		if lineErr != nil {
+			result = batch.Result{Index: index, Error: lineErr.Error()}
+		} else {
+			if _, ok := item["request_id"]; !ok {
+				item["request_id"] = batchItemRequestID(index, item, deriveRequestIDs)
+			}
+			t, err := client.CreateTransfer(cmd.Context(), item)
+			if errors.Is(err, api.ErrDryRun) {
+				result = batch.Result{Index: index, Success: true, DryRun: true}
+			} else if err != nil {
+				result = batch.Result{Index: index, Error: err.Error(), Input: item}
+			} else {
+				result = batch.Result{Index: index, Success: true, ID: t.TransferID}
+			}
+		}
+
+		switch {
+		case result.DryRun:
+			summary.Previewed++
+		case result.Success:
+			summary.Success++
+		default:
+			summary.Failed++
+		}
+		if err := emit(result); err != nil {
+			return err
+		}
+
+		if !result.Success {
+			if ctxErr := cmd.Context().Err(); ctxErr != nil {
+				interrupted = ctxErr
+				break
+			}
+			if !continueOnError {
+				break
+			}
+		}
+	}
+
+	if jsonMode {
+		final := map[string]interface{}{"summary": summary}
+		if interrupted != nil {
+			final["interrupted"] = true
+		}
+		if err := enc.Encode(final); err != nil {
+			return err
+		}
+	} else {
+		u.Info(batchCompletedMessage(summary))
+	}
+
+	if interrupted != nil {
+		return fmt.Errorf("interrupted after %d transfers: %w", summary.Total, interrupted)
+	}
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d transfers failed", summary.Failed)
+	}
+	return nil
+}
+
+// batchCompletedMessage reports a batch's outcome counts, including the items
+// previewed under --dry-run.
+func batchCompletedMessage(summary batch.Summary) string {
+	msg := fmt.Sprintf("Completed: %d success, %d failed", summary.Success, summary.Failed)
+	if summary.Previewed > 0 {
+		msg += fmt.Sprintf(", %d previewed (dry-run)", summary.Previewed)
+	}
+	return msg
+}
+
+// transferBatchKeySpace namespaces the request IDs derived for batch items.
+var transferBatchKeySpace = uuid.MustParse("6f1c3a52-8d4e-4b7a-9c21-5e0f7d9a2b36")
+
+// batchItemRequestID returns a request_id for a batch item that has none:
+// derived from the item when derive is set, random otherwise.
+func batchItemRequestID(index int, item map[string]interface{}, derive bool) string {
+	if derive {
+		return batchRequestID(index, item)
+	}
+	return uuid.New().String()
+}
+
+// batchRequestID derives a stable request_id from an item's position and
+// content, so re-running the same input reuses the same idempotency keys.
+func batchRequestID(index int, item map[string]interface{}) string {
+	data, _ := json.Marshal(item) // map keys are sorted, so this is canonical
+	return uuid.NewSHA1(transferBatchKeySpace, []byte(strconv.Itoa(index)+":"+string(data))).String()
+}
+
+// readTransferBatch reads batch-create input, treating .csv files as CSV and
+// anything else as JSON.
+func readTransferBatch(filename string) ([]map[string]interface{}, error) {
+	if !strings.EqualFold(filepath.Ext(filename), ".csv") {
+		return batch.ReadItems(filename)
+	}
+	items, err := batch.ReadCSVItems(filename)
+	if err != nil {
+		return nil, err
+	}
+	for i, item := range items {
+		if err := normalizeCSVTransfer(item); err != nil {
+			// Row 1 is the header.
+			return nil, fmt.Errorf("%s row %d: %w", filename, i+2, err)
+		}
+	}
+	return items, nil
+}
+
+// normalizeCSVTransfer maps the short CSV column names onto the create
+// request fields, fills the same defaults as transfers create, and turns
+// amount cells into exact decimals.
+func normalizeCSVTransfer(item map[string]interface{}) error {
+	for short, field := range map[string]string{"amount": "transfer_amount", "currency": "transfer_currency"} {
+		if v, ok := item[short]; ok {
+			if _, dup := item[field]; dup {
+				return fmt.Errorf("both %s and %s are set", short, field)
+			}
+			item[field] = v
+			delete(item, short)
+		}
+	}
+	if _, ok := item["source_currency"]; !ok && item["transfer_currency"] != nil {
+		item["source_currency"] = item["transfer_currency"]
+	}
+	if _, ok := item["transfer_method"]; !ok {
+		item["transfer_method"] = "LOCAL"
+	}
+	for _, field := range []string{"transfer_amount", "source_amount"} {
+		v, ok := item[field]
+		if !ok {
+			continue
+		}
+		var amount amountValue
+		if err := amount.Set(v.(string)); err != nil {
+			return fmt.Errorf("%s: %w", field, err)
+		}
+		item[field] = json.Number(amount)
+	}
+	return nil
+}
+
 func newTransfersCancelCmd() *cobra.Command {
+	var showTransition bool
+
 	cmd := &cobra.Command{
 		Use:     "cancel <transferId>",
 		Aliases: []string{"x"},
@@ -547,20 +1024,34 @@ func newTransfersCancelCmd() *cobra.Command {
 				return err
 			}
 
+			var before *api.Transfer
+			if showTransition {
+				if before, err = client.GetTransfer(cmd.Context(), transferID); err != nil {
+					return err
+				}
+			}
+
 			t, err := client.CancelTransfer(cmd.Context(), transferID)
 			if err != nil {
 				return err
 			}
 
 			if outfmt.IsJSON(cmd.Context()) {
+				if showTransition {
+					return writeJSONOutput(cmd, transition[*api.Transfer]{Before: before, After: t})
+				}
 				return writeJSONOutput(cmd, t)
 			}
 
 			u.Success(fmt.Sprintf("Cancelled transfer: %s", t.TransferID))
+			if showTransition {
+				u.Info(fmt.Sprintf("Status: %s -> %s", before.Status, t.Status))
+			}
 			return nil
 		},
 	}
 
+	cmd.Flags().BoolVar(&showTransition, "show-transition", false, "Fetch the transfer first and output {before, after} in JSON mode")
 	return cmd
 }
 
@@ -570,17 +1061,26 @@ func newTransfersConfirmationCmd() *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:     "confirmation <transferId>",
-		Aliases: []string{"conf"},
+		Aliases: []string{"conf", "letter"},
 		Short:   "Download transfer confirmation letter as PDF",
 		Long: `Download a PDF confirmation letter for a completed transfer.
 
+Without --file (or --out), the letter is saved as confirmation_<transferId>.pdf
+in the current directory.
+
 Examples:
-  # Download with standard format (includes fees)
+  # Download with standard format (includes fees) to confirmation_tfr_xxx.pdf
+  airwallex transfers letter tfr_xxx
+
+  # Choose the filename
   airwallex transfers confirmation tfr_xxx --file confirmation.pdf
 
   # Download without fee display
   airwallex transfers confirmation tfr_xxx --file confirmation.pdf --format NO_FEE_DISPLAY
 
+  # The global --output-file works too; the PDF bytes are written as-is
+  airwallex transfers confirmation tfr_xxx --output-file confirmation.pdf
+
 Format options:
   STANDARD         - Includes transfer fees in the confirmation letter (default)
   NO_FEE_DISPLAY   - Excludes transfer fees from the confirmation letter`,
@@ -588,8 +1088,16 @@ Format options:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			transferID := NormalizeIDArg(args[0])
 
+			format = strings.ToUpper(format)
 			if format != "STANDARD" && format != "NO_FEE_DISPLAY" {
-				return fmt.Errorf("invalid format: %s (must be STANDARD or NO_FEE_DISPLAY)", format)
+				return fmt.Errorf("invalid format: %s (must be STANDARD or NO_FEE_DISPLAY; the letter is always a PDF)", format)
+			}
+			// Never write PDF bytes to a terminal: without --file, use the
+			// global --output-file if set, else a default filename.
+			flags, _ := rootFlagsFromContext(cmd.Context())
+			toOutputFile := output == "" && flags != nil && flags.outFile != nil
+			if output == "" && !toOutputFile {
+				output = confirmationLetterFilename(transferID)
 			}
 
 			u := ui.FromContext(cmd.Context())
@@ -603,7 +1111,14 @@ Format options:
 				return err
 			}
 
-			if err := os.WriteFile(output, pdfData, 0o600); err != nil {
+			if toOutputFile {
+				// Write to the file itself, bypassing any text writers
+				// (e.g. --mask-ids redaction) layered over stdout.
+				if _, err := flags.outFile.Write(pdfData); err != nil {
+					return fmt.Errorf("failed to write PDF file: %w", err)
+				}
+				output = flags.OutputFile
+			} else if err := os.WriteFile(output, pdfData, 0o600); err != nil {
 				return fmt.Errorf("failed to write PDF file: %w", err)
 			}
 
@@ -613,7 +1128,20 @@ Format options:
 	}
 
 	cmd.Flags().StringVar(&format, "format", "STANDARD", "Format type (STANDARD or NO_FEE_DISPLAY)")
-	cmd.Flags().StringVarP(&output, "file", "f", "", "Output filename (required)")
-	mustMarkRequired(cmd, "file")
+	cmd.Flags().StringVarP(&output, "file", "f", "", "Output filename (default confirmation_<transferId>.pdf)")
+	flagAlias(cmd.Flags(), "file", "out")
 	return cmd
 }
+
+// confirmationLetterFilename is the default file name for a transfer's
+// confirmation letter. Path separators are replaced so an odd ID can't
+// escape the current directory.
+func confirmationLetterFilename(transferID string) string {
+	safe := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' {
+			return '_'
+		}
+		return r
+	}, transferID)
+	return "confirmation_" + safe + ".pdf"
+}
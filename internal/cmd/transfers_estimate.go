@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/outfmt"
+)
+
+// arrivalWindow is a typical delivery time in business days after the
+// transfer is released (0 means the same business day).
+type arrivalWindow struct {
+	MinDays int
+	MaxDays int
+}
+
+// localArrivalWindows holds typical LOCAL delivery times by bank country and
+// clearing system. The "" entry is the country's default clearing system.
+// The API does not expose arrival estimates, so these are indicative only.
+var localArrivalWindows = map[string]map[string]arrivalWindow{
+	"US": {"": {1, 2}, "ACH": {1, 2}, "NEXT_DAY_ACH": {1, 1}, "FEDNOW": {0, 0}, "FEDWIRE": {0, 0}},
+	"CA": {"": {1, 3}, "EFT": {1, 3}, "REGULAR_EFT": {2, 3}, "INTERAC": {0, 0}, "BILL_PAYMENT": {1, 3}},
+	"GB": {"": {0, 0}},
+	"AU": {"": {0, 1}},
+	"NZ": {"": {0, 1}},
+	"SG": {"": {0, 1}},
+	"HK": {"": {0, 1}},
+	"CN": {"": {1, 2}},
+	"JP": {"": {1, 2}},
+	"IN": {"": {0, 1}},
+}
+
+var (
+	// sepaArrivalWindow applies to eurozone countries without their own entry.
+	sepaArrivalWindow = arrivalWindow{0, 1}
+	// defaultLocalArrivalWindow applies to LOCAL corridors missing from the table.
+	defaultLocalArrivalWindow = arrivalWindow{1, 3}
+	// swiftArrivalWindow applies to every SWIFT corridor.
+	swiftArrivalWindow = arrivalWindow{1, 5}
+)
+
+type transferArrivalEstimate struct {
+	Country         string `json:"country"`
+	Currency        string `json:"currency,omitempty"`
+	Method          string `json:"method"`
+	ClearingSystem  string `json:"clearing_system,omitempty"`
+	MinBusinessDays int    `json:"min_business_days"`
+	MaxBusinessDays int    `json:"max_business_days"`
+	Window          string `json:"window"`
+	EarliestDate    string `json:"earliest_date"`
+	LatestDate      string `json:"latest_date"`
+	Source          string `json:"source"`
+}
+
+// lookupArrivalWindow returns the typical window for a corridor. matched is
+// false when a fallback was used because the corridor is not in the table.
+func lookupArrivalWindow(country, method, clearingSystem string) (window arrivalWindow, matched bool) {
+	if method == "SWIFT" {
+		return swiftArrivalWindow, true
+	}
+	if systems, ok := localArrivalWindows[country]; ok {
+		if w, ok := systems[clearingSystem]; ok {
+			return w, true
+		}
+		return systems[""], false
+	}
+	if countryDefaultCurrency[country] == "EUR" {
+		return sepaArrivalWindow, clearingSystem == ""
+	}
+	return defaultLocalArrivalWindow, false
+}
+
+// addBusinessDays returns the date n business days after start, skipping
+// weekends (public holidays are not accounted for). A weekend start rolls
+// forward to Monday first.
+func addBusinessDays(start time.Time, n int) time.Time {
+	d := start
+	for d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+		d = d.AddDate(0, 0, 1)
+	}
+	for n > 0 {
+		d = d.AddDate(0, 0, 1)
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			n--
+		}
+	}
+	return d
+}
+
+func formatArrivalWindow(w arrivalWindow) string {
+	switch {
+	case w.MaxDays == 0:
+		return "same business day"
+	case w.MinDays == w.MaxDays && w.MaxDays == 1:
+		return "1 business day"
+	case w.MinDays == w.MaxDays:
+		return fmt.Sprintf("%d business days", w.MaxDays)
+	case w.MinDays == 0:
+		return fmt.Sprintf("same day to %d business day%s", w.MaxDays, pluralSuffix(w.MaxDays))
+	default:
+		return fmt.Sprintf("%d-%d business days", w.MinDays, w.MaxDays)
+	}
+}
+
+func pluralSuffix(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
+
+func newTransfersEstimateArrivalCmd() *cobra.Command {
+	var country string
+	var currency string
+	var method string
+	var clearingSystem string
+	var from string
+
+	cmd := &cobra.Command{
+		Use:     "estimate-arrival",
+		Aliases: []string{"eta"},
+		Short:   "Estimate when a transfer will arrive",
+		Long: `Estimate the arrival window for a transfer corridor.
+
+The Airwallex API does not return arrival estimates, so this uses a built-in
+table of typical clearing times by bank country, method, and clearing system.
+Estimates count business days (weekends skipped, public holidays ignored) from
+--from, or today, and are indicative only.
+
+Examples:
+  airwallex transfers estimate-arrival --country US --clearing-system ACH
+  airwallex transfers estimate-arrival --country CA --method INTERAC
+  airwallex transfers estimate-arrival --country DE --method SWIFT --from 2025-01-10`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			country = strings.ToUpper(strings.TrimSpace(country))
+			if len(country) != 2 {
+				return fmt.Errorf("--country must be a 2-letter country code, got %q", country)
+			}
+			currency = strings.ToUpper(strings.TrimSpace(currency))
+			if err := validateCurrency(currency); err != nil {
+				return fmt.Errorf("--currency: %w", err)
+			}
+			if currency == "" {
+				currency = countryDefaultCurrency[country]
+			}
+
+			// Accept a clearing system as --method, like transfers create.
+			method = strings.ToUpper(strings.TrimSpace(method))
+			clearingSystem = strings.ToUpper(strings.TrimSpace(clearingSystem))
+			if method != "LOCAL" && method != "SWIFT" {
+				if clearingSystem == "" {
+					clearingSystem = method
+				}
+				method = "LOCAL"
+			}
+			if method == "SWIFT" {
+				clearingSystem = ""
+			}
+
+			start := time.Now()
+			if from != "" {
+				if err := validateDate(from); err != nil {
+					return fmt.Errorf("--from: %w", err)
+				}
+				start, _ = time.Parse("2006-01-02", from)
+			}
+
+			window, matched := lookupArrivalWindow(country, method, clearingSystem)
+			source := "corridor_table"
+			if !matched {
+				source = "default"
+			}
+			est := transferArrivalEstimate{
+				Country:         country,
+				Currency:        currency,
+				Method:          method,
+				ClearingSystem:  clearingSystem,
+				MinBusinessDays: window.MinDays,
+				MaxBusinessDays: window.MaxDays,
+				Window:          formatArrivalWindow(window),
+				EarliestDate:    addBusinessDays(start, window.MinDays).Format("2006-01-02"),
+				LatestDate:      addBusinessDays(start, window.MaxDays).Format("2006-01-02"),
+				Source:          source,
+			}
+
+			if outfmt.IsJSON(cmd.Context()) {
+				return writeJSONOutput(cmd, est)
+			}
+
+			corridor := country
+			if currency != "" {
+				corridor += " " + currency
+			}
+			via := method
+			if clearingSystem != "" {
+				via += " (" + clearingSystem + ")"
+			}
+			arrival := est.EarliestDate
+			if est.LatestDate != est.EarliestDate {
+				arrival += " to " + est.LatestDate
+			}
+			rows := []outfmt.KV{
+				{Key: "corridor", Value: corridor},
+				{Key: "method", Value: via},
+				{Key: "estimate", Value: est.Window},
+				{Key: "arrival", Value: arrival},
+			}
+			if !matched {
+				rows = append(rows, outfmt.KV{Key: "note", Value: "corridor not in table; using a generic estimate"})
+			}
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
+		},
+	}
+
+	cmd.Flags().StringVar(&country, "country", "", "Beneficiary bank country code (e.g., US) (required)")
+	cmd.Flags().StringVar(&currency, "currency", "", "Transfer currency (defaults to the country's currency where unambiguous)")
+	cmd.Flags().StringVarP(&method, "method", "m", "LOCAL", "LOCAL, SWIFT, or a clearing system (INTERAC, ACH, FEDWIRE, etc.)")
+	cmd.Flags().StringVar(&clearingSystem, "clearing-system", "", "Local clearing system (e.g., ACH, EFT, INTERAC)")
+	cmd.Flags().StringVar(&from, "from", "", "Release date to count from (YYYY-MM-DD, default today)")
+	mustMarkRequired(cmd, "country")
+	return cmd
+}
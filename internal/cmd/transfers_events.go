@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/api"
+	"github.com/salmonumbrella/airwallex-cli/internal/outfmt"
+	"github.com/salmonumbrella/airwallex-cli/internal/ui"
+)
+
+func newTransfersEventsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "events <transferId>",
+		Aliases: []string{"history", "timeline"},
+		Short:   "Show a transfer's status history",
+		Long: `Show the status changes of a transfer in chronological order.
+
+When the account has no event history for the transfer, the timeline is
+derived from the transfer itself: its creation time and its current status.
+
+Examples:
+  airwallex transfers events tfr_123
+  airwallex transfers events tfr_123 --output json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			transferID := NormalizeIDArg(args[0])
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			events, err := client.ListTransferEvents(cmd.Context(), transferID)
+			if api.IsNotFoundError(err) {
+				// No events endpoint for this transfer; GetTransfer reports
+				// a genuinely unknown ID.
+				t, getErr := client.GetTransfer(cmd.Context(), transferID)
+				if getErr != nil {
+					return getErr
+				}
+				ui.FromContext(cmd.Context()).Info("No event history available; showing creation and current status")
+				events, err = transferEventsFromTransfer(t), nil
+			}
+			if err != nil {
+				return err
+			}
+
+			f := outfmt.FromContext(cmd.Context())
+			if outfmt.IsJSON(cmd.Context()) {
+				return f.Output(events)
+			}
+			if len(events) == 0 {
+				f.Empty("No events")
+				return nil
+			}
+
+			f.StartTable([]string{"TIME", "STATUS", "REASON"})
+			colTypes := []outfmt.ColumnType{outfmt.ColumnPlain, outfmt.ColumnStatus, outfmt.ColumnPlain}
+			for _, e := range events {
+				f.ColorRow(colTypes, e.CreatedAt, e.Status, e.Reason)
+			}
+			return f.EndTable()
+		},
+	}
+}
+
+// transferEventsFromTransfer builds the best timeline the transfer itself
+// supports: when it was created and, if it has moved on, its current status.
+func transferEventsFromTransfer(t *api.Transfer) []api.TransferEvent {
+	events := []api.TransferEvent{{Status: "CREATED", CreatedAt: t.CreatedAt}}
+	if t.Status != "" {
+		events = append(events, api.TransferEvent{Status: t.Status, CreatedAt: t.UpdatedAt})
+	}
+	return events
+}
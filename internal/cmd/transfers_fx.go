@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/api"
+)
+
+// attachTransferFX sets FX on each cross-currency transfer that references a
+// conversion. Transfers sharing a conversion trigger a single lookup.
+func attachTransferFX(ctx context.Context, client *api.Client, transfers []api.Transfer) error {
+	byConversion := make(map[string][]int)
+	for i, t := range transfers {
+		if t.ConversionID == "" || t.SourceCurrency == "" || t.SourceCurrency == t.TransferCurrency {
+			continue
+		}
+		byConversion[t.ConversionID] = append(byConversion[t.ConversionID], i)
+	}
+	if len(byConversion) == 0 {
+		return nil
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	// Lookups share the client's connection pool, so they are bounded by the
+	// same per-host limit rather than queuing inside the transport.
+	sem := make(chan struct{}, api.MaxConnsPerHost)
+	for id, idxs := range byConversion {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(id string, idxs []int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			conv, err := client.GetConversion(ctx, id)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("fetching conversion %s for transfer %s: %w", id, transfers[idxs[0]].TransferID, err)
+				}
+				return
+			}
+			for _, i := range idxs {
+				transfers[i].FX = &api.TransferFX{
+					ConversionID: conv.ID,
+					Rate:         conv.Rate,
+					SellCurrency: conv.SellCurrency,
+					SellAmount:   conv.SellAmount,
+					BuyCurrency:  conv.BuyCurrency,
+					BuyAmount:    conv.BuyAmount,
+				}
+			}
+		}(id, idxs)
+	}
+	wg.Wait()
+	return firstErr
+}
@@ -1,11 +1,30 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/api"
+	"github.com/salmonumbrella/airwallex-cli/internal/batch"
+	"github.com/salmonumbrella/airwallex-cli/internal/exitcode"
+	"github.com/salmonumbrella/airwallex-cli/internal/iocontext"
 )
 
 func TestTransfersListCmd_PageSizeFlag(t *testing.T) {
@@ -472,3 +491,1397 @@ func setRequiredTransferFlagsNoAmount(t *testing.T, cmd *cobra.Command) {
 		}
 	}
 }
+
+func TestTransfersCreate_Metadata(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	var body map[string]interface{}
+	testMockServer.Handle("POST", "/api/v1/transfers/create", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"tfr_meta","status":"NEW"}`))
+	})
+	defer testMockServer.HandleError("POST", "/api/v1/transfers/create", http.StatusNotFound, "endpoint not found")
+
+	run := func(metadata ...string) error {
+		args := []string{
+			"transfers", "create",
+			"--beneficiary-id", "ben_123",
+			"--transfer-amount", "100",
+			"--transfer-currency", "USD",
+			"--source-currency", "USD",
+			"--reference", "Invoice 123",
+			"--reason", "payment_to_supplier",
+			"--output", "json",
+		}
+		for _, m := range metadata {
+			args = append(args, "--metadata", m)
+		}
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: io.Discard, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetArgs(args)
+		return root.ExecuteContext(ctx)
+	}
+
+	if err := run("invoice=INV-123", "cost_center=ops=east"); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	want := map[string]interface{}{"invoice": "INV-123", "cost_center": "ops=east"}
+	if !reflect.DeepEqual(body["metadata"], want) {
+		t.Errorf("metadata = %v, want %v", body["metadata"], want)
+	}
+
+	body = nil
+	if err := run("invoice=INV-123", "invoice=INV-456"); err == nil || !strings.Contains(err.Error(), "duplicate") {
+		t.Errorf("expected duplicate key error, got %v", err)
+	}
+	if err := run("=value"); err == nil || !strings.Contains(err.Error(), "key=value") {
+		t.Errorf("expected empty key error, got %v", err)
+	}
+	if body != nil {
+		t.Errorf("invalid metadata should not reach the API, got %v", body)
+	}
+}
+
+func TestTransfersCreate_WaitWithEmptyResponse(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	var requestID string
+	testMockServer.Handle("POST", "/api/v1/transfers/create", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		requestID, _ = body["request_id"].(string)
+		w.WriteHeader(http.StatusOK)
+	})
+	defer testMockServer.HandleError("POST", "/api/v1/transfers/create", http.StatusNotFound, "endpoint not found")
+
+	var out, errOut bytes.Buffer
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: &errOut, In: strings.NewReader("")})
+	root := NewRootCmd()
+	root.SetArgs([]string{
+		"transfers", "create",
+		"--beneficiary-id", "ben_123",
+		"--transfer-amount", "100",
+		"--transfer-currency", "USD",
+		"--source-currency", "USD",
+		"--reference", "Invoice 123",
+		"--reason", "payment_to_supplier",
+		"--wait",
+		"--output", "json",
+	})
+	if err := root.ExecuteContext(ctx); err != nil {
+		t.Fatalf("create --wait should succeed when the transfer was created: %v", err)
+	}
+
+	var got noDetailsResult
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+	if !got.Success || requestID == "" || !strings.Contains(got.Message, requestID) {
+		t.Errorf("result = %+v, want success naming request_id %q", got, requestID)
+	}
+	if !strings.Contains(errOut.String(), "warning: not waiting") {
+		t.Errorf("stderr = %q, want a warning that --wait was skipped", errOut.String())
+	}
+}
+
+func TestTransfersCreate_SourceOfFunds(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	var body map[string]interface{}
+	testMockServer.Handle("POST", "/api/v1/transfers/create", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"tfr_sof","status":"NEW"}`))
+	})
+	defer testMockServer.HandleError("POST", "/api/v1/transfers/create", http.StatusNotFound, "endpoint not found")
+
+	run := func(extra ...string) error {
+		args := append([]string{
+			"transfers", "create",
+			"--beneficiary-id", "ben_123",
+			"--transfer-amount", "20000",
+			"--transfer-currency", "CNY",
+			"--source-currency", "USD",
+			"--reference", "Invoice 123",
+			"--reason", "payment_to_supplier",
+			"--output", "json",
+		}, extra...)
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: io.Discard, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetArgs(args)
+		return root.ExecuteContext(ctx)
+	}
+
+	if err := run("--source-of-funds", "business_income"); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if got := body["source_of_funds"]; got != "business_income" {
+		t.Errorf("source_of_funds = %v, want business_income", got)
+	}
+
+	body = nil
+	err := run("--validate")
+	if err == nil || !strings.Contains(err.Error(), "--source-of-funds is required for CNY payouts") {
+		t.Errorf("expected missing source of funds error, got %v", err)
+	}
+	if err := run("--validate", "--source-of-funds", "savings"); err != nil {
+		t.Errorf("validate with source of funds failed: %v", err)
+	}
+	if body != nil {
+		t.Errorf("--validate should not reach the API, got %v", body)
+	}
+}
+
+func TestTransfersCreate_ShowIdempotencyKey(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	var sentKey string
+	testMockServer.Handle("POST", "/api/v1/transfers/create", func(w http.ResponseWriter, r *http.Request) {
+		sentKey = r.Header.Get("x-idempotency-key")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"tfr_idem","status":"NEW"}`))
+	})
+	defer testMockServer.HandleError("POST", "/api/v1/transfers/create", http.StatusNotFound, "endpoint not found")
+
+	run := func(extra ...string) (string, string) {
+		t.Helper()
+		var out, errOut bytes.Buffer
+		args := append([]string{
+			"transfers", "create",
+			"--beneficiary-id", "ben_123",
+			"--transfer-amount", "100",
+			"--transfer-currency", "USD",
+			"--source-currency", "USD",
+			"--reference", "Invoice 123",
+			"--reason", "payment_to_supplier",
+			"--output", "json",
+			"--show-idempotency-key",
+		}, extra...)
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: &errOut, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetArgs(args)
+		if err := root.ExecuteContext(ctx); err != nil {
+			t.Fatalf("create failed: %v", err)
+		}
+		return out.String(), errOut.String()
+	}
+
+	out, errOut := run()
+	if sentKey == "" {
+		t.Fatal("expected a generated x-idempotency-key header")
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON output %q: %v", out, err)
+	}
+	if got["idempotency_key"] != sentKey || got["id"] != "tfr_idem" {
+		t.Errorf("JSON output = %v, want id tfr_idem and idempotency_key %q", got, sentKey)
+	}
+	if want := "idempotency-key: " + sentKey + " (POST /api/v1/transfers/create)"; !strings.Contains(errOut, want) {
+		t.Errorf("stderr = %q, want it to contain %q", errOut, want)
+	}
+
+	out, _ = run("--idempotency-key", "retry-key-123")
+	if sentKey != "retry-key-123" {
+		t.Errorf("x-idempotency-key = %q, want supplied key", sentKey)
+	}
+	if !strings.Contains(out, `"idempotency_key": "retry-key-123"`) {
+		t.Errorf("JSON output should report the supplied key, got %s", out)
+	}
+}
+
+func TestTransfersCreate_ExactAmount(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	var raw []byte
+	testMockServer.Handle("POST", "/api/v1/transfers/create", func(w http.ResponseWriter, r *http.Request) {
+		raw, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"tfr_exact","status":"NEW","transfer_amount":12345678901234.56}`))
+	})
+	defer testMockServer.HandleError("POST", "/api/v1/transfers/create", http.StatusNotFound, "endpoint not found")
+
+	run := func(amountFlag, amount string) (string, error) {
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetArgs([]string{
+			"transfers", "create",
+			"--beneficiary-id", "ben_123",
+			amountFlag, amount,
+			"--transfer-currency", "USD",
+			"--source-currency", "USD",
+			"--reference", "Invoice 123",
+			"--reason", "payment_to_supplier",
+			"--output", "json",
+		})
+		err := root.ExecuteContext(ctx)
+		return out.String(), err
+	}
+
+	// 12345678901234.56 is not representable as a float64.
+	out, err := run("--transfer-amount", "12345678901234.56")
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if !bytes.Contains(raw, []byte(`"transfer_amount":12345678901234.56`)) {
+		t.Errorf("request body should carry the exact amount, got %s", raw)
+	}
+	if !strings.Contains(out, "12345678901234.56") {
+		t.Errorf("output should keep the exact amount, got %s", out)
+	}
+
+	if _, err := run("--source-amount", "0.10"); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if !bytes.Contains(raw, []byte(`"source_amount":0.10`)) {
+		t.Errorf("request body should keep the digits as given, got %s", raw)
+	}
+
+	raw = nil
+	for _, bad := range []string{"1e3", "-5", "0.00", "12,50"} {
+		if _, err := run("--transfer-amount", bad); err == nil || !strings.Contains(err.Error(), "positive decimal amount") {
+			t.Errorf("--transfer-amount %s: expected decimal validation error, got %v", bad, err)
+		}
+	}
+	if raw != nil {
+		t.Errorf("invalid amounts should not reach the API, got %s", raw)
+	}
+}
+
+func TestTransfersCreate_SwiftValidationReportsAllIssues(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: io.Discard, ErrOut: io.Discard, In: strings.NewReader("")})
+	root := NewRootCmd()
+	root.SetArgs([]string{
+		"transfers", "create",
+		"--beneficiary-id", "ben_123",
+		"--transfer-amount", "5000",
+		"--transfer-currency", "EUR",
+		"--source-currency", "USD",
+		"--method", "SWIFT",
+		"--reference", "Invoice 123",
+		"--reason", "payment_to_supplier",
+		"--swift-charge-option", "OUR",
+		"--remittance-info", strings.Repeat("x", 141),
+	})
+	err := root.ExecuteContext(ctx)
+	if err == nil {
+		t.Fatal("expected SWIFT validation error")
+	}
+	for _, want := range []string{
+		"invalid SWIFT transfer",
+		"--charge-account-id is required when --swift-charge-option is PAYER",
+		"--remittance-info must be at most 140 characters (got 141)",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error missing %q:\n%v", want, err)
+		}
+	}
+}
+
+func TestValidateSwiftTransfer(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       swiftTransferOptions
+		wantErr    string
+		wantOption string
+	}{
+		{name: "local without swift fields", opts: swiftTransferOptions{Method: "LOCAL"}},
+		{name: "shared by alias", opts: swiftTransferOptions{Method: "SWIFT", ChargeOption: "sha"}, wantOption: "SHARED"},
+		{name: "payer with account", opts: swiftTransferOptions{Method: "SWIFT", ChargeOption: "OUR", ChargeAccountID: "acct_1"}, wantOption: "PAYER"},
+		{name: "swift field on local", opts: swiftTransferOptions{Method: "LOCAL", RemittanceInfo: "INV 1"}, wantErr: "only apply to SWIFT transfers"},
+		{name: "charge account without payer", opts: swiftTransferOptions{Method: "SWIFT", ChargeAccountID: "acct_1"}, wantErr: "only applies when --swift-charge-option is PAYER"},
+		{name: "unknown option", opts: swiftTransferOptions{Method: "SWIFT", ChargeOption: "BEN"}, wantErr: "invalid --swift-charge-option"},
+		{name: "bad charset", opts: swiftTransferOptions{Method: "SWIFT", RemittanceInfo: "INV #1"}, wantErr: "may only contain"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSwiftTransfer(&tt.opts)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if tt.opts.ChargeOption != tt.wantOption {
+					t.Errorf("ChargeOption = %q, want %q", tt.opts.ChargeOption, tt.wantOption)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransfersBatchCreate_OnlyErrors(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.Handle("POST", "/api/v1/transfers/create", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		if body["reference"] == "BAD" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":"validation_error","message":"invalid beneficiary"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"tfr_ok","status":"NEW"}`))
+	})
+	defer testMockServer.HandleError("POST", "/api/v1/transfers/create", http.StatusNotFound, "endpoint not found")
+
+	input := filepath.Join(t.TempDir(), "transfers.json")
+	items := `[{"reference":"OK1"},{"reference":"BAD"},{"reference":"OK2"}]`
+	if err := os.WriteFile(input, []byte(items), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+	root := NewRootCmd()
+	root.SetArgs([]string{"transfers", "batch-create", "--from-file", input, "--continue-on-error", "--only-errors", "--output", "json"})
+	if err := root.ExecuteContext(ctx); err != nil {
+		t.Fatalf("batch-create failed: %v", err)
+	}
+
+	var got struct {
+		Results []struct {
+			Index   int    `json:"index"`
+			Success bool   `json:"success"`
+			Error   string `json:"error"`
+		} `json:"results"`
+		Summary struct {
+			Total   int `json:"total"`
+			Success int `json:"success"`
+			Failed  int `json:"failed"`
+		} `json:"summary"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+	if len(got.Results) != 1 {
+		t.Fatalf("expected only 1 failed result, got %d: %s", len(got.Results), out.String())
+	}
+	if got.Results[0].Index != 1 || got.Results[0].Success || !strings.Contains(got.Results[0].Error, "invalid beneficiary") {
+		t.Errorf("unexpected failure entry: %+v", got.Results[0])
+	}
+	if got.Summary.Total != 3 || got.Summary.Success != 2 || got.Summary.Failed != 1 {
+		t.Errorf("summary = %+v, want total=3 success=2 failed=1", got.Summary)
+	}
+}
+
+func TestTransfersBatchCreate_DryRunPreviewsEveryItem(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	var calls int32
+	testMockServer.Handle("POST", "/api/v1/transfers/create", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	defer testMockServer.HandleError("POST", "/api/v1/transfers/create", http.StatusNotFound, "endpoint not found")
+
+	dir := t.TempDir()
+	input := filepath.Join(dir, "transfers.json")
+	if err := os.WriteFile(input, []byte(`[{"reference":"A"},{"reference":"B"}]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ndjson := filepath.Join(dir, "transfers.ndjson")
+	if err := os.WriteFile(ndjson, []byte("{\"reference\":\"A\"}\n{\"reference\":\"B\"}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) string {
+		t.Helper()
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetArgs(append([]string{"transfers", "batch-create", "--dry-run"}, args...))
+		if err := root.ExecuteContext(ctx); err != nil {
+			t.Fatalf("batch-create %v --dry-run failed: %v", args, err)
+		}
+		return out.String()
+	}
+
+	table := run("--from-file", input)
+	if n := strings.Count(table, "[DRY-RUN] POST"); n != 2 {
+		t.Errorf("previewed %d requests, want 2:\n%s", n, table)
+	}
+	if n := strings.Count(table, "dry-run"); n != 2 || strings.Contains(table, "failed") {
+		t.Errorf("want 2 dry-run rows and no failures:\n%s", table)
+	}
+
+	stream := run("--from-file", ndjson, "--stream", "--output", "json")
+	if n := strings.Count(stream, `"dry_run":true`); n != 2 {
+		t.Errorf("streamed %d dry-run results, want 2:\n%s", n, stream)
+	}
+	if !strings.Contains(stream, `{"summary":{"total":2,"success":0,"failed":0,"previewed":2}}`) {
+		t.Errorf("stream summary should count both items as previewed:\n%s", stream)
+	}
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Errorf("--dry-run sent %d transfers, want 0", n)
+	}
+}
+
+func TestTransfersBatchCreate_StreamsNDJSONFromStdin(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	received := make(chan string, 4)
+	testMockServer.Handle("POST", "/api/v1/transfers/create", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		ref, _ := body["reference"].(string)
+		received <- ref
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"tfr_` + ref + `","status":"NEW"}`))
+	})
+	defer testMockServer.HandleError("POST", "/api/v1/transfers/create", http.StatusNotFound, "endpoint not found")
+
+	stdin, feed := io.Pipe()
+	var out bytes.Buffer
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: stdin})
+	root := NewRootCmd()
+	root.SetArgs([]string{"transfers", "batch-create", "--from-file", "-", "--stream", "--continue-on-error", "--output", "json"})
+	done := make(chan error, 1)
+	go func() { done <- root.ExecuteContext(ctx) }()
+
+	waitFor := func(want string) {
+		t.Helper()
+		select {
+		case got := <-received:
+			if got != want {
+				t.Fatalf("created %q, want %q", got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("transfer %q was not created before more input arrived", want)
+		}
+	}
+
+	// Each transfer must be created while stdin is still open.
+	_, _ = io.WriteString(feed, `{"reference":"one"}`+"\n")
+	waitFor("one")
+	_, _ = io.WriteString(feed, "not json\n")
+	_, _ = io.WriteString(feed, `{"reference":"two"}`+"\n")
+	waitFor("two")
+	_ = feed.Close()
+
+	if err := <-done; err == nil || !strings.Contains(err.Error(), "1 transfers failed") {
+		t.Fatalf("batch-create error = %v, want 1 failure for the bad line", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 3 result lines and a summary, got:\n%s", out.String())
+	}
+	var results []batch.Result
+	for _, line := range lines[:3] {
+		var r batch.Result
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("result line is not JSON: %v: %s", err, line)
+		}
+		results = append(results, r)
+	}
+	if !results[0].Success || results[0].ID != "tfr_one" || results[1].Success || !strings.Contains(results[1].Error, "line 2") || results[2].ID != "tfr_two" || results[2].Index != 2 {
+		t.Errorf("unexpected results: %+v", results)
+	}
+	var summary struct {
+		Summary struct {
+			Total, Success, Failed int
+		} `json:"summary"`
+	}
+	if err := json.Unmarshal([]byte(lines[3]), &summary); err != nil {
+		t.Fatalf("summary line is not JSON: %v: %s", err, lines[3])
+	}
+	if summary.Summary.Total != 3 || summary.Summary.Success != 2 || summary.Summary.Failed != 1 {
+		t.Errorf("summary = %+v, want total=3 success=2 failed=1", summary.Summary)
+	}
+}
+
+func TestTransfersBatchCreate_StreamYAMLWritesNoJSONLines(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.HandleJSON("POST", "/api/v1/transfers/create", http.StatusOK, map[string]any{"id": "tfr_1", "status": "NEW"})
+	defer testMockServer.HandleError("POST", "/api/v1/transfers/create", http.StatusNotFound, "endpoint not found")
+
+	var out bytes.Buffer
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader(`{"reference":"one"}` + "\n")})
+	root := NewRootCmd()
+	root.SetOut(&out)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"transfers", "batch-create", "--from-file", "-", "--stream", "--output", "yaml"})
+	if err := root.ExecuteContext(ctx); err != nil {
+		t.Fatalf("batch-create failed: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("--output yaml should not stream JSON lines to stdout, got:\n%s", out.String())
+	}
+}
+
+func TestTransfersList_YAMLDocuments(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.HandleJSON("GET", "/api/v1/transfers", http.StatusOK, map[string]any{
+		"items": []any{
+			map[string]any{"id": "tfr_1", "status": "PAID"},
+			map[string]any{"id": "tfr_2", "status": "NEW"},
+		},
+		"has_more": false,
+	})
+	defer testMockServer.HandleError("GET", "/api/v1/transfers", http.StatusNotFound, "endpoint not found")
+
+	var out bytes.Buffer
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+	root := NewRootCmd()
+	root.SetArgs([]string{"transfers", "list", "--output", "yaml", "--yaml-documents"})
+	if err := root.ExecuteContext(ctx); err != nil {
+		t.Fatalf("transfers list failed: %v", err)
+	}
+
+	docs := strings.Split(strings.TrimPrefix(out.String(), "---\n"), "---\n")
+	if len(docs) != 2 {
+		t.Fatalf("expected 2 YAML documents, got %d:\n%s", len(docs), out.String())
+	}
+	for i, want := range []string{"tfr_1", "tfr_2"} {
+		var doc map[string]any
+		if err := yaml.Unmarshal([]byte(docs[i]), &doc); err != nil {
+			t.Fatalf("document %d does not parse on its own: %v\n%s", i, err, docs[i])
+		}
+		if doc["id"] != want {
+			t.Errorf("document %d id = %v, want %s", i, doc["id"], want)
+		}
+	}
+
+	root = NewRootCmd()
+	root.SetArgs([]string{"transfers", "list", "--output", "json", "--yaml-documents"})
+	root.SetErr(io.Discard)
+	if err := root.ExecuteContext(iocontext.WithIO(context.Background(), &iocontext.IO{Out: io.Discard, ErrOut: io.Discard, In: strings.NewReader("")})); err == nil || !strings.Contains(err.Error(), "requires --output yaml") {
+		t.Errorf("expected --yaml-documents to require yaml output, got %v", err)
+	}
+}
+
+func TestTransfersGet_MoneyObjects(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.HandleJSON("GET", "/api/v1/transfers/tfr_money", http.StatusOK, map[string]any{
+		"id":                "tfr_money",
+		"transfer_amount":   100.5,
+		"transfer_currency": "EUR",
+		"source_amount":     110.25,
+		"source_currency":   "USD",
+		"status":            "PAID",
+	})
+
+	var out bytes.Buffer
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+	root := NewRootCmd()
+	root.SetArgs([]string{"transfers", "get", "tfr_money", "--output", "json", "--money-objects"})
+	if err := root.ExecuteContext(ctx); err != nil {
+		t.Fatalf("transfers get failed: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+	for _, key := range []string{"transfer_amount", "transfer_currency", "source_amount", "source_currency"} {
+		if _, ok := got[key]; ok {
+			t.Errorf("expected %s to be grouped away, got %v", key, got)
+		}
+	}
+	transfer, _ := got["transfer"].(map[string]any)
+	if transfer["amount"] != 100.5 || transfer["currency"] != "EUR" {
+		t.Errorf("transfer = %v, want {amount: 100.5, currency: EUR}", got["transfer"])
+	}
+	source, _ := got["source"].(map[string]any)
+	if source["amount"] != 110.25 || source["currency"] != "USD" {
+		t.Errorf("source = %v, want {amount: 110.25, currency: USD}", got["source"])
+	}
+	if got["status"] != "PAID" {
+		t.Errorf("status = %v, want PAID", got["status"])
+	}
+}
+
+func TestTransfersGet_Pluck(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.HandleJSON("GET", "/api/v1/transfers/tfr_pluck", http.StatusOK, map[string]any{
+		"id":                "tfr_pluck",
+		"status":            "PAID",
+		"transfer_amount":   100.5,
+		"transfer_currency": "EUR",
+	})
+	testMockServer.HandleJSON("GET", "/api/v1/transfers", http.StatusOK, map[string]any{
+		"items": []map[string]any{
+			{"id": "tfr_1", "status": "PAID"},
+			{"id": "tfr_2", "status": "PROCESSING"},
+		},
+		"has_more": false,
+	})
+	defer testMockServer.HandleError("GET", "/api/v1/transfers/tfr_pluck", http.StatusNotFound, "endpoint not found")
+	defer testMockServer.HandleError("GET", "/api/v1/transfers", http.StatusNotFound, "endpoint not found")
+
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetArgs(args)
+		err := root.ExecuteContext(ctx)
+		return out.String(), err
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"text prints raw scalar", []string{"transfers", "get", "tfr_pluck", "--pluck", "status"}, "PAID\n"},
+		{"json keeps quoting", []string{"transfers", "get", "tfr_pluck", "--pluck", "status", "--output", "json"}, "\"PAID\"\n"},
+		{"nested path", []string{"transfers", "get", "tfr_pluck", "--money-objects", "--pluck", "transfer.currency"}, "EUR\n"},
+		{"number", []string{"transfers", "get", "tfr_pluck", "--pluck", "transfer_amount"}, "100.5\n"},
+		{"list prints one per line", []string{"transfers", "list", "--pluck", "status"}, "PAID\nPROCESSING\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := run(tt.args...)
+			if err != nil {
+				t.Fatalf("%v failed: %v", tt.args, err)
+			}
+			if out != tt.want {
+				t.Errorf("output = %q, want %q", out, tt.want)
+			}
+		})
+	}
+
+	if _, err := run("transfers", "get", "tfr_pluck", "--pluck", "nope"); err == nil || !strings.Contains(err.Error(), "--pluck nope: path not found") {
+		t.Errorf("missing path error = %v", err)
+	}
+	if _, err := run("transfers", "get", "tfr_pluck", "--money-objects", "--pluck", "transfer"); err == nil || !strings.Contains(err.Error(), "not a scalar") {
+		t.Errorf("non-scalar error = %v", err)
+	}
+	if _, err := run("transfers", "get", "tfr_pluck", "--pluck", "status", "--query", ".id"); err == nil {
+		t.Error("expected --pluck with --query to fail")
+	}
+}
+
+func TestTransfersBatchCreate_InterruptedStillWritesValidJSON(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls atomic.Int32
+	testMockServer.Handle("POST", "/api/v1/transfers/create", func(w http.ResponseWriter, r *http.Request) {
+		// Simulate SIGTERM arriving while the second transfer is in flight.
+		if calls.Add(1) == 2 {
+			cancel()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"tfr_ok","status":"NEW"}`))
+	})
+	defer testMockServer.HandleError("POST", "/api/v1/transfers/create", http.StatusNotFound, "endpoint not found")
+
+	input := filepath.Join(t.TempDir(), "transfers.json")
+	if err := os.WriteFile(input, []byte(`[{"reference":"A"},{"reference":"B"},{"reference":"C"},{"reference":"D"}]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	ioCtx := iocontext.WithIO(ctx, &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+	root := NewRootCmd()
+	root.SetArgs([]string{"transfers", "batch-create", "--from-file", input, "--continue-on-error", "--output", "json"})
+	err := root.ExecuteContext(ioCtx)
+	if err == nil || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected interruption error wrapping context.Canceled, got %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected processing to stop after 2 requests, got %d", got)
+	}
+
+	var got struct {
+		Results     []map[string]any `json:"results"`
+		Summary     map[string]any   `json:"summary"`
+		Interrupted bool             `json:"interrupted"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("truncated output is not valid JSON: %v\n%s", err, out.String())
+	}
+	if !got.Interrupted {
+		t.Error("expected interrupted=true in output")
+	}
+	if len(got.Results) == 0 || len(got.Results) > 2 {
+		t.Errorf("expected 1-2 results before interruption, got %d", len(got.Results))
+	}
+}
+
+func TestTransfersList_GroupByStatus(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	transfer := func(id, amount, currency, status string) map[string]any {
+		return map[string]any{"id": id, "transfer_amount": json.Number(amount), "transfer_currency": currency, "status": status}
+	}
+	testMockServer.HandleJSON("GET", "/api/v1/transfers", http.StatusOK, map[string]any{
+		"items": []any{
+			transfer("tfr_1", "100", "USD", "PAID"),
+			transfer("tfr_2", "25.50", "USD", "PENDING"),
+			transfer("tfr_3", "50", "USD", "PAID"),
+			transfer("tfr_4", "10", "EUR", "PAID"),
+		},
+		"has_more": false,
+	})
+	defer testMockServer.HandleError("GET", "/api/v1/transfers", http.StatusNotFound, "endpoint not found")
+
+	var out bytes.Buffer
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+	root := NewRootCmd()
+	root.SetArgs([]string{"transfers", "list", "--output", "text", "--group-by", "status"})
+	if err := root.ExecuteContext(ctx); err != nil {
+		t.Fatalf("transfers list --group-by failed: %v", err)
+	}
+
+	got := out.String()
+	for _, want := range []string{
+		"STATUS: PAID (3)",
+		"STATUS: PENDING (1)",
+		"Subtotal AMOUNT: 10.00 EUR, 150.00 USD",
+		"Subtotal AMOUNT: 25.50 USD",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "STATUS: PAID") > strings.Index(got, "STATUS: PENDING") {
+		t.Errorf("groups should follow first appearance order:\n%s", got)
+	}
+
+	root = NewRootCmd()
+	root.SetArgs([]string{"transfers", "list", "--output", "text", "--group-by", "nope"})
+	root.SetErr(io.Discard)
+	err := root.ExecuteContext(iocontext.WithIO(context.Background(), &iocontext.IO{Out: io.Discard, ErrOut: io.Discard, In: strings.NewReader("")}))
+	if err == nil || !strings.Contains(err.Error(), "unknown --group-by column") {
+		t.Errorf("unknown column error = %v", err)
+	}
+}
+
+func TestTransfersEstimateArrival(t *testing.T) {
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(&out)
+		root.SetErr(io.Discard)
+		root.SetArgs(append([]string{"transfers", "estimate-arrival"}, args...))
+		err := root.ExecuteContext(ctx)
+		return out.String(), err
+	}
+
+	// 2025-01-10 is a Friday, so a 1-2 business day ACH lands Mon-Tue.
+	out, err := run("--country", "us", "--method", "ACH", "--from", "2025-01-10", "--output", "json")
+	if err != nil {
+		t.Fatalf("estimate-arrival failed: %v", err)
+	}
+	var est transferArrivalEstimate
+	if err := json.Unmarshal([]byte(out), &est); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	want := transferArrivalEstimate{
+		Country: "US", Method: "LOCAL", ClearingSystem: "ACH",
+		MinBusinessDays: 1, MaxBusinessDays: 2, Window: "1-2 business days",
+		EarliestDate: "2025-01-13", LatestDate: "2025-01-14", Source: "corridor_table",
+	}
+	if est != want {
+		t.Errorf("estimate = %+v, want %+v", est, want)
+	}
+
+	out, err = run("--country", "DE", "--method", "SWIFT", "--from", "2025-01-10")
+	if err != nil {
+		t.Fatalf("estimate-arrival failed: %v", err)
+	}
+	for _, want := range []string{"DE EUR", "SWIFT", "1-5 business days", "2025-01-13 to 2025-01-17"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("text output missing %q:\n%s", want, out)
+		}
+	}
+
+	out, err = run("--country", "ZZ", "--from", "2025-01-10")
+	if err != nil {
+		t.Fatalf("estimate-arrival failed: %v", err)
+	}
+	if !strings.Contains(out, "generic estimate") {
+		t.Errorf("unknown corridor should be flagged:\n%s", out)
+	}
+}
+
+func TestTransfersList_IncludeFX(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.HandleJSON("GET", "/api/v1/transfers", http.StatusOK, map[string]interface{}{
+		"items": []map[string]interface{}{
+			{
+				"id": "tfr_fx", "transfer_amount": 100, "transfer_currency": "EUR",
+				"source_amount": 110.5, "source_currency": "USD", "status": "PAID",
+				"conversion_id": "conv_1", "fee_amount": 2.5, "fee_currency": "USD",
+			},
+			{
+				"id": "tfr_same", "transfer_amount": 50, "transfer_currency": "USD",
+				"source_amount": 50, "source_currency": "USD", "status": "PAID",
+			},
+		},
+		"has_more": false,
+	})
+	defer testMockServer.HandleError("GET", "/api/v1/transfers", http.StatusNotFound, "endpoint not found")
+
+	var conversionCalls int32
+	testMockServer.Handle("GET", "/api/v1/fx/conversions/conv_1", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&conversionCalls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"conv_1","sell_currency":"USD","buy_currency":"EUR","sell_amount":110.5,"buy_amount":100,"rate":0.905,"status":"SETTLED"}`))
+	})
+	defer testMockServer.HandleError("GET", "/api/v1/fx/conversions/conv_1", http.StatusNotFound, "endpoint not found")
+
+	var out bytes.Buffer
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+	root := NewRootCmd()
+	root.SetOut(&out)
+	root.SetArgs([]string{"transfers", "list", "--include-fx", "--output", "json"})
+	if err := root.ExecuteContext(ctx); err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+
+	var resp struct {
+		Items []map[string]interface{} `json:"items"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	if len(resp.Items) != 2 {
+		t.Fatalf("got %d items, want 2", len(resp.Items))
+	}
+	fx, ok := resp.Items[0]["fx"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("cross-currency transfer missing fx: %v", resp.Items[0])
+	}
+	for key, want := range map[string]interface{}{
+		"conversion_id": "conv_1", "rate": 0.905, "sell_currency": "USD",
+		"buy_currency": "EUR", "sell_amount": 110.5, "buy_amount": 100.0,
+	} {
+		if fx[key] != want {
+			t.Errorf("fx[%q] = %v, want %v", key, fx[key], want)
+		}
+	}
+	if _, ok := fx["fee_amount"]; ok {
+		t.Errorf("the transfer's own fee should not be reported under fx: %v", fx)
+	}
+	if resp.Items[0]["fee_amount"] != 2.5 {
+		t.Errorf("fee_amount = %v, want 2.5", resp.Items[0]["fee_amount"])
+	}
+	if _, ok := resp.Items[1]["fx"]; ok {
+		t.Errorf("same-currency transfer should not have fx: %v", resp.Items[1])
+	}
+	if n := atomic.LoadInt32(&conversionCalls); n != 1 {
+		t.Errorf("conversion fetched %d times, want 1", n)
+	}
+}
+
+func TestTransfersWait(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []string
+		args     []string
+		wantCode int
+		wantOut  string
+		wantErr  string
+	}{
+		{name: "completes", statuses: []string{"PROCESSING", "COMPLETED"}, wantCode: exitcode.Success, wantOut: "COMPLETED"},
+		{name: "fails", statuses: []string{"PROCESSING", "FAILED"}, wantCode: exitcode.Failed, wantOut: "FAILED", wantErr: "reached failure state: FAILED"},
+		{name: "times out", statuses: []string{"PROCESSING"}, args: []string{"--timeout", "50ms"}, wantCode: exitcode.Error, wantErr: "timed out after 50ms waiting for transfer tfr_wait (last status PROCESSING)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleanup := setupTestEnvironment(t)
+			defer cleanup()
+
+			var polls atomic.Int32
+			testMockServer.Handle("GET", "/api/v1/transfers/tfr_wait", func(w http.ResponseWriter, r *http.Request) {
+				i := min(int(polls.Add(1)), len(tt.statuses)) - 1
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]any{"id": "tfr_wait", "status": tt.statuses[i]})
+			})
+
+			var out bytes.Buffer
+			ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+			root := NewRootCmd()
+			root.SetOut(&out)
+			root.SetErr(io.Discard)
+			root.SetArgs(append([]string{"transfers", "wait", "tfr_wait", "--interval", "10ms"}, tt.args...))
+			err := root.ExecuteContext(ctx)
+
+			if got := exitcode.FromError(err); got != tt.wantCode {
+				t.Errorf("exit code = %d (err %v), want %d", got, err, tt.wantCode)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+			if !strings.Contains(out.String(), tt.wantOut) {
+				t.Errorf("output = %q, want final status %q", out.String(), tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestTransfersEvents(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.Handle("GET", "/api/v1/transfers/tfr_ev/events", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[
+			{"status":"COMPLETED","created_at":"2025-01-16T09:00:00Z"},
+			{"status":"NEW","created_at":"2025-01-15T10:30:00Z"}
+		]}`))
+	})
+	testMockServer.HandleError("GET", "/api/v1/transfers/tfr_old/events", http.StatusNotFound, "not found")
+	testMockServer.Handle("GET", "/api/v1/transfers/tfr_old", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"tfr_old","status":"COMPLETED","created_at":"2025-01-10T08:00:00Z","updated_at":"2025-01-11T08:00:00Z"}`))
+	})
+
+	run := func(args ...string) string {
+		t.Helper()
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(&out)
+		root.SetErr(io.Discard)
+		root.SetArgs(append([]string{"transfers", "events"}, args...))
+		if err := root.ExecuteContext(ctx); err != nil {
+			t.Fatalf("events %v failed: %v", args, err)
+		}
+		return out.String()
+	}
+
+	out := run("tfr_ev")
+	newAt, doneAt := strings.Index(out, "NEW"), strings.Index(out, "COMPLETED")
+	if newAt < 0 || doneAt < 0 || newAt > doneAt {
+		t.Errorf("output should list NEW before COMPLETED, got:\n%s", out)
+	}
+
+	var events []api.TransferEvent
+	if err := json.Unmarshal([]byte(run("tfr_old", "--output", "json")), &events); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	want := []api.TransferEvent{
+		{Status: "CREATED", CreatedAt: "2025-01-10T08:00:00Z"},
+		{Status: "COMPLETED", CreatedAt: "2025-01-11T08:00:00Z"},
+	}
+	if len(events) != len(want) || events[0] != want[0] || events[1] != want[1] {
+		t.Errorf("derived events = %+v, want %+v", events, want)
+	}
+}
+
+func TestTransfersWait_CancelStopsPolling(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	testMockServer.Handle("GET", "/api/v1/transfers/tfr_wait", func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "tfr_wait", "status": "PROCESSING"}`))
+	})
+
+	var out bytes.Buffer
+	ctx = iocontext.WithIO(ctx, &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+	root := NewRootCmd()
+	root.SetOut(&out)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"transfers", "wait", "tfr_wait", "--interval", "10ms"})
+	done := make(chan error, 1)
+	go func() { done <- root.ExecuteContext(ctx) }()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("error = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("transfers wait did not stop after cancellation")
+	}
+	if out.Len() != 0 {
+		t.Errorf("output = %q, want nothing after cancellation", out.String())
+	}
+}
+
+func TestTransfersBatchCreate_CSVFile(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	var bodies []string
+	testMockServer.Handle("POST", "/api/v1/transfers/create", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(string(data), `"reference":"BAD"`) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":"validation_error","message":"invalid beneficiary"}`))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"id":"tfr_%d","status":"NEW"}`, len(bodies))
+	})
+	defer testMockServer.HandleError("POST", "/api/v1/transfers/create", http.StatusNotFound, "endpoint not found")
+
+	input := filepath.Join(t.TempDir(), "payments.csv")
+	rows := "beneficiary_id,amount,currency,reference,reason\n" +
+		"ben_1,12345678901234.56,USD,INV-1,payment_to_supplier\n" +
+		"ben_2,5.00,EUR,BAD,payment_to_supplier\n" +
+		"ben_3,0.10,GBP,INV-3,payment_to_supplier\n"
+	if err := os.WriteFile(input, []byte(rows), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(&out)
+		root.SetErr(io.Discard)
+		root.SetArgs(append([]string{"transfers", "batch-create", "--file", input}, args...))
+		err := root.ExecuteContext(ctx)
+		return out.String(), err
+	}
+
+	out, err := run("--continue-on-error")
+	if err == nil || !strings.Contains(err.Error(), "1 transfers failed") {
+		t.Errorf("error = %v, want 1 transfers failed", err)
+	}
+	for _, want := range []string{"tfr_1", "tfr_3", "failed", "invalid beneficiary"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("result table missing %q:\n%s", want, out)
+		}
+	}
+	if len(bodies) != 3 {
+		t.Fatalf("sent %d transfers, want 3", len(bodies))
+	}
+	for _, want := range []string{
+		`"transfer_amount":12345678901234.56`,
+		`"transfer_currency":"USD"`,
+		`"source_currency":"USD"`,
+		`"transfer_method":"LOCAL"`,
+	} {
+		if !strings.Contains(bodies[0], want) {
+			t.Errorf("first request missing %s: %s", want, bodies[0])
+		}
+	}
+	if strings.Contains(bodies[0], `"amount"`) || strings.Contains(bodies[0], `"currency"`) {
+		t.Errorf("short column names should be mapped away: %s", bodies[0])
+	}
+
+	// A re-run sends the same idempotency keys; without --continue-on-error
+	// it stops at the first failure.
+	first := bodies
+	bodies = nil
+	if _, err := run(); err == nil {
+		t.Error("expected stop-on-first-failure error")
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("sent %d transfers, want to stop after the failing row", len(bodies))
+	}
+	requestID := func(body string) string {
+		var m map[string]any
+		_ = json.Unmarshal([]byte(body), &m)
+		id, _ := m["request_id"].(string)
+		return id
+	}
+	for i := range bodies {
+		if requestID(bodies[i]) == "" || requestID(bodies[i]) != requestID(first[i]) {
+			t.Errorf("row %d request_id = %q, want the first run's %q", i, requestID(bodies[i]), requestID(first[i]))
+		}
+	}
+	if requestID(first[0]) == requestID(first[2]) {
+		t.Error("rows should get distinct request_ids")
+	}
+}
+
+func TestTransfersBatchCreate_DeriveRequestIDsOptInForJSON(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	var ids []string
+	testMockServer.Handle("POST", "/api/v1/transfers/create", func(w http.ResponseWriter, r *http.Request) {
+		var m map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&m)
+		id, _ := m["request_id"].(string)
+		ids = append(ids, id)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"id":"tfr_%d","status":"NEW"}`, len(ids))
+	})
+	defer testMockServer.HandleError("POST", "/api/v1/transfers/create", http.StatusNotFound, "endpoint not found")
+
+	input := filepath.Join(t.TempDir(), "payments.json")
+	items := `[{"beneficiary_id":"ben_1","transfer_amount":"10.00","transfer_currency":"USD","source_currency":"USD","transfer_method":"LOCAL","reference":"INV-1","reason":"payment_to_supplier"}]`
+	if err := os.WriteFile(input, []byte(items), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) {
+		t.Helper()
+		root := NewRootCmd()
+		root.SetOut(io.Discard)
+		root.SetErr(io.Discard)
+		root.SetArgs(append([]string{"transfers", "batch-create", "--file", input}, args...))
+		if err := root.ExecuteContext(iocontext.WithIO(context.Background(), &iocontext.IO{Out: io.Discard, ErrOut: io.Discard, In: strings.NewReader("")})); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// JSON items get a fresh request_id per run unless derivation is asked for.
+	run()
+	run()
+	if len(ids) != 2 || ids[0] == "" || ids[0] == ids[1] {
+		t.Errorf("request_ids = %q, want two distinct random IDs", ids)
+	}
+
+	ids = nil
+	run("--derive-request-ids")
+	run("--derive-request-ids")
+	if len(ids) != 2 || ids[0] == "" || ids[0] != ids[1] {
+		t.Errorf("request_ids = %q, want the same derived ID on both runs", ids)
+	}
+}
+
+func TestTransfersBatchCreate_CSVInvalidAmount(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	input := filepath.Join(t.TempDir(), "payments.csv")
+	rows := "beneficiary_id,amount,currency\nben_1,10.00,USD\nben_2,\"1,000\",USD\n"
+	if err := os.WriteFile(input, []byte(rows), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	root := NewRootCmd()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"transfers", "batch-create", "--file", input})
+	err := root.ExecuteContext(iocontext.WithIO(context.Background(), &iocontext.IO{Out: io.Discard, ErrOut: io.Discard, In: strings.NewReader("")}))
+	if err == nil || !strings.Contains(err.Error(), "row 3: transfer_amount") {
+		t.Errorf("error = %v, want the bad row reported before sending anything", err)
+	}
+}
+
+func TestTransfers_CSVOutput(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	transfer := map[string]any{
+		"id": "tfr_csv", "transfer_amount": json.Number("100"), "transfer_currency": "USD",
+		"status": "PAID", "reference": `INV-1, "rush"`,
+	}
+	testMockServer.HandleJSON("GET", "/api/v1/transfers", http.StatusOK, map[string]any{"items": []any{transfer}, "has_more": false})
+	defer testMockServer.HandleError("GET", "/api/v1/transfers", http.StatusNotFound, "endpoint not found")
+	testMockServer.HandleJSON("GET", "/api/v1/transfers/tfr_csv", http.StatusOK, transfer)
+
+	run := func(args ...string) string {
+		t.Helper()
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(&out)
+		root.SetErr(io.Discard)
+		root.SetArgs(append(args, "--output", "csv"))
+		if err := root.ExecuteContext(ctx); err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+		return out.String()
+	}
+
+	list := run("transfers", "list")
+	records, err := csv.NewReader(strings.NewReader(list)).ReadAll()
+	if err != nil {
+		t.Fatalf("list output is not CSV: %v\n%s", err, list)
+	}
+	if len(records) != 2 || records[0][0] != "TRANSFER_ID" || records[1][4] != `INV-1, "rush"` {
+		t.Errorf("list records = %q, want a header row and one transfer", records)
+	}
+
+	get := run("transfers", "get", "tfr_csv", "--no-headers")
+	records, err = csv.NewReader(strings.NewReader(get)).ReadAll()
+	if err != nil {
+		t.Fatalf("get output is not CSV: %v\n%s", err, get)
+	}
+	if len(records) != 1 || records[0][0] != "tfr_csv" || !slices.Contains(records[0], `INV-1, "rush"`) {
+		t.Errorf("get records = %q, want one row of values", records)
+	}
+}
+
+func TestTransfersCancel_ShowTransition(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.HandleJSON("GET", "/api/v1/transfers/tfr_x", http.StatusOK, map[string]any{"id": "tfr_x", "status": "SCHEDULED"})
+	testMockServer.HandleJSON("POST", "/api/v1/transfers/tfr_x/cancel", http.StatusOK, map[string]any{"id": "tfr_x", "status": "CANCELLED"})
+
+	var out bytes.Buffer
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+	root := NewRootCmd()
+	root.SetOut(&out)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"transfers", "cancel", "tfr_x", "--yes", "--show-transition", "--output", "json"})
+	if err := root.ExecuteContext(ctx); err != nil {
+		t.Fatalf("transfers cancel failed: %v", err)
+	}
+
+	var got struct {
+		Before api.Transfer `json:"before"`
+		After  api.Transfer `json:"after"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+	if got.Before.TransferID != "tfr_x" || got.Before.Status != "SCHEDULED" {
+		t.Errorf("before = %+v, want tfr_x SCHEDULED", got.Before)
+	}
+	if got.After.TransferID != "tfr_x" || got.After.Status != "CANCELLED" {
+		t.Errorf("after = %+v, want tfr_x CANCELLED", got.After)
+	}
+}
+
+func TestTransfers_Template(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testMockServer.HandleJSON("GET", "/api/v1/transfers/tfr_123", http.StatusOK, map[string]interface{}{
+		"id": "tfr_123", "status": "PAID",
+	})
+	testMockServer.HandleJSON("GET", "/api/v1/transfers", http.StatusOK, map[string]interface{}{
+		"items": []map[string]interface{}{
+			{"id": "tfr_1", "status": "PAID"},
+			{"id": "tfr_2", "status": "FAILED"},
+		},
+		"has_more": false,
+	})
+
+	run := func(args ...string) string {
+		t.Helper()
+		var out bytes.Buffer
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(&out)
+		root.SetErr(io.Discard)
+		root.SetArgs(args)
+		if err := root.ExecuteContext(ctx); err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+		return out.String()
+	}
+
+	if got := run("transfers", "get", "tfr_123", "--template", "{{.TransferID}} {{.Status}}"); got != "tfr_123 PAID" {
+		t.Errorf("get template output = %q", got)
+	}
+	got := run("transfers", "list", "--template", "{{range .items}}{{.TransferID}}={{.Status}}\n{{end}}")
+	if got != "tfr_1=PAID\ntfr_2=FAILED\n" {
+		t.Errorf("list template output = %q", got)
+	}
+}
+
+func TestTransfersConfirmation_OutputFile(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	pdf := []byte("%PDF-1.4\n\x00\x01\xff binary\n%%EOF")
+	testMockServer.Handle("POST", "/api/v1/confirmation_letters/create", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write(pdf)
+	})
+	defer testMockServer.HandleError("POST", "/api/v1/confirmation_letters/create", http.StatusNotFound, "endpoint not found")
+
+	run := func(args ...string) error {
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: io.Discard, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(io.Discard)
+		root.SetErr(io.Discard)
+		root.SetArgs(args)
+		return root.ExecuteContext(ctx)
+	}
+
+	path := filepath.Join(t.TempDir(), "letter.pdf")
+	if err := run("transfers", "confirmation", "tfr_123", "--output-file", path, "--mask-ids"); err != nil {
+		t.Fatalf("confirmation failed: %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read output file: %v", err)
+	}
+	if !bytes.Equal(got, pdf) {
+		t.Errorf("PDF bytes changed:\ngot  %q\nwant %q", got, pdf)
+	}
+}
+
+func TestTransfersLetter_WritesFile(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	pdf := []byte("%PDF-1.4 fake letter %%EOF")
+	var gotFormat string
+	testMockServer.Handle("POST", "/api/v1/confirmation_letters/create", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		gotFormat = body["format"]
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write(pdf)
+	})
+	defer testMockServer.HandleError("POST", "/api/v1/confirmation_letters/create", http.StatusNotFound, "endpoint not found")
+
+	run := func(args ...string) error {
+		ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: io.Discard, ErrOut: io.Discard, In: strings.NewReader("")})
+		root := NewRootCmd()
+		root.SetOut(io.Discard)
+		root.SetErr(io.Discard)
+		root.SetArgs(args)
+		return root.ExecuteContext(ctx)
+	}
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := run("transfers", "letter", "tfr_123"); err != nil {
+		t.Fatalf("letter failed: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, "confirmation_tfr_123.pdf"))
+	if err != nil {
+		t.Fatalf("default file not written: %v", err)
+	}
+	if !bytes.Equal(got, pdf) {
+		t.Errorf("file content = %q, want %q", got, pdf)
+	}
+
+	out := filepath.Join(dir, "letter.pdf")
+	if err := run("transfers", "letter", "tfr_123", "--out", out, "--format", "no_fee_display"); err != nil {
+		t.Fatalf("letter --out failed: %v", err)
+	}
+	if got, _ := os.ReadFile(out); !bytes.Equal(got, pdf) {
+		t.Errorf("--out content = %q, want %q", got, pdf)
+	}
+	if gotFormat != "NO_FEE_DISPLAY" {
+		t.Errorf("format sent = %q, want NO_FEE_DISPLAY", gotFormat)
+	}
+
+	if err := run("transfers", "letter", "tfr_123", "--format", "docx"); err == nil || !strings.Contains(err.Error(), "invalid format") {
+		t.Errorf("expected invalid format error, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/api"
+	"github.com/salmonumbrella/airwallex-cli/internal/outfmt"
+	"github.com/salmonumbrella/airwallex-cli/internal/ui"
+	"github.com/salmonumbrella/airwallex-cli/internal/wait"
+)
+
+func newTransfersWaitCmd() *cobra.Command {
+	var interval time.Duration
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "wait <transferId>",
+		Short: "Wait for a transfer to reach a final status",
+		Long: `Poll a transfer until it is COMPLETED, FAILED, CANCELLED, or RETURNED.
+
+The final status is printed when polling stops. The command exits 0 when the
+transfer completes, 10 when it ends FAILED, CANCELLED, or RETURNED, and 1 if
+--timeout elapses first.
+
+Examples:
+  airwallex transfers wait tfr_123
+  airwallex transfers wait tfr_123 --interval 10s --timeout 30m`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval <= 0 {
+				return fmt.Errorf("--interval must be positive")
+			}
+			if timeout <= 0 {
+				return fmt.Errorf("--timeout must be positive")
+			}
+			u := ui.FromContext(cmd.Context())
+			transferID := NormalizeIDArg(args[0])
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			u.Info(fmt.Sprintf("Waiting for transfer %s (every %s, up to %s)...", transferID, interval, timeout))
+			t, waitErr := client.WaitForTransferEvery(cmd.Context(), transferID, timeout, interval)
+			var stateErr *wait.StateError
+			switch {
+			case waitErr == nil:
+			case errors.As(waitErr, &stateErr):
+				waitErr = fmt.Errorf("transfer %s %w", transferID, waitErr)
+			case errors.Is(waitErr, context.DeadlineExceeded) && cmd.Context().Err() == nil && t != nil:
+				// Our own deadline, not the caller's (Ctrl-C cancels the parent).
+				waitErr = fmt.Errorf("timed out after %s waiting for transfer %s (last status %s)", timeout, transferID, t.Status)
+			default:
+				return waitErr
+			}
+
+			if err := writeTransferWaitResult(cmd, t); err != nil {
+				return err
+			}
+			return waitErr
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "Time between status checks")
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "Give up (exit 1) if no final status by then")
+	return cmd
+}
+
+func writeTransferWaitResult(cmd *cobra.Command, t *api.Transfer) error {
+	if outfmt.IsJSON(cmd.Context()) {
+		return writeJSONOutput(cmd, t)
+	}
+	return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), []outfmt.KV{
+		{Key: "transfer_id", Value: t.TransferID},
+		{Key: "status", Value: t.Status},
+	})
+}
@@ -3,7 +3,6 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -146,7 +145,7 @@ func newWebhooksGetCmd() *cobra.Command {
 				{Key: "status", Value: wh.Status},
 				{Key: "created_at", Value: wh.CreatedAt},
 			}
-			return outfmt.WriteKV(cmd.OutOrStdout(), rows)
+			return outfmt.WriteKVForContext(cmd.Context(), cmd.OutOrStdout(), rows)
 		},
 	}, getClient)
 }
@@ -223,8 +222,8 @@ Common events:
 			}
 
 			u.Success(fmt.Sprintf("Created webhook: %s", wh.ID))
-			_, _ = fmt.Fprintf(os.Stdout, "URL: %s\n", wh.URL)
-			_, _ = fmt.Fprintf(os.Stdout, "Events: %s\n", strings.Join(wh.Events, ", "))
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "URL: %s\n", wh.URL)
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Events: %s\n", strings.Join(wh.Events, ", "))
 			return nil
 		},
 	}
@@ -24,6 +24,15 @@ func ConfigDir() (string, error) {
 	return filepath.Join(home, ".config", AppName), nil
 }
 
+// TokenDir returns the directory holding cached API login tokens.
+func TokenDir() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tokens"), nil
+}
+
 // DataDir returns the data directory path.
 // Uses XDG_DATA_HOME on Linux, ~/Library/Application Support on macOS.
 func DataDir() (string, error) {
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSettingsRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	s, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings() on missing file error = %v", err)
+	}
+	if s.DefaultAccount != "" {
+		t.Errorf("DefaultAccount = %q, want empty", s.DefaultAccount)
+	}
+
+	if err := SaveSettings(&Settings{DefaultAccount: "prod"}); err != nil {
+		t.Fatalf("SaveSettings() error = %v", err)
+	}
+	info, err := os.Stat(filepath.Join(dir, AppName, "config.json"))
+	if err != nil {
+		t.Fatalf("settings file not written: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("settings file mode = %o, want 600", perm)
+	}
+
+	s, err = LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings() error = %v", err)
+	}
+	if s.DefaultAccount != "prod" {
+		t.Errorf("DefaultAccount = %q, want 'prod'", s.DefaultAccount)
+	}
+}
+
+func TestLoadSettings_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	if err := os.MkdirAll(filepath.Join(dir, AppName), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, AppName, "config.json"), []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSettings(); err == nil {
+		t.Error("expected error for invalid settings file")
+	}
+}
@@ -2,6 +2,7 @@ package debug
 
 import (
 	"context"
+	"io"
 	"log/slog"
 	"os"
 )
@@ -26,6 +27,11 @@ func IsEnabled(ctx context.Context) bool {
 // SetupLogger configures slog based on debug mode.
 // Call this once in PersistentPreRunE after setting debug context.
 func SetupLogger(debugEnabled bool) {
+	SetupLoggerTo(os.Stderr, debugEnabled)
+}
+
+// SetupLoggerTo is SetupLogger with an explicit destination for log output.
+func SetupLoggerTo(w io.Writer, debugEnabled bool) {
 	var level slog.Level
 	if debugEnabled {
 		level = slog.LevelDebug
@@ -33,7 +39,7 @@ func SetupLogger(debugEnabled bool) {
 		level = slog.LevelWarn // Only show warnings and errors normally
 	}
 
-	handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+	handler := slog.NewTextHandler(w, &slog.HandlerOptions{
 		Level: level,
 	})
 	slog.SetDefault(slog.New(handler))
@@ -7,19 +7,21 @@ import (
 	"errors"
 
 	"github.com/salmonumbrella/airwallex-cli/internal/api"
+	"github.com/salmonumbrella/airwallex-cli/internal/wait"
 )
 
 // Exit codes for structured error handling.
 // These align with common CLI conventions and enable agent automation.
 const (
-	Success      = 0 // Command completed successfully
-	Error        = 1 // Generic error
-	AuthRequired = 4 // Authentication required or expired
-	NotFound     = 5 // Resource not found
-	Validation   = 6 // Validation error (bad input)
-	RateLimited  = 7 // Rate limit exceeded
-	Conflict     = 8 // Resource conflict (already exists, etc.)
-	ServerErr    = 9 // Server-side error (5xx)
+	Success      = 0  // Command completed successfully
+	Error        = 1  // Generic error
+	AuthRequired = 4  // Authentication required or expired
+	NotFound     = 5  // Resource not found
+	Validation   = 6  // Validation error (bad input)
+	RateLimited  = 7  // Rate limit exceeded
+	Conflict     = 8  // Resource conflict (already exists, etc.)
+	ServerErr    = 9  // Server-side error (5xx)
+	Failed       = 10 // Resource reached a failure state while waiting (e.g. FAILED transfer)
 )
 
 // NotFoundError indicates a resource was not found.
@@ -99,6 +101,11 @@ func FromError(err error) int {
 		return ServerErr
 	}
 
+	var stateErr *wait.StateError
+	if errors.As(err, &stateErr) {
+		return Failed
+	}
+
 	// Check ContextualError for HTTP status code mapping
 	var ctxErr *api.ContextualError
 	if errors.As(err, &ctxErr) {
@@ -2,9 +2,11 @@ package exitcode
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/salmonumbrella/airwallex-cli/internal/api"
+	"github.com/salmonumbrella/airwallex-cli/internal/wait"
 )
 
 func TestFromError_NilReturnsSuccess(t *testing.T) {
@@ -69,6 +71,13 @@ func TestFromError_CircuitBreakerErrorReturnsServerError(t *testing.T) {
 	}
 }
 
+func TestFromError_StateErrorReturnsFailed(t *testing.T) {
+	err := fmt.Errorf("transfer tfr_123: %w", &wait.StateError{State: "FAILED"})
+	if got := FromError(err); got != Failed {
+		t.Errorf("FromError(StateError) = %d, want %d", got, Failed)
+	}
+}
+
 func TestFromError_WrappedError(t *testing.T) {
 	// Test that wrapped errors are properly unwrapped
 	innerErr := &api.AuthError{Reason: "expired"}
@@ -310,6 +310,16 @@ var mappings = map[string]Mapping{
 		SchemaPath:  "beneficiary.last_name",
 		Description: "Last name (for PERSONAL entity)",
 	},
+	"date-of-birth": {
+		Flag:        "date-of-birth",
+		SchemaPath:  "beneficiary.date_of_birth",
+		Description: "Date of birth, YYYY-MM-DD (for PERSONAL entity, required by some corridors)",
+	},
+	"nationality": {
+		Flag:        "nationality",
+		SchemaPath:  "beneficiary.additional_info.personal_nationality",
+		Description: "Nationality country code, e.g. GB (for PERSONAL entity, required by some corridors)",
+	},
 
 	// Address
 	"address-country": {
@@ -55,6 +55,8 @@ func TestFlagToSchemaPath(t *testing.T) {
 		{"company-name", "beneficiary.company_name", ""},
 		{"first-name", "beneficiary.first_name", ""},
 		{"last-name", "beneficiary.last_name", ""},
+		{"date-of-birth", "beneficiary.date_of_birth", ""},
+		{"nationality", "beneficiary.additional_info.personal_nationality", ""},
 		// China legal representative (for business)
 		{"legal-rep-first-name", "beneficiary.additional_info.legal_rep_first_name", ""},
 		{"legal-rep-last-name", "beneficiary.additional_info.legal_rep_last_name", ""},
@@ -91,8 +93,8 @@ func TestGetMappingNotFound(t *testing.T) {
 
 func TestAllMappings(t *testing.T) {
 	all := AllMappings()
-	if len(all) != 56 {
-		t.Errorf("expected 56 mappings, got %d", len(all))
+	if len(all) != 58 {
+		t.Errorf("expected 58 mappings, got %d", len(all))
 	}
 }
 
@@ -65,3 +65,34 @@ func TestIO_HasIO(t *testing.T) {
 		t.Error("HasIO() should return true when IO is in context")
 	}
 }
+
+func TestTrimNewlineWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []string
+		want   string
+	}{
+		{name: "single trailing newline", writes: []string{"{}\n"}, want: "{}"},
+		{name: "newline written separately", writes: []string{"a", "\n"}, want: "a"},
+		{name: "inner newlines kept", writes: []string{"a\n", "b\n"}, want: "a\nb"},
+		{name: "only the last of several dropped", writes: []string{"a\n\n"}, want: "a\n"},
+		{name: "no newline", writes: []string{"a"}, want: "a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewTrimNewlineWriter(&buf)
+			for _, s := range tt.writes {
+				if _, err := w.Write([]byte(s)); err != nil {
+					t.Fatalf("Write() error: %v", err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close() error: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("output = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
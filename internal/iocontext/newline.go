@@ -0,0 +1,50 @@
+package iocontext
+
+import (
+	"bytes"
+	"io"
+)
+
+// TrimNewlineWriter holds back trailing newlines until more output follows,
+// so the final newline of a command's output can be dropped (for
+// --no-trailing-newline). Call Close once output is complete.
+type TrimNewlineWriter struct {
+	w       io.Writer
+	pending int // newlines written by the caller but not yet passed through
+}
+
+// NewTrimNewlineWriter wraps w.
+func NewTrimNewlineWriter(w io.Writer) *TrimNewlineWriter {
+	return &TrimNewlineWriter{w: w}
+}
+
+func (t *TrimNewlineWriter) Write(p []byte) (int, error) {
+	body := bytes.TrimRight(p, "\n")
+	if len(body) == 0 {
+		t.pending += len(p)
+		return len(p), nil
+	}
+	if err := t.flushNewlines(t.pending); err != nil {
+		return 0, err
+	}
+	if _, err := t.w.Write(body); err != nil {
+		return 0, err
+	}
+	t.pending = len(p) - len(body)
+	return len(p), nil
+}
+
+// Close writes any held-back newlines except the last one.
+func (t *TrimNewlineWriter) Close() error {
+	n := t.pending - 1
+	t.pending = 0
+	return t.flushNewlines(n)
+}
+
+func (t *TrimNewlineWriter) flushNewlines(n int) error {
+	if n <= 0 {
+		return nil
+	}
+	_, err := t.w.Write(bytes.Repeat([]byte("\n"), n))
+	return err
+}
@@ -0,0 +1,130 @@
+package outfmt
+
+import "strings"
+
+// FieldMask keeps (Only) or removes (Omit) dot-path fields from structured
+// output, e.g. "beneficiary.first_name". Paths are relative to each record:
+// the object itself for get commands, or each item of a list. Arrays along a
+// path apply the rest of the path to every element.
+type FieldMask struct {
+	Only []string
+	Omit []string
+}
+
+// IsZero reports whether the mask leaves output unchanged.
+func (m FieldMask) IsZero() bool {
+	return len(m.Only) == 0 && len(m.Omit) == 0
+}
+
+// Apply returns data with the mask applied to each record. Only runs before
+// Omit, so the two can be combined to keep an object minus some of its fields.
+func (m FieldMask) Apply(data interface{}) interface{} {
+	if m.IsZero() {
+		return data
+	}
+	only := splitFieldPaths(m.Only)
+	omit := splitFieldPaths(m.Omit)
+	record := func(v interface{}) interface{} {
+		if len(only) > 0 {
+			kept, ok := keepFields(v, only)
+			if !ok {
+				kept = map[string]interface{}{}
+			}
+			v = kept
+		}
+		for _, path := range omit {
+			omitField(v, path)
+		}
+		return v
+	}
+
+	switch v := data.(type) {
+	case []interface{}:
+		return mapRecords(v, record)
+	case map[string]interface{}:
+		// List envelopes keep their paging fields; the mask applies to items.
+		for _, key := range []string{"items", "results"} {
+			if items, ok := v[key].([]interface{}); ok {
+				v[key] = mapRecords(items, record)
+				return v
+			}
+		}
+	}
+	return record(data)
+}
+
+func mapRecords(items []interface{}, fn func(interface{}) interface{}) []interface{} {
+	out := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		out = append(out, fn(item))
+	}
+	return out
+}
+
+func splitFieldPaths(paths []string) [][]string {
+	var out [][]string
+	for _, p := range paths {
+		p = strings.Trim(strings.TrimSpace(p), ".")
+		if p == "" {
+			continue
+		}
+		out = append(out, strings.Split(p, "."))
+	}
+	return out
+}
+
+// keepFields returns v reduced to the given paths. ok is false when none of
+// the paths exist in v.
+func keepFields(v interface{}, paths [][]string) (interface{}, bool) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		byKey := make(map[string][][]string)
+		whole := make(map[string]bool)
+		for _, path := range paths {
+			if len(path) == 1 {
+				whole[path[0]] = true
+				continue
+			}
+			byKey[path[0]] = append(byKey[path[0]], path[1:])
+		}
+		out := make(map[string]interface{})
+		for key, child := range val {
+			if whole[key] {
+				out[key] = child
+				continue
+			}
+			if rest, ok := byKey[key]; ok {
+				if kept, ok := keepFields(child, rest); ok {
+					out[key] = kept
+				}
+			}
+		}
+		return out, len(out) > 0
+	case []interface{}:
+		out := make([]interface{}, 0, len(val))
+		for _, item := range val {
+			if kept, ok := keepFields(item, paths); ok {
+				out = append(out, kept)
+			}
+		}
+		return out, len(out) > 0
+	default:
+		return nil, false
+	}
+}
+
+// omitField deletes path from v in place.
+func omitField(v interface{}, path []string) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if len(path) == 1 {
+			delete(val, path[0])
+			return
+		}
+		omitField(val[path[0]], path[1:])
+	case []interface{}:
+		for _, item := range val {
+			omitField(item, path)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package outfmt
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -22,6 +23,7 @@ type Formatter struct {
 	out       io.Writer
 	errOut    io.Writer
 	tabWriter *tabwriter.Writer
+	csvWriter *csv.Writer // set by StartTable in CSV mode
 }
 
 // OutputOption configures a Formatter.
@@ -92,10 +94,10 @@ func selectItemsOrResults(data any) any {
 	}
 
 	if selected, ok := selectMapField(data, "items"); ok {
-		return selected
+		return emptyIfNil(selected)
 	}
 	if selected, ok := selectMapField(data, "results"); ok {
-		return selected
+		return emptyIfNil(selected)
 	}
 	if selected, ok := selectStructField(data, "Items"); ok {
 		return selected
@@ -106,6 +108,15 @@ func selectItemsOrResults(data any) any {
 	return data
 }
 
+// emptyIfNil turns a missing (null) items/results value into an empty list so
+// --items-only never prints null.
+func emptyIfNil(v any) any {
+	if v == nil {
+		return []any{}
+	}
+	return v
+}
+
 func selectMapField(data any, key string) (any, bool) {
 	rv := reflect.ValueOf(data)
 	for rv.Kind() == reflect.Ptr {
@@ -144,13 +155,23 @@ func selectStructField(data any, fieldName string) (any, bool) {
 
 // OutputWithTemplate applies a Go template to data.
 func (f *Formatter) OutputWithTemplate(data any, tmplStr string) error {
-	tmpl, err := template.New("output").Funcs(templateFuncs()).Parse(tmplStr)
+	tmpl, err := ParseTemplate(tmplStr)
 	if err != nil {
-		return fmt.Errorf("invalid template: %w", err)
+		return err
 	}
 	return tmpl.Execute(f.out, data)
 }
 
+// ParseTemplate parses a --template string with the output helper functions,
+// so syntax errors can be reported before any request is made.
+func ParseTemplate(tmplStr string) (*template.Template, error) {
+	tmpl, err := template.New("output").Funcs(templateFuncs()).Parse(tmplStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
 // templateFuncs returns helper functions for templates.
 func templateFuncs() template.FuncMap {
 	return template.FuncMap{
@@ -224,10 +245,22 @@ func (f *Formatter) OutputAnnotated(data any, links map[string]string) error {
 
 // StartTable writes table headers and returns true if in text mode.
 // Returns false if in JSON mode (caller should skip row writing).
+// In CSV mode the table is written as RFC 4180 CSV instead of aligned
+// columns. --no-headers suppresses the header row in both.
 func (f *Formatter) StartTable(headers []string) bool {
 	if IsJSON(f.ctx) {
 		return false
 	}
+	if IsCSV(f.ctx) {
+		f.csvWriter = csv.NewWriter(f.out)
+		if !GetNoHeaders(f.ctx) {
+			_ = f.csvWriter.Write(headers)
+		}
+		return true
+	}
+	if GetNoHeaders(f.ctx) {
+		return true
+	}
 
 	u := ui.FromContext(f.ctx)
 	for i, h := range headers {
@@ -242,6 +275,10 @@ func (f *Formatter) StartTable(headers []string) bool {
 
 // Row writes a single row to the table.
 func (f *Formatter) Row(columns ...string) {
+	if f.csvWriter != nil {
+		_ = f.csvWriter.Write(columns)
+		return
+	}
 	for i, col := range columns {
 		if i > 0 {
 			_, _ = fmt.Fprint(f.tabWriter, "\t")
@@ -268,7 +305,13 @@ const (
 // ColorRow writes a row with colorization based on column types.
 // columnTypes specifies how each column should be colorized.
 // If columnTypes is shorter than columns, remaining columns are treated as plain.
+// With --trim-zero-decimals, amount columns drop an all-zero fraction in text
+// tables; CSV keeps the values as given.
 func (f *Formatter) ColorRow(columnTypes []ColumnType, columns ...string) {
+	if f.csvWriter != nil {
+		f.Row(columns...)
+		return
+	}
 	u := ui.FromContext(f.ctx)
 	for i, col := range columns {
 		if i > 0 {
@@ -287,6 +330,9 @@ func (f *Formatter) ColorRow(columnTypes []ColumnType, columns ...string) {
 		case ColumnStatus:
 			formatted = u.FormatStatus(col)
 		case ColumnAmount:
+			if GetTrimZeroDecimals(f.ctx) {
+				col = TrimZeroDecimals(col)
+			}
 			formatted = u.FormatAmount(col)
 		case ColumnCurrency:
 			formatted = u.FormatCurrency(col)
@@ -301,6 +347,12 @@ func (f *Formatter) ColorRow(columnTypes []ColumnType, columns ...string) {
 
 // EndTable flushes the table output.
 func (f *Formatter) EndTable() error {
+	if f.csvWriter != nil {
+		f.csvWriter.Flush()
+		err := f.csvWriter.Error()
+		f.csvWriter = nil
+		return err
+	}
 	return f.tabWriter.Flush()
 }
 
@@ -336,6 +388,10 @@ func (f *Formatter) OutputListWithColors(items any, headers []string, columnType
 		return f.Output(processed.Interface())
 	}
 
+	if groupBy := GetGroupBy(f.ctx); groupBy != "" {
+		return f.outputGroupedTable(processed, headers, columnTypes, rowFn, groupBy)
+	}
+
 	// Text mode: use table methods
 	if !f.StartTable(headers) {
 		return nil
@@ -1315,3 +1315,111 @@ func TestFormatter_OutputAnnotated(t *testing.T) {
 		t.Errorf("expected self link, got: %v", links2["self"])
 	}
 }
+
+func TestFormatter_OutputList_CSV(t *testing.T) {
+	type item struct {
+		Name string
+		Note string
+	}
+	items := []item{
+		{Name: "Acme, Inc.", Note: `say "hi"`},
+		{Name: "plain", Note: "line one\nline two"},
+	}
+	rowFn := func(v any) []string {
+		it := v.(item)
+		return []string{it.Name, it.Note}
+	}
+
+	tests := []struct {
+		name      string
+		noHeaders bool
+		want      string
+	}{
+		{"with headers", false, "NAME,NOTE\n\"Acme, Inc.\",\"say \"\"hi\"\"\"\nplain,\"line one\nline two\"\n"},
+		{"no headers", true, "\"Acme, Inc.\",\"say \"\"hi\"\"\"\nplain,\"line one\nline two\"\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := WithNoHeaders(WithFormat(context.Background(), "csv"), tt.noHeaders)
+			var buf bytes.Buffer
+			f := FromContext(ctx, WithWriter(&buf))
+			err := f.OutputListWithColors(items, []string{"NAME", "NOTE"}, []ColumnType{ColumnPlain, ColumnStatus}, rowFn)
+			if err != nil {
+				t.Fatalf("OutputListWithColors() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("CSV output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_StartTable_NoHeadersText(t *testing.T) {
+	ctx := WithNoHeaders(context.Background(), true)
+	var buf bytes.Buffer
+	f := FromContext(ctx, WithWriter(&buf))
+	f.StartTable([]string{"ID", "STATUS"})
+	f.Row("tfr_1", "PAID")
+	if err := f.EndTable(); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "tfr_1  PAID\n" {
+		t.Errorf("output = %q, want only the data row", got)
+	}
+}
+
+func TestFormatter_OutputList_TrimZeroDecimals(t *testing.T) {
+	type balance struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}
+	items := []balance{
+		{Amount: "1000.00", Currency: "USD"},
+		{Amount: "1000.50", Currency: "USD"},
+		{Amount: "1000", Currency: "JPY"},
+		{Amount: "2.000", Currency: "KWD"},
+	}
+	rowFn := func(v any) []string {
+		b := v.(balance)
+		return []string{b.Amount, b.Currency}
+	}
+	colTypes := []ColumnType{ColumnAmount, ColumnCurrency}
+
+	tests := []struct {
+		name string
+		trim bool
+		want []string
+	}{
+		{"trimmed", true, []string{"1000 USD", "1000.50 USD", "1000 JPY", "2 KWD"}},
+		{"default", false, []string{"1000.00 USD", "1000.50 USD", "1000 JPY", "2.000 KWD"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := WithTrimZeroDecimals(context.Background(), tt.trim)
+			var buf bytes.Buffer
+			f := FromContext(ctx, WithWriter(&buf))
+			if err := f.OutputListWithColors(items, []string{"AMOUNT", "CURRENCY"}, colTypes, rowFn); err != nil {
+				t.Fatalf("OutputListWithColors() error = %v", err)
+			}
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")[1:]
+			for i, want := range tt.want {
+				if i >= len(lines) || strings.Join(strings.Fields(lines[i]), " ") != want {
+					t.Errorf("rows = %q, want %q", lines, tt.want)
+					break
+				}
+			}
+		})
+	}
+
+	t.Run("json keeps precision", func(t *testing.T) {
+		ctx := WithTrimZeroDecimals(WithFormat(context.Background(), "json"), true)
+		var buf bytes.Buffer
+		f := FromContext(ctx, WithWriter(&buf))
+		if err := f.OutputListWithColors(items, []string{"AMOUNT", "CURRENCY"}, colTypes, rowFn); err != nil {
+			t.Fatalf("OutputListWithColors() error = %v", err)
+		}
+		if !strings.Contains(buf.String(), `"1000.00"`) {
+			t.Errorf("JSON output lost precision: %s", buf.String())
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package outfmt
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/ui"
+)
+
+// outputGroupedTable writes one table per distinct value of the groupBy
+// column (in order of first appearance), each preceded by a "COLUMN: value
+// (count)" header and followed by subtotals of its amount columns. Amounts
+// are subtotalled per currency when the table has a currency column.
+func (f *Formatter) outputGroupedTable(items reflect.Value, headers []string, columnTypes []ColumnType, rowFn func(item any) []string, groupBy string) error {
+	groupCol := findColumn(headers, groupBy)
+	if groupCol < 0 {
+		return fmt.Errorf("unknown --group-by column %q (available: %s)", groupBy, strings.Join(headers, ", "))
+	}
+	currencyCol := -1
+	for i, t := range columnTypes {
+		if t == ColumnCurrency {
+			currencyCol = i
+			break
+		}
+	}
+
+	var order []string
+	groups := map[string][][]string{}
+	for i := 0; i < items.Len(); i++ {
+		cols := rowFn(items.Index(i).Interface())
+		key := ""
+		if groupCol < len(cols) {
+			key = cols[groupCol]
+		}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], cols)
+	}
+
+	u := ui.FromContext(f.ctx)
+	for gi, key := range order {
+		rows := groups[key]
+		if gi > 0 {
+			_, _ = fmt.Fprintln(f.out)
+		}
+		label := key
+		if label == "" {
+			label = "(none)"
+		}
+		_, _ = fmt.Fprintf(f.out, "%s: %s (%d)\n", u.FormatHeader(headers[groupCol]), label, len(rows))
+
+		f.StartTable(headers)
+		for _, cols := range rows {
+			if columnTypes != nil {
+				f.ColorRow(columnTypes, cols...)
+			} else {
+				f.Row(cols...)
+			}
+		}
+		if err := f.EndTable(); err != nil {
+			return err
+		}
+
+		for i, t := range columnTypes {
+			if t != ColumnAmount || i >= len(headers) {
+				continue
+			}
+			_, _ = fmt.Fprintf(f.out, "Subtotal %s: %s\n", headers[i], subtotal(rows, i, currencyCol, GetTrimZeroDecimals(f.ctx)))
+		}
+	}
+	return nil
+}
+
+// findColumn returns the index of the header matching name, ignoring case
+// and treating "-" and "_" alike, or -1.
+func findColumn(headers []string, name string) int {
+	norm := func(s string) string {
+		return strings.ReplaceAll(strings.ToUpper(strings.TrimSpace(s)), "-", "_")
+	}
+	want := norm(name)
+	for i, h := range headers {
+		if norm(h) == want {
+			return i
+		}
+	}
+	return -1
+}
+
+// subtotal sums column col over rows, split by the currency in currencyCol
+// when it is >= 0. Amounts are summed as exact decimals and each total is
+// shown with its currency's decimal places. Unparseable cells are skipped.
+func subtotal(rows [][]string, col, currencyCol int, trimZero bool) string {
+	totals := map[string]*big.Rat{}
+	for _, cols := range rows {
+		if col >= len(cols) {
+			continue
+		}
+		v, ok := new(big.Rat).SetString(strings.ReplaceAll(cols[col], ",", ""))
+		if !ok {
+			continue
+		}
+		currency := ""
+		if currencyCol >= 0 && currencyCol < len(cols) {
+			currency = cols[currencyCol]
+		}
+		if totals[currency] == nil {
+			totals[currency] = new(big.Rat)
+		}
+		totals[currency].Add(totals[currency], v)
+	}
+
+	currencies := make([]string, 0, len(totals))
+	for c := range totals {
+		currencies = append(currencies, c)
+	}
+	sort.Strings(currencies)
+	parts := make([]string, 0, len(currencies))
+	for _, c := range currencies {
+		amount := totals[c].FloatString(CurrencyDecimals(c))
+		if trimZero {
+			amount = TrimZeroDecimals(amount)
+		}
+		parts = append(parts, strings.TrimSpace(amount+" "+c))
+	}
+	if len(parts) == 0 {
+		return "0.00"
+	}
+	return strings.Join(parts, ", ")
+}
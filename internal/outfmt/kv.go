@@ -1,6 +1,8 @@
 package outfmt
 
 import (
+	"context"
+	"encoding/csv"
 	"fmt"
 	"io"
 	"text/tabwriter"
@@ -23,3 +25,28 @@ func WriteKV(w io.Writer, rows []KV) error {
 	}
 	return tw.Flush()
 }
+
+// WriteKVForContext writes key/value rows according to the output format in
+// context. CSV output is a single record: the keys as the header row (unless
+// --no-headers) followed by one row of values.
+func WriteKVForContext(ctx context.Context, w io.Writer, rows []KV) error {
+	if !IsCSV(ctx) {
+		return WriteKV(w, rows)
+	}
+	keys := make([]string, 0, len(rows))
+	values := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if row.Key == "" {
+			continue
+		}
+		keys = append(keys, row.Key)
+		values = append(values, row.Value)
+	}
+	cw := csv.NewWriter(w)
+	if !GetNoHeaders(ctx) {
+		_ = cw.Write(keys)
+	}
+	_ = cw.Write(values)
+	cw.Flush()
+	return cw.Error()
+}
@@ -3,6 +3,7 @@ package outfmt
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 // FormatMoney formats a json.Number monetary amount for human display with 2
@@ -20,17 +21,60 @@ func FormatMoney(n json.Number) string {
 	return fmt.Sprintf("%.2f", f)
 }
 
+// currencyMinorUnits lists ISO 4217 currencies whose minor unit is not two
+// decimal places.
+var currencyMinorUnits = map[string]int{
+	"BHD": 3, "CLP": 0, "IQD": 3, "ISK": 0, "JOD": 3, "JPY": 0, "KRW": 0,
+	"KWD": 3, "LYD": 3, "OMR": 3, "PYG": 0, "TND": 3, "UGX": 0, "VND": 0,
+	"XAF": 0, "XOF": 0,
+}
+
+// FormatMoneyCurrency formats a json.Number amount with the number of decimal
+// places used by currency (e.g. 0 for JPY, 3 for KWD, 2 otherwise). Returns
+// zero at that precision for empty or invalid numbers.
+func FormatMoneyCurrency(n json.Number, currency string) string {
+	return FormatRatePrecision(n, CurrencyDecimals(currency))
+}
+
+// CurrencyDecimals returns the number of decimal places used by currency
+// (e.g. 0 for JPY, 3 for KWD, 2 otherwise).
+func CurrencyDecimals(currency string) int {
+	if decimals, ok := currencyMinorUnits[strings.ToUpper(currency)]; ok {
+		return decimals
+	}
+	return 2
+}
+
+// TrimZeroDecimals drops an all-zero fractional part from a formatted amount
+// ("1000.00" becomes "1000"). Amounts with any non-zero decimal keep every
+// digit ("1000.50" stays "1000.50"), and amounts without decimals, such as
+// JPY, are returned unchanged, so a currency's minor units are never lost.
+func TrimZeroDecimals(amount string) string {
+	whole, frac, ok := strings.Cut(amount, ".")
+	if !ok || whole == "" || strings.Trim(frac, "0") != "" {
+		return amount
+	}
+	return whole
+}
+
+// DefaultRatePrecision is the number of decimals FormatRate displays.
+const DefaultRatePrecision = 6
+
 // FormatRate formats a json.Number exchange rate for human display with 6
 // decimal places. Returns "0.000000" for empty or invalid numbers.
 func FormatRate(n json.Number) string {
-	if n == "" {
-		return "0.000000"
-	}
+	return FormatRatePrecision(n, DefaultRatePrecision)
+}
+
+// FormatRatePrecision formats a json.Number exchange rate rounded to
+// precision decimal places. Returns zero at that precision for empty or
+// invalid numbers.
+func FormatRatePrecision(n json.Number, precision int) string {
 	f, err := n.Float64()
-	if err != nil {
-		return "0.000000"
+	if n == "" || err != nil {
+		f = 0
 	}
-	return fmt.Sprintf("%.6f", f)
+	return fmt.Sprintf("%.*f", precision, f)
 }
 
 // MoneyFloat64 converts a json.Number to float64, returning 0 on error.
@@ -39,3 +83,40 @@ func MoneyFloat64(n json.Number) float64 {
 	f, _ := n.Float64()
 	return f
 }
+
+// GroupMoneyObjects recursively rewrites decoded JSON objects so that each
+// "<prefix>_amount" / "<prefix>_currency" pair becomes a nested
+// "<prefix>": {"amount", "currency"} object (e.g. transfer_amount and
+// transfer_currency become transfer). Pairs are left alone when the target
+// key already exists, so no API field is overwritten.
+func GroupMoneyObjects(v interface{}) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for _, child := range val {
+			GroupMoneyObjects(child)
+		}
+		for key, amount := range val {
+			prefix, ok := strings.CutSuffix(key, "_amount")
+			if !ok || prefix == "" {
+				continue
+			}
+			currency, ok := val[prefix+"_currency"]
+			if !ok {
+				continue
+			}
+			if _, exists := val[prefix]; exists {
+				continue
+			}
+			val[prefix] = map[string]interface{}{
+				"amount":   amount,
+				"currency": currency,
+			}
+			delete(val, key)
+			delete(val, prefix+"_currency")
+		}
+	case []interface{}:
+		for _, child := range val {
+			GroupMoneyObjects(child)
+		}
+	}
+}
@@ -23,6 +23,12 @@ const (
 	limitKey     contextKey = "limit_flag"
 	sortByKey    contextKey = "sort_by_flag"
 	descKey      contextKey = "desc_flag"
+	moneyObjKey  contextKey = "money_objects_flag"
+	groupByKey   contextKey = "group_by_flag"
+	yamlDocsKey  contextKey = "yaml_documents_flag"
+	fieldMaskKey contextKey = "field_mask_flag"
+	noHeadersKey contextKey = "no_headers_flag"
+	trimZeroKey  contextKey = "trim_zero_decimals_flag"
 )
 
 func WithFormat(ctx context.Context, format string) context.Context {
@@ -36,15 +42,28 @@ func GetFormat(ctx context.Context) string {
 	return "text"
 }
 
+// Format returns the selected output format normalized to one of text, json,
+// jsonl, yaml, or csv.
+func Format(ctx context.Context) string {
+	return NormalizeFormat(GetFormat(ctx))
+}
+
+// IsJSON reports whether output is structured (json, jsonl, or yaml), i.e.
+// rendered from the JSON representation rather than as text tables.
 func IsJSON(ctx context.Context) bool {
-	switch NormalizeFormat(GetFormat(ctx)) {
-	case "json", "jsonl":
+	switch Format(ctx) {
+	case "json", "jsonl", "yaml":
 		return true
 	default:
 		return false
 	}
 }
 
+// IsCSV reports whether tables and key/value output are written as CSV.
+func IsCSV(ctx context.Context) bool {
+	return Format(ctx) == "csv"
+}
+
 // NormalizeFormat canonicalizes output format strings.
 // "ndjson" is treated as an alias of "jsonl".
 func NormalizeFormat(format string) string {
@@ -54,6 +73,8 @@ func NormalizeFormat(format string) string {
 		return "text"
 	case "ndjson":
 		return "jsonl"
+	case "yml":
+		return "yaml"
 	default:
 		return normalized
 	}
@@ -82,44 +103,79 @@ func GetTemplate(ctx context.Context) string {
 }
 
 func WriteJSON(w io.Writer, v interface{}) error {
-	return writeJSONWithFormatAndQuery(w, v, "json", "")
+	return writeJSONWithFormatAndQuery(w, v, "json", "", false, FieldMask{})
 }
 
 // WriteJSONFiltered writes JSON with optional filtering
 func WriteJSONFiltered(w io.Writer, v interface{}, query string) error {
-	return writeJSONWithFormatAndQuery(w, v, "json", query)
+	return writeJSONWithFormatAndQuery(w, v, "json", query, false, FieldMask{})
 }
 
 // WriteJSONForContext writes JSON according to output settings in context.
 // Supported formats:
 //   - json: pretty-printed JSON
 //   - jsonl: compact newline-delimited JSON (one value per line, arrays split per item)
+//   - yaml: YAML, optionally one "---" document per list item
+//
+// YAML keeps struct field order unless a query, field mask, or money grouping
+// reshapes the data, in which case object keys are sorted.
 func WriteJSONForContext(ctx context.Context, w io.Writer, v interface{}) error {
-	format := NormalizeFormat(GetFormat(ctx))
+	if pluck, ok := getPluck(ctx); ok {
+		data, err := prepareStructuredOutput(v, "", GetMoneyObjects(ctx), GetFieldMask(ctx))
+		if err != nil {
+			return err
+		}
+		return writePlucked(w, data, pluck)
+	}
+	if warnings := GetWarnings(ctx); warnings != nil {
+		v = withWarnings{data: v, warnings: warnings.List()}
+	}
+	format := Format(ctx)
 	query := GetQuery(ctx)
-	return writeJSONWithFormatAndQuery(w, v, format, query)
+	if format == "yaml" {
+		return writeYAMLForContext(ctx, w, v)
+	}
+	return writeJSONWithFormatAndQuery(w, v, format, query, GetMoneyObjects(ctx), GetFieldMask(ctx))
+}
+
+func writeJSONWithFormatAndQuery(w io.Writer, v interface{}, format, query string, moneyObjects bool, mask FieldMask) error {
+	data, err := prepareStructuredOutput(v, query, moneyObjects, mask)
+	if err != nil {
+		return err
+	}
+
+	if NormalizeFormat(format) == "jsonl" {
+		return writeJSONLines(w, data)
+	}
+	return writeJSONPretty(w, data)
 }
 
-func writeJSONWithFormatAndQuery(w io.Writer, v interface{}, format, query string) error {
+// prepareStructuredOutput normalizes v to generic JSON values and applies
+// money grouping, the field mask, and the --query filter, in that order.
+func prepareStructuredOutput(v interface{}, query string, moneyObjects bool, mask FieldMask) (interface{}, error) {
 	// Convert typed struct to generic interface{} for gojq compatibility.
 	// gojq cannot traverse Go structs directly - it needs map[string]interface{}.
 	// Also normalizes nil slices to [] to prevent jq "cannot iterate over: null".
 	data, err := normalizeJSON(v)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	// Group before filtering so --query sees the same shape that is printed.
+	if moneyObjects {
+		GroupMoneyObjects(data)
+	}
+
+	// Mask before filtering so omitted fields cannot leak through --query.
+	data = mask.Apply(data)
+
 	if query != "" {
 		data, err = filter.Apply(data, query)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
-
-	if NormalizeFormat(format) == "jsonl" {
-		return writeJSONLines(w, data)
-	}
-	return writeJSONPretty(w, data)
+	return data, nil
 }
 
 func writeJSONPretty(w io.Writer, v interface{}) error {
@@ -196,10 +252,26 @@ func normalizeJSON(v interface{}) (interface{}, error) {
 	if err := dec.Decode(&data); err != nil {
 		return nil, err
 	}
+	if data == nil && isSliceValue(v) {
+		// A nil Go slice marshals as null; list output is always [].
+		return []interface{}{}, nil
+	}
 	NullsToEmpty(data)
 	return data, nil
 }
 
+// isSliceValue reports whether v is a slice or array, looking through pointers.
+func isSliceValue(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return false
+		}
+		rv = rv.Elem()
+	}
+	return rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array
+}
+
 // NullsToEmpty recursively walks a decoded JSON value and replaces null values
 // inside objects with empty arrays [] when the key name matches a known
 // collection field. This prevents jq filters from failing with
@@ -225,7 +297,7 @@ func NullsToEmpty(v interface{}) {
 // array/slice field. Uses known collection field names from the Airwallex API.
 func looksLikeSliceKey(key string) bool {
 	switch key {
-	case "items", "rates", "events", "errors", "fields", "limits",
+	case "items", "results", "accounts", "rates", "events", "errors", "fields", "limits",
 		"balances", "currencies", "transaction_types", "transfer_methods",
 		"payment_methods", "enum":
 		return true
@@ -306,3 +378,81 @@ func GetDesc(ctx context.Context) bool {
 	}
 	return false
 }
+
+// MoneyObjects flag context functions
+
+func WithMoneyObjects(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, moneyObjKey, enabled)
+}
+
+func GetMoneyObjects(ctx context.Context) bool {
+	if v, ok := ctx.Value(moneyObjKey).(bool); ok {
+		return v
+	}
+	return false
+}
+
+// GroupBy flag context functions
+
+func WithGroupBy(ctx context.Context, column string) context.Context {
+	return context.WithValue(ctx, groupByKey, column)
+}
+
+func GetGroupBy(ctx context.Context) string {
+	if v, ok := ctx.Value(groupByKey).(string); ok {
+		return v
+	}
+	return ""
+}
+
+// NoHeaders flag context functions
+
+func WithNoHeaders(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, noHeadersKey, enabled)
+}
+
+func GetNoHeaders(ctx context.Context) bool {
+	if v, ok := ctx.Value(noHeadersKey).(bool); ok {
+		return v
+	}
+	return false
+}
+
+// TrimZeroDecimals flag context functions
+
+func WithTrimZeroDecimals(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, trimZeroKey, enabled)
+}
+
+func GetTrimZeroDecimals(ctx context.Context) bool {
+	if v, ok := ctx.Value(trimZeroKey).(bool); ok {
+		return v
+	}
+	return false
+}
+
+// YAMLDocuments flag context functions
+
+func WithYAMLDocuments(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, yamlDocsKey, enabled)
+}
+
+func GetYAMLDocuments(ctx context.Context) bool {
+	if v, ok := ctx.Value(yamlDocsKey).(bool); ok {
+		return v
+	}
+	return false
+}
+
+// FieldMask flag context functions
+
+func WithFieldMask(ctx context.Context, mask FieldMask) context.Context {
+	return context.WithValue(ctx, fieldMaskKey, mask)
+}
+
+func GetFieldMask(ctx context.Context) FieldMask {
+	if v, ok := ctx.Value(fieldMaskKey).(FieldMask); ok {
+		return v
+	}
+	return FieldMask{}
+}
@@ -3,6 +3,7 @@ package outfmt
 import (
 	"bytes"
 	"context"
+	"strings"
 	"testing"
 )
 
@@ -178,6 +179,31 @@ func TestWriteJSONForContext_JSONMode_PrettyPrinted(t *testing.T) {
 	}
 }
 
+func TestWriteJSONForContext_EmptyListsAreArrays(t *testing.T) {
+	ctx := WithFormat(context.Background(), "json")
+	tests := map[string]any{
+		"nil slice":     []string(nil),
+		"nil results":   map[string]any{"results": nil},
+		"nil accounts":  map[string]any{"accounts": nil},
+		"nil via items": selectItemsOrResults(map[string]any{"items": nil}),
+	}
+	want := map[string]string{
+		"nil slice":     "[]\n",
+		"nil results":   "{\n  \"results\": []\n}\n",
+		"nil accounts":  "{\n  \"accounts\": []\n}\n",
+		"nil via items": "[]\n",
+	}
+	for name, data := range tests {
+		var buf bytes.Buffer
+		if err := WriteJSONForContext(ctx, &buf, data); err != nil {
+			t.Fatalf("%s: WriteJSONForContext error: %v", name, err)
+		}
+		if got := buf.String(); got != want[name] {
+			t.Errorf("%s: got %q, want %q", name, got, want[name])
+		}
+	}
+}
+
 func TestWriteJSONForContext_JSONLMode_ArrayAsLines(t *testing.T) {
 	type item struct {
 		ID   int    `json:"id"`
@@ -222,3 +248,81 @@ func TestWriteJSONForContext_JSONLMode_QueryResultsAsLines(t *testing.T) {
 		t.Errorf("WriteJSONForContext(jsonl query) = %q, want %q", got, want)
 	}
 }
+
+func TestWriteJSONForContext_MoneyObjects(t *testing.T) {
+	ctx := WithMoneyObjects(WithFormat(context.Background(), "json"), true)
+	var buf bytes.Buffer
+	data := map[string]any{
+		"items": []any{
+			map[string]any{"billing_amount": 5, "billing_currency": "SGD"},
+		},
+		"fee_amount":   1,
+		"fee_currency": "USD",
+		"fee":          "existing",
+		"amount":       2,
+	}
+
+	if err := WriteJSONForContext(ctx, &buf, data); err != nil {
+		t.Fatalf("WriteJSONForContext error: %v", err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{`"billing": {`, `"currency": "SGD"`, `"fee_amount": 1`, `"fee": "existing"`, `"amount": 2`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %s:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "billing_amount") {
+		t.Errorf("expected billing_amount to be grouped:\n%s", got)
+	}
+}
+
+func TestWriteKVForContext_CSV(t *testing.T) {
+	rows := []KV{
+		{Key: "id", Value: "ben_1"},
+		{Key: "", Value: "skipped"},
+		{Key: "name", Value: "Smith, Jane"},
+		{Key: "nickname", Value: `"JJ"`},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteKVForContext(WithFormat(context.Background(), "csv"), &buf, rows); err != nil {
+		t.Fatalf("WriteKVForContext() error = %v", err)
+	}
+	if want := "id,name,nickname\nben_1,\"Smith, Jane\",\"\"\"JJ\"\"\"\n"; buf.String() != want {
+		t.Errorf("CSV output = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	ctx := WithNoHeaders(WithFormat(context.Background(), "csv"), true)
+	if err := WriteKVForContext(ctx, &buf, rows); err != nil {
+		t.Fatalf("WriteKVForContext() error = %v", err)
+	}
+	if want := "ben_1,\"Smith, Jane\",\"\"\"JJ\"\"\"\n"; buf.String() != want {
+		t.Errorf("CSV output without headers = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := WriteKVForContext(context.Background(), &buf, rows[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if want := "id  ben_1\n"; buf.String() != want {
+		t.Errorf("text output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestSubtotal_ExactDecimalsPerCurrency(t *testing.T) {
+	rows := [][]string{
+		{"0.10", "USD"},
+		{"0.20", "USD"},
+		{"12345678901234.56", "USD"},
+		{"1000", "JPY"},
+		{"1,500", "JPY"},
+		{"0.125", "KWD"},
+		{"n/a", "USD"},
+	}
+	want := "2500 JPY, 0.125 KWD, 12345678901234.86 USD"
+	if got := subtotal(rows, 0, 1, false); got != want {
+		t.Errorf("subtotal() = %q, want %q", got, want)
+	}
+}
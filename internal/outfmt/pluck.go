@@ -0,0 +1,90 @@
+package outfmt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const pluckKey contextKey = "pluck_path"
+
+// pluckOptions selects a single dot-path value from each record.
+type pluckOptions struct {
+	path string
+	raw  bool // print strings without JSON quoting
+}
+
+// WithPluck makes structured output print only the value at the dot-path
+// of each record instead of the whole document. With raw set, strings are
+// printed as-is rather than JSON-encoded.
+func WithPluck(ctx context.Context, path string, raw bool) context.Context {
+	return context.WithValue(ctx, pluckKey, pluckOptions{path: path, raw: raw})
+}
+
+func getPluck(ctx context.Context) (pluckOptions, bool) {
+	v, ok := ctx.Value(pluckKey).(pluckOptions)
+	return v, ok && v.path != ""
+}
+
+// writePlucked writes the value at opts.path for data, one line per record:
+// the object itself, or each item of a list or list envelope.
+func writePlucked(w io.Writer, data interface{}, opts pluckOptions) error {
+	path := strings.Split(strings.Trim(strings.TrimSpace(opts.path), "."), ".")
+	records := []interface{}{data}
+	switch v := data.(type) {
+	case []interface{}:
+		records = v
+	case map[string]interface{}:
+		for _, key := range []string{"items", "results"} {
+			if items, ok := v[key].([]interface{}); ok {
+				records = items
+				break
+			}
+		}
+	}
+
+	lines := make([]string, 0, len(records))
+	for i, record := range records {
+		value, err := pluckValue(record, path)
+		if err != nil {
+			if len(records) > 1 {
+				return fmt.Errorf("--pluck %s: item %d: %w", opts.path, i, err)
+			}
+			return fmt.Errorf("--pluck %s: %w", opts.path, err)
+		}
+		if s, ok := value.(string); ok && opts.raw {
+			lines = append(lines, s)
+			continue
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, string(b))
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func pluckValue(v interface{}, path []string) (interface{}, error) {
+	for i, key := range path {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s is not an object", strings.Join(path[:i], "."))
+		}
+		if v, ok = obj[key]; !ok {
+			return nil, fmt.Errorf("path not found")
+		}
+	}
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		return nil, fmt.Errorf("value is not a scalar")
+	}
+	return v, nil
+}
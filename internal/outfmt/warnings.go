@@ -0,0 +1,74 @@
+package outfmt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+const warningsKey contextKey = "warnings_collector"
+
+// Warnings collects the warnings a command emits so --warnings-in-json can
+// add them to the structured output. It is safe for concurrent use.
+type Warnings struct {
+	mu   sync.Mutex
+	msgs []string
+}
+
+// Add records a warning message.
+func (w *Warnings) Add(msg string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.msgs = append(w.msgs, msg)
+}
+
+// List returns the recorded warnings, never nil.
+func (w *Warnings) List() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]string{}, w.msgs...)
+}
+
+func WithWarnings(ctx context.Context, w *Warnings) context.Context {
+	return context.WithValue(ctx, warningsKey, w)
+}
+
+// GetWarnings returns the collector, or nil when warnings are not collected.
+func GetWarnings(ctx context.Context) *Warnings {
+	if v, ok := ctx.Value(warningsKey).(*Warnings); ok {
+		return v
+	}
+	return nil
+}
+
+// withWarnings appends a "warnings" array to data when it encodes as a JSON
+// object. Other values (e.g. --items-only arrays) are returned unchanged.
+type withWarnings struct {
+	data     any
+	warnings []string
+}
+
+func (w withWarnings) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(w.data)
+	if err != nil {
+		return nil, err
+	}
+	trimmed := bytes.TrimSpace(b)
+	if len(trimmed) < 2 || trimmed[0] != '{' {
+		return b, nil
+	}
+	list, err := json.Marshal(w.warnings)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.Write(trimmed[:len(trimmed)-1])
+	if len(bytes.TrimSpace(trimmed[1:len(trimmed)-1])) > 0 {
+		buf.WriteByte(',')
+	}
+	buf.WriteString(`"warnings":`)
+	buf.Write(list)
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,188 @@
+package outfmt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// writeYAMLForContext writes v as YAML using the query, field mask, and
+// money grouping settings in ctx.
+func writeYAMLForContext(ctx context.Context, w io.Writer, v interface{}) error {
+	query, moneyObjects, mask := GetQuery(ctx), GetMoneyObjects(ctx), GetFieldMask(ctx)
+	var node *yaml.Node
+	if query == "" && !moneyObjects && mask.IsZero() {
+		n, err := orderedYAMLNode(v)
+		if err != nil {
+			return err
+		}
+		node = n
+	} else {
+		data, err := prepareStructuredOutput(v, query, moneyObjects, mask)
+		if err != nil {
+			return err
+		}
+		node = yamlNode(data)
+	}
+	return writeYAML(w, node, GetYAMLDocuments(ctx))
+}
+
+// writeYAML writes node as YAML. With documents set, a list (a top-level
+// sequence, or the items/results sequence of a list envelope) is written as
+// one "---"-separated document per item so it can be streamed.
+func writeYAML(w io.Writer, node *yaml.Node, documents bool) error {
+	if !documents {
+		return encodeYAML(w, node)
+	}
+
+	values := []*yaml.Node{node}
+	switch node.Kind {
+	case yaml.SequenceNode:
+		values = node.Content
+	case yaml.MappingNode:
+		for _, key := range []string{"items", "results"} {
+			if v := mappingValue(node, key); v != nil && v.Kind == yaml.SequenceNode {
+				values = v.Content
+				break
+			}
+		}
+	}
+	for _, v := range values {
+		if _, err := io.WriteString(w, "---\n"); err != nil {
+			return err
+		}
+		if err := encodeYAML(w, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func encodeYAML(w io.Writer, node *yaml.Node) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// orderedYAMLNode converts v to a yaml.Node via its JSON encoding, keeping
+// object keys in the order encoding/json writes them (struct field order).
+// Null collections become empty sequences, as in normalizeJSON.
+func orderedYAMLNode(v interface{}) (*yaml.Node, error) {
+	jsonBytes, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(jsonBytes))
+	dec.UseNumber()
+	node, err := decodeYAMLNode(dec, "")
+	if err != nil {
+		return nil, err
+	}
+	if node.Tag == "!!null" && isSliceValue(v) {
+		return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}, nil
+	}
+	return node, nil
+}
+
+// decodeYAMLNode reads the next JSON value from dec. key is the object key
+// the value belongs to, if any.
+func decodeYAMLNode(dec *json.Decoder, key string) (*yaml.Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '[' {
+			node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			for dec.More() {
+				child, err := decodeYAMLNode(dec, "")
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, child)
+			}
+			_, err := dec.Token() // ]
+			return node, err
+		}
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for dec.More() {
+			kt, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			k, _ := kt.(string)
+			child, err := decodeYAMLNode(dec, k)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, yamlNode(k), child)
+		}
+		_, err := dec.Token() // }
+		return node, err
+	case nil:
+		if looksLikeSliceKey(key) {
+			return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}, nil
+		}
+		return yamlNode(nil), nil
+	default:
+		return yamlNode(t), nil
+	}
+}
+
+// yamlNode converts decoded JSON into a yaml.Node, keeping json.Number values
+// exactly as the API sent them. Object keys are sorted, since decoded maps
+// have no order.
+func yamlNode(v interface{}) *yaml.Node {
+	switch val := v.(type) {
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(val)}
+	case json.Number:
+		tag := "!!float"
+		if _, err := val.Int64(); err == nil {
+			tag = "!!int"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: val.String()}
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: val}
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range val {
+			node.Content = append(node.Content, yamlNode(item))
+		}
+		return node
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for _, k := range keys {
+			node.Content = append(node.Content, yamlNode(k), yamlNode(val[k]))
+		}
+		return node
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: fmt.Sprint(val)}
+	}
+}
@@ -0,0 +1,160 @@
+package outfmt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/api"
+)
+
+func TestWriteJSONForContext_YAML(t *testing.T) {
+	ctx := WithFormat(context.Background(), "yml")
+	var buf bytes.Buffer
+	data := map[string]any{"amount": json.Number("100.50"), "count": 2, "name": "x", "tags": nil}
+
+	if err := WriteJSONForContext(ctx, &buf, data); err != nil {
+		t.Fatalf("WriteJSONForContext error: %v", err)
+	}
+
+	want := "amount: 100.50\ncount: 2\nname: x\ntags: null\n"
+	if got := buf.String(); got != want {
+		t.Errorf("yaml output = %q, want %q", got, want)
+	}
+}
+
+func TestWriteJSONForContext_YAMLDocuments(t *testing.T) {
+	ctx := WithYAMLDocuments(WithFormat(context.Background(), "yaml"), true)
+	envelope := map[string]any{
+		"items":    []any{map[string]any{"id": "a"}, map[string]any{"id": "b"}},
+		"has_more": false,
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJSONForContext(ctx, &buf, envelope); err != nil {
+		t.Fatalf("WriteJSONForContext error: %v", err)
+	}
+
+	docs := strings.Split(strings.TrimPrefix(buf.String(), "---\n"), "---\n")
+	if len(docs) != 2 {
+		t.Fatalf("expected 2 documents, got %d:\n%s", len(docs), buf.String())
+	}
+	for i, want := range []string{"a", "b"} {
+		var doc map[string]string
+		if err := yaml.Unmarshal([]byte(docs[i]), &doc); err != nil {
+			t.Fatalf("document %d does not parse: %v\n%s", i, err, docs[i])
+		}
+		if doc["id"] != want {
+			t.Errorf("document %d id = %q, want %q", i, doc["id"], want)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := map[string]string{"": "text", "JSON": "json", "ndjson": "jsonl", "yml": "yaml", "yaml": "yaml"}
+	for in, want := range tests {
+		if got := Format(WithFormat(context.Background(), in)); got != want {
+			t.Errorf("Format(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// yamlRoundTrip writes v as YAML and decodes it back into out through its
+// JSON tags, returning the YAML text.
+func yamlRoundTrip(t *testing.T, v, out any) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := WriteJSONForContext(WithFormat(context.Background(), "yaml"), &buf, v); err != nil {
+		t.Fatalf("WriteJSONForContext error: %v", err)
+	}
+	var generic any
+	if err := yaml.Unmarshal(buf.Bytes(), &generic); err != nil {
+		t.Fatalf("output is not YAML: %v\n%s", err, buf.String())
+	}
+	data, err := json.Marshal(generic)
+	if err != nil {
+		t.Fatalf("re-encode: %v", err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		t.Fatalf("decode %s: %v", data, err)
+	}
+	return buf.String()
+}
+
+// assertKeyOrder checks that keys appear in text in the given order.
+func assertKeyOrder(t *testing.T, text string, keys ...string) {
+	t.Helper()
+	last := -1
+	for _, k := range keys {
+		i := strings.Index(text, k+":")
+		if i < 0 {
+			t.Fatalf("key %q missing from:\n%s", k, text)
+		}
+		if i < last {
+			t.Errorf("key %q out of struct order in:\n%s", k, text)
+		}
+		last = i
+	}
+}
+
+func TestWriteJSONForContext_YAMLRoundTripBeneficiary(t *testing.T) {
+	want := api.Beneficiary{
+		BeneficiaryID: "ben_123",
+		Nickname:      "Supplier: Acme #1",
+		Beneficiary: api.BeneficiaryDetails{
+			EntityType:  "COMPANY",
+			CompanyName: "Acme Ltd",
+			BankDetails: api.BeneficiaryBankDetails{
+				BankCountryCode:      "US",
+				BankName:             "Chase",
+				AccountName:          "Acme Ltd",
+				AccountNumber:        "000123456789",
+				AccountCurrency:      "USD",
+				AccountRoutingType1:  "aba",
+				AccountRoutingValue1: "021000021",
+			},
+			Address: &api.BeneficiaryAddress{City: "New York", CountryCode: "US"},
+		},
+		PaymentMethods:  []string{"LOCAL"},
+		TransferMethods: []string{"LOCAL", "SWIFT"},
+	}
+
+	var got api.Beneficiary
+	text := yamlRoundTrip(t, want, &got)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+	// Leading zeros and digit-only strings must stay strings.
+	if !strings.Contains(text, `"000123456789"`) && !strings.Contains(text, `'000123456789'`) {
+		t.Errorf("account number not quoted as a string:\n%s", text)
+	}
+	assertKeyOrder(t, text, "id", "nickname", "beneficiary", "payment_methods", "transfer_methods")
+}
+
+func TestWriteJSONForContext_YAMLRoundTripTransfer(t *testing.T) {
+	want := api.Transfer{
+		TransferID:       "tfr_123",
+		BeneficiaryID:    "ben_123",
+		TransferAmount:   json.Number("100.5"),
+		TransferCurrency: "EUR",
+		SourceAmount:     json.Number("110"),
+		SourceCurrency:   "USD",
+		PaymentMethod:    "SWIFT",
+		Status:           "PAID",
+		Reference:        "Invoice 42",
+		Reason:           "payment_to_supplier",
+		CreatedAt:        "2025-01-10T09:30:00Z",
+	}
+
+	var got api.Transfer
+	text := yamlRoundTrip(t, want, &got)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+	assertKeyOrder(t, text, "id", "beneficiary_id", "transfer_amount", "transfer_currency", "source_amount", "status", "created_at")
+}
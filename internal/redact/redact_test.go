@@ -0,0 +1,41 @@
+package redact
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestIDs(t *testing.T) {
+	uuid := "7f687fe6-dcf4-4462-92fa-80335301d9d2"
+	got := IDs("GET /api/v1/transfers/" + uuid + " for acct_AbC123xyz")
+
+	if strings.Contains(got, uuid) || strings.Contains(got, "acct_AbC123xyz") {
+		t.Fatalf("IDs not masked: %q", got)
+	}
+	if !strings.Contains(got, "GET /api/v1/transfers/#") || !strings.Contains(got, " for acct_#") {
+		t.Errorf("unexpected masked form: %q", got)
+	}
+	if again := IDs("GET /api/v1/transfers/" + uuid + " for acct_AbC123xyz"); again != got {
+		t.Errorf("masking not stable: %q vs %q", got, again)
+	}
+	if other := IDs("7f687fe6-dcf4-4462-92fa-80335301d9d3"); strings.Contains(got, other) {
+		t.Errorf("different IDs masked to the same value %q", other)
+	}
+	if plain := IDs("Created transfer for 100.00 USD"); plain != "Created transfer for 100.00 USD" {
+		t.Errorf("text without IDs changed: %q", plain)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	in := "ID: ben_12345678\n"
+	n, err := w.Write([]byte(in))
+	if err != nil || n != len(in) {
+		t.Fatalf("Write() = %d, %v", n, err)
+	}
+	if buf.String() != IDs(in) || strings.Contains(buf.String(), "ben_12345678") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
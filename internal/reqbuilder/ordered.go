@@ -0,0 +1,143 @@
+package reqbuilder
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// keyOrder records the object key order of a JSON value and of the values
+// nested inside it.
+type keyOrder struct {
+	keys     []string
+	children map[string]*keyOrder
+	elems    []*keyOrder
+}
+
+// MarshalOrdered encodes m as JSON with object keys in the order they appear
+// in template, typically the server response m was decoded from, so a
+// read-modify-write keeps the server's field order. Keys missing from
+// template follow in sorted order. Nested objects, including objects inside
+// arrays (matched by index), are ordered the same way.
+func MarshalOrdered(m map[string]interface{}, template []byte) (json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(template))
+	ord, err := parseKeyOrder(dec)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := writeOrdered(&buf, m, ord); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func parseKeyOrder(dec *json.Decoder) (*keyOrder, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return nil, nil
+	}
+	ord := &keyOrder{}
+	switch delim {
+	case '{':
+		ord.children = make(map[string]*keyOrder)
+		for dec.More() {
+			kt, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			k, _ := kt.(string)
+			child, err := parseKeyOrder(dec)
+			if err != nil {
+				return nil, err
+			}
+			ord.keys = append(ord.keys, k)
+			ord.children[k] = child
+		}
+	case '[':
+		for dec.More() {
+			child, err := parseKeyOrder(dec)
+			if err != nil {
+				return nil, err
+			}
+			ord.elems = append(ord.elems, child)
+		}
+	}
+	if _, err := dec.Token(); err != nil { // closing delimiter
+		return nil, err
+	}
+	return ord, nil
+}
+
+func writeOrdered(buf *bytes.Buffer, v interface{}, ord *keyOrder) error {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		var keys []string
+		seen := make(map[string]bool, len(val))
+		if ord != nil {
+			for _, k := range ord.keys {
+				if _, ok := val[k]; ok && !seen[k] {
+					keys = append(keys, k)
+					seen[k] = true
+				}
+			}
+		}
+		var rest []string
+		for k := range val {
+			if !seen[k] {
+				rest = append(rest, k)
+			}
+		}
+		sort.Strings(rest)
+		keys = append(keys, rest...)
+
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			kb, err := json.Marshal(k)
+			if err != nil {
+				return err
+			}
+			buf.Write(kb)
+			buf.WriteByte(':')
+			var child *keyOrder
+			if ord != nil {
+				child = ord.children[k]
+			}
+			if err := writeOrdered(buf, val[k], child); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, item := range val {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			var child *keyOrder
+			if ord != nil && i < len(ord.elems) {
+				child = ord.elems[i]
+			}
+			if err := writeOrdered(buf, item, child); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	default:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+		return nil
+	}
+}
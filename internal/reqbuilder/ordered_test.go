@@ -0,0 +1,27 @@
+package reqbuilder
+
+import "testing"
+
+func TestMarshalOrdered(t *testing.T) {
+	template := []byte(`{"nickname":"old","beneficiary":{"entity_type":"COMPANY","bank_details":{"bank_country_code":"US","account_name":"A"}},"payment_methods":["LOCAL"],"items":[{"z":1,"a":2}]}`)
+	m := map[string]interface{}{
+		"payment_methods": []interface{}{"LOCAL"},
+		"nickname":        "new",
+		"beneficiary": map[string]interface{}{
+			"bank_details": map[string]interface{}{"account_name": "A", "bank_country_code": "US", "swift_code": "X"},
+			"entity_type":  "COMPANY",
+			"address":      map[string]interface{}{"city": "NYC"},
+		},
+		"items":     []interface{}{map[string]interface{}{"a": 2, "z": 1}},
+		"added_new": true,
+	}
+
+	got, err := MarshalOrdered(m, template)
+	if err != nil {
+		t.Fatalf("MarshalOrdered error: %v", err)
+	}
+	want := `{"nickname":"new","beneficiary":{"entity_type":"COMPANY","bank_details":{"bank_country_code":"US","account_name":"A","swift_code":"X"},"address":{"city":"NYC"}},"payment_methods":["LOCAL"],"items":[{"z":1,"a":2}],"added_new":true}`
+	if string(got) != want {
+		t.Errorf("MarshalOrdered =\n%s\nwant\n%s", got, want)
+	}
+}
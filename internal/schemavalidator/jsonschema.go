@@ -0,0 +1,129 @@
+package schemavalidator
+
+import (
+	"strings"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/api"
+)
+
+// JSONSchemaDraft is the $schema URI emitted by ToJSONSchema.
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// ToJSONSchema converts an Airwallex field/rule schema into a JSON Schema
+// document. Dotted field paths become nested objects; required fields are
+// listed in the "required" array of their parent object.
+func ToJSONSchema(schema *api.Schema) map[string]interface{} {
+	root := newJSONSchemaObject()
+	root["$schema"] = JSONSchemaDraft
+	if schema == nil {
+		return root
+	}
+
+	for _, field := range schema.Fields {
+		path := field.Path
+		if path == "" {
+			path = field.Key
+		}
+		if path == "" {
+			continue
+		}
+
+		parts := strings.Split(path, ".")
+		parent := root
+		for _, part := range parts[:len(parts)-1] {
+			props := objectProperties(parent)
+			child, ok := props[part].(map[string]interface{})
+			if !ok {
+				child = newJSONSchemaObject()
+				props[part] = child
+			}
+			if field.Required {
+				addRequired(parent, part)
+			}
+			parent = child
+		}
+
+		name := parts[len(parts)-1]
+		props := objectProperties(parent)
+		if existing, ok := props[name].(map[string]interface{}); ok && existing["properties"] != nil {
+			// Another field already nests under this path; keep the
+			// object and only take over the description.
+			if field.Description != "" {
+				existing["description"] = field.Description
+			}
+		} else {
+			props[name] = jsonSchemaProperty(field)
+		}
+		if field.Required {
+			addRequired(parent, name)
+		}
+	}
+
+	return root
+}
+
+// objectProperties returns node's "properties" map. A node that was added as
+// a leaf property becomes an object when another field nests under its path;
+// its description is kept and its value constraints dropped.
+func objectProperties(node map[string]interface{}) map[string]interface{} {
+	if props, ok := node["properties"].(map[string]interface{}); ok {
+		return props
+	}
+	for _, key := range []string{"pattern", "enum", "minLength", "maxLength"} {
+		delete(node, key)
+	}
+	props := map[string]interface{}{}
+	node["type"] = "object"
+	node["properties"] = props
+	return props
+}
+
+func newJSONSchemaObject() map[string]interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	}
+}
+
+func addRequired(obj map[string]interface{}, name string) {
+	required, _ := obj["required"].([]string)
+	for _, existing := range required {
+		if existing == name {
+			return
+		}
+	}
+	obj["required"] = append(required, name)
+}
+
+func jsonSchemaProperty(field api.SchemaField) map[string]interface{} {
+	prop := map[string]interface{}{
+		"type": jsonSchemaType(field.Rule.Type),
+	}
+	if field.Description != "" {
+		prop["description"] = field.Description
+	}
+	if field.Rule.Pattern != "" {
+		prop["pattern"] = field.Rule.Pattern
+	}
+	if len(field.Rule.Enum) > 0 {
+		prop["enum"] = []string(field.Rule.Enum)
+	}
+	if field.Rule.MinLength > 0 {
+		prop["minLength"] = field.Rule.MinLength
+	}
+	if field.Rule.MaxLength > 0 {
+		prop["maxLength"] = field.Rule.MaxLength
+	}
+	return prop
+}
+
+// jsonSchemaType maps Airwallex rule types onto JSON Schema types, treating
+// anything unrecognised as a string (the API sends most values as strings).
+func jsonSchemaType(ruleType string) string {
+	switch strings.ToLower(ruleType) {
+	case "number", "integer", "boolean", "object", "array":
+		return strings.ToLower(ruleType)
+	default:
+		return "string"
+	}
+}
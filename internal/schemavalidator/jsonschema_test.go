@@ -0,0 +1,85 @@
+package schemavalidator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/salmonumbrella/airwallex-cli/internal/api"
+)
+
+func TestToJSONSchema(t *testing.T) {
+	schema := &api.Schema{
+		Fields: []api.SchemaField{
+			{
+				Key:      "account_number",
+				Path:     "beneficiary.bank_details.account_number",
+				Required: true,
+				Rule:     api.SchemaFieldRule{Type: "string", Pattern: `^\d{4,17}$`, MinLength: 4, MaxLength: 17},
+			},
+			{
+				Key:  "account_routing_type1",
+				Path: "beneficiary.bank_details.account_routing_type1",
+				Rule: api.SchemaFieldRule{Type: "string", Enum: []string{"aba", "ach"}},
+			},
+			{Key: "nickname", Description: "Display name"},
+		},
+	}
+
+	got := ToJSONSchema(schema)
+
+	if got["$schema"] != JSONSchemaDraft || got["type"] != "object" {
+		t.Fatalf("root = %v, want object with $schema", got)
+	}
+	if req, _ := got["required"].([]string); !reflect.DeepEqual(req, []string{"beneficiary"}) {
+		t.Errorf("root required = %v, want [beneficiary]", got["required"])
+	}
+
+	props := got["properties"].(map[string]interface{})
+	nick := props["nickname"].(map[string]interface{})
+	if nick["type"] != "string" || nick["description"] != "Display name" {
+		t.Errorf("nickname = %v", nick)
+	}
+
+	bankDetails := props["beneficiary"].(map[string]interface{})["properties"].(map[string]interface{})["bank_details"].(map[string]interface{})
+	if req, _ := bankDetails["required"].([]string); !reflect.DeepEqual(req, []string{"account_number"}) {
+		t.Errorf("bank_details required = %v, want [account_number]", bankDetails["required"])
+	}
+
+	bankProps := bankDetails["properties"].(map[string]interface{})
+	acct := bankProps["account_number"].(map[string]interface{})
+	if acct["pattern"] != `^\d{4,17}$` || acct["minLength"] != 4 || acct["maxLength"] != 17 {
+		t.Errorf("account_number = %v", acct)
+	}
+	routing := bankProps["account_routing_type1"].(map[string]interface{})
+	if !reflect.DeepEqual(routing["enum"], []string{"aba", "ach"}) {
+		t.Errorf("account_routing_type1 enum = %v", routing["enum"])
+	}
+}
+
+func TestToJSONSchema_PrefixOverlappingPaths(t *testing.T) {
+	for _, order := range [][]int{{0, 1}, {1, 0}} {
+		fields := []api.SchemaField{
+			{Key: "address", Path: "beneficiary.address", Description: "Address", Rule: api.SchemaFieldRule{Type: "string", MaxLength: 200}},
+			{Key: "city", Path: "beneficiary.address.city", Required: true, Rule: api.SchemaFieldRule{Type: "string"}},
+		}
+		schema := &api.Schema{Fields: []api.SchemaField{fields[order[0]], fields[order[1]]}}
+
+		got := ToJSONSchema(schema)
+
+		beneficiary := got["properties"].(map[string]interface{})["beneficiary"].(map[string]interface{})
+		address := beneficiary["properties"].(map[string]interface{})["address"].(map[string]interface{})
+		if address["type"] != "object" || address["description"] != "Address" {
+			t.Errorf("order %v: address = %v, want object keeping the description", order, address)
+		}
+		if _, ok := address["maxLength"]; ok {
+			t.Errorf("order %v: address object kept the leaf maxLength: %v", order, address)
+		}
+		city, _ := address["properties"].(map[string]interface{})["city"].(map[string]interface{})
+		if city["type"] != "string" {
+			t.Errorf("order %v: city = %v, want string property", order, city)
+		}
+		if req, _ := address["required"].([]string); !reflect.DeepEqual(req, []string{"city"}) {
+			t.Errorf("order %v: address required = %v, want [city]", order, address["required"])
+		}
+	}
+}
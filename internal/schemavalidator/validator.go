@@ -3,6 +3,7 @@ package schemavalidator
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/salmonumbrella/airwallex-cli/internal/api"
 )
@@ -41,6 +42,60 @@ func Validate(schema *api.Schema, provided map[string]string) ([]MissingField, e
 	return missing, nil
 }
 
+// InvalidField represents a provided field whose value breaks its schema rule
+type InvalidField struct {
+	Key  string
+	Path string
+	Err  error
+}
+
+// ValidationError lists every missing and invalid field found in one pass
+type ValidationError struct {
+	Missing []MissingField
+	Invalid []InvalidField
+}
+
+func (e *ValidationError) Error() string {
+	return strings.TrimSpace(FormatMissingFields(e.Missing) + FormatInvalidFields(e.Invalid))
+}
+
+// ValidateFields checks required presence, value patterns, and allowed enum
+// values for every schema field, returning a nil ValidationError or one
+// listing all problems
+func ValidateFields(schema *api.Schema, provided map[string]string) (*ValidationError, error) {
+	missing, err := Validate(schema, provided)
+	if err != nil {
+		return nil, err
+	}
+
+	var invalid []InvalidField
+	for _, field := range schema.Fields {
+		if field.Rule.Pattern == "" && len(field.Rule.Enum) == 0 {
+			continue
+		}
+		path := field.Path
+		if path == "" {
+			path = field.Key
+		}
+		value, ok := provided[path]
+		if !ok || value == "" {
+			continue
+		}
+		err := ValidateEnum(value, field.Rule.Enum)
+		if err == nil {
+			err = ValidatePattern(value, field.Rule.Pattern)
+		}
+		if err != nil {
+			invalid = append(invalid, InvalidField{Key: field.Key, Path: path, Err: err})
+		}
+	}
+
+	if len(missing) == 0 && len(invalid) == 0 {
+		return nil, nil
+	}
+	return &ValidationError{Missing: missing, Invalid: invalid}, nil
+}
+
 // ValidatePattern checks if a value matches the schema's pattern
 func ValidatePattern(value, pattern string) error {
 	if pattern == "" {
@@ -59,6 +114,20 @@ func ValidatePattern(value, pattern string) error {
 	return nil
 }
 
+// ValidateEnum checks that a value is one of the schema's allowed values,
+// ignoring case. An empty enum allows any value.
+func ValidateEnum(value string, enum []string) error {
+	if len(enum) == 0 {
+		return nil
+	}
+	for _, allowed := range enum {
+		if strings.EqualFold(value, allowed) {
+			return nil
+		}
+	}
+	return fmt.Errorf("value %q is not allowed (valid: %s)", value, strings.Join(enum, ", "))
+}
+
 // FormatMissingFields returns a human-readable error message
 func FormatMissingFields(missing []MissingField) string {
 	if len(missing) == 0 {
@@ -75,3 +144,16 @@ func FormatMissingFields(missing []MissingField) string {
 	}
 	return msg
 }
+
+// FormatInvalidFields returns a human-readable error message
+func FormatInvalidFields(invalid []InvalidField) string {
+	if len(invalid) == 0 {
+		return ""
+	}
+
+	msg := "invalid fields:\n"
+	for _, f := range invalid {
+		msg += fmt.Sprintf("  - %s: %v\n", f.Key, f.Err)
+	}
+	return msg
+}
@@ -156,3 +156,95 @@ func TestFormatMissingFields_WithoutDescription(t *testing.T) {
 		t.Errorf("expected result to contain 'account_name', got: %s", result)
 	}
 }
+
+func TestValidateFields_ReportsAllProblems(t *testing.T) {
+	schema := &api.Schema{
+		Fields: []api.SchemaField{
+			{Key: "account_name", Path: "beneficiary.bank_details.account_name", Required: true},
+			{Key: "account_number", Path: "beneficiary.bank_details.account_number", Required: true},
+			{Key: "swift_code", Path: "beneficiary.bank_details.swift_code", Required: true, Rule: api.SchemaFieldRule{Pattern: "^[A-Z]{6}[A-Z0-9]{2}([A-Z0-9]{3})?$"}},
+		},
+	}
+
+	provided := map[string]string{
+		"beneficiary.bank_details.swift_code": "bad-swift",
+	}
+
+	verr, err := ValidateFields(schema, provided)
+	if err != nil {
+		t.Fatalf("ValidateFields() error: %v", err)
+	}
+	if verr == nil {
+		t.Fatal("expected a validation error")
+	}
+	if len(verr.Missing) != 2 || len(verr.Invalid) != 1 {
+		t.Fatalf("got %d missing and %d invalid, want 2 and 1", len(verr.Missing), len(verr.Invalid))
+	}
+
+	msg := verr.Error()
+	for _, want := range []string{"missing required fields", "account_name", "account_number", "invalid fields", "swift_code", `"bad-swift"`} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("error missing %q:\n%s", want, msg)
+		}
+	}
+}
+
+func TestValidateFields_Valid(t *testing.T) {
+	schema := &api.Schema{
+		Fields: []api.SchemaField{
+			{Key: "swift_code", Path: "beneficiary.bank_details.swift_code", Required: true, Rule: api.SchemaFieldRule{Pattern: "^[A-Z]{6}[A-Z0-9]{2}([A-Z0-9]{3})?$"}},
+		},
+	}
+
+	if verr, err := ValidateFields(schema, map[string]string{"beneficiary.bank_details.swift_code": "DEUTDEFF"}); verr != nil || err != nil {
+		t.Errorf("unexpected error: %v, %v", verr, err)
+	}
+}
+
+func TestValidateFields_Enum(t *testing.T) {
+	schema := &api.Schema{
+		Fields: []api.SchemaField{
+			{Key: "account_category", Path: "beneficiary.bank_details.account_category", Rule: api.SchemaFieldRule{Enum: []string{"Checking", "Savings"}}},
+			{Key: "nickname", Path: "nickname"},
+		},
+	}
+
+	if verr, err := ValidateFields(schema, map[string]string{
+		"beneficiary.bank_details.account_category": "Savings",
+		"nickname": "anything goes",
+	}); verr != nil || err != nil {
+		t.Errorf("unexpected error for allowed value: %v, %v", verr, err)
+	}
+
+	verr, err := ValidateFields(schema, map[string]string{"beneficiary.bank_details.account_category": "Brokerage"})
+	if err != nil {
+		t.Fatalf("ValidateFields() error: %v", err)
+	}
+	if verr == nil || len(verr.Invalid) != 1 {
+		t.Fatalf("expected one invalid field, got %v", verr)
+	}
+	msg := verr.Error()
+	for _, want := range []string{"account_category", `"Brokerage"`, "Checking, Savings"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("error missing %q:\n%s", want, msg)
+		}
+	}
+}
+
+func TestValidateEnum_IgnoresCase(t *testing.T) {
+	enum := []string{"Checking", "Savings"}
+	for _, value := range []string{"Checking", "checking", "SAVINGS"} {
+		if err := ValidateEnum(value, enum); err != nil {
+			t.Errorf("ValidateEnum(%q) error: %v", value, err)
+		}
+	}
+	if err := ValidateEnum("Brokerage", enum); err == nil {
+		t.Error("expected an error for a value outside the enum")
+	}
+}
+
+func TestValidateEnum_EmptyEnumAllowsAnything(t *testing.T) {
+	if err := ValidateEnum("anything", nil); err != nil {
+		t.Errorf("expected no error without an enum, got: %v", err)
+	}
+}
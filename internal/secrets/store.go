@@ -36,6 +36,7 @@ type Credentials struct {
 	ClientID  string    `json:"client_id"`
 	APIKey    string    `json:"-"`
 	AccountID string    `json:"account_id,omitempty"`
+	Env       string    `json:"env,omitempty"` // "" or "production", or "demo"
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -43,6 +44,7 @@ type storedCredentials struct {
 	ClientID  string    `json:"client_id"`
 	APIKey    string    `json:"api_key"`
 	AccountID string    `json:"account_id,omitempty"`
+	Env       string    `json:"env,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -79,6 +81,7 @@ func (s *KeyringStore) Set(name string, creds Credentials) error {
 		ClientID:  creds.ClientID,
 		APIKey:    creds.APIKey,
 		AccountID: creds.AccountID,
+		Env:       creds.Env,
 		CreatedAt: creds.CreatedAt,
 	})
 	if err != nil {
@@ -110,6 +113,7 @@ func (s *KeyringStore) Get(name string) (Credentials, error) {
 		ClientID:  stored.ClientID,
 		APIKey:    stored.APIKey,
 		AccountID: stored.AccountID,
+		Env:       stored.Env,
 		CreatedAt: stored.CreatedAt,
 	}
 
@@ -54,6 +54,16 @@ func FromContext(ctx context.Context) *UI {
 	return New("auto")
 }
 
+// WithWriters returns a copy of u that writes to out and err, keeping the
+// color decision made for the original terminal streams.
+func (u *UI) WithWriters(out, err io.Writer) *UI {
+	return &UI{
+		out:   termenv.NewOutput(out),
+		err:   termenv.NewOutput(err),
+		color: u.color,
+	}
+}
+
 func (u *UI) Out() io.Writer {
 	return u.out
 }